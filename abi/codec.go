@@ -0,0 +1,140 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// EncodeTopLevel encodes the value as a whole argument of the provided type
+func EncodeTopLevel(t Type, value interface{}) ([]byte, error) {
+	if t == nil {
+		return nil, ErrNilType
+	}
+
+	buffer := &bytes.Buffer{}
+	err := t.encodeTopLevel(buffer, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// EncodeNested encodes the value of the provided type as it is encoded inside another value
+func EncodeNested(t Type, value interface{}) ([]byte, error) {
+	if t == nil {
+		return nil, ErrNilType
+	}
+
+	buffer := &bytes.Buffer{}
+	err := t.encodeNested(buffer, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// DecodeTopLevel decodes a whole argument of the provided type
+func DecodeTopLevel(t Type, data []byte) (interface{}, error) {
+	if t == nil {
+		return nil, ErrNilType
+	}
+
+	return t.decodeTopLevel(data)
+}
+
+// DecodeNested decodes a nested encoded value of the provided type, requiring all the data to be consumed
+func DecodeNested(t Type, data []byte) (interface{}, error) {
+	if t == nil {
+		return nil, ErrNilType
+	}
+
+	return decodeWhole(t, data)
+}
+
+// EncodeArguments top-level encodes every value as the type on the same position. The result can be appended to a
+// transaction data builder or used as the arguments of a VM input
+func EncodeArguments(types []Type, values []interface{}) ([][]byte, error) {
+	if len(types) != len(values) {
+		return nil, fmt.Errorf("%w: %d types, %d values", ErrArgumentsCountMismatch, len(types), len(values))
+	}
+
+	arguments := make([][]byte, 0, len(values))
+	for i, value := range values {
+		argument, err := EncodeTopLevel(types[i], value)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		arguments = append(arguments, argument)
+	}
+
+	return arguments, nil
+}
+
+// DecodeArguments top-level decodes every argument as the type on the same position. It can be used on the arguments
+// returned by the call arguments parser or on the return data of a VM output
+func DecodeArguments(types []Type, arguments [][]byte) ([]interface{}, error) {
+	if len(types) != len(arguments) {
+		return nil, fmt.Errorf("%w: %d types, %d arguments", ErrArgumentsCountMismatch, len(types), len(arguments))
+	}
+
+	values := make([]interface{}, 0, len(arguments))
+	for i, argument := range arguments {
+		value, err := DecodeTopLevel(types[i], argument)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+type nestedReader struct {
+	data   []byte
+	offset int
+}
+
+func newNestedReader(data []byte) *nestedReader {
+	return &nestedReader{data: data}
+}
+
+func (reader *nestedReader) read(numBytes int) ([]byte, error) {
+	if numBytes < 0 || numBytes > len(reader.data)-reader.offset {
+		return nil, ErrUnexpectedEndOfData
+	}
+
+	data := reader.data[reader.offset : reader.offset+numBytes]
+	reader.offset += numBytes
+
+	return data, nil
+}
+
+func (reader *nestedReader) readLength() (int, error) {
+	prefix, err := reader.read(lengthPrefixSize)
+	if err != nil {
+		return 0, err
+	}
+
+	length := binary.BigEndian.Uint32(prefix)
+	if uint64(length) > uint64(len(reader.data)-reader.offset) {
+		return 0, ErrUnexpectedEndOfData
+	}
+
+	return int(length), nil
+}
+
+func (reader *nestedReader) readLengthPrefixed() ([]byte, error) {
+	length, err := reader.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	return reader.read(length)
+}
+
+func (reader *nestedReader) isEmpty() bool {
+	return reader.offset == len(reader.data)
+}
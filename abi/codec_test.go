@@ -0,0 +1,197 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAddress = bytes.Repeat([]byte{0xab}, AddressLength)
+
+func TestCodec_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		valueType Type
+		value     interface{}
+		topLevel  string
+		nested    string
+	}{
+		{name: "u8", valueType: U8, value: uint8(5), topLevel: "05", nested: "05"},
+		{name: "u8 zero", valueType: U8, value: uint8(0), topLevel: "", nested: "00"},
+		{name: "u16", valueType: U16, value: uint16(0x102), topLevel: "0102", nested: "0102"},
+		{name: "u32", valueType: U32, value: uint32(1), topLevel: "01", nested: "00000001"},
+		{name: "u64", valueType: U64, value: uint64(0xffffffffffffffff), topLevel: "ffffffffffffffff", nested: "ffffffffffffffff"},
+		{name: "i8 negative", valueType: I8, value: int8(-1), topLevel: "ff", nested: "ff"},
+		{name: "i16 positive with sign bit", valueType: I16, value: int16(128), topLevel: "0080", nested: "0080"},
+		{name: "i32 negative", valueType: I32, value: int32(-129), topLevel: "ff7f", nested: "ffffff7f"},
+		{name: "i64 zero", valueType: I64, value: int64(0), topLevel: "", nested: "0000000000000000"},
+		{name: "big uint", valueType: BigUint, value: big.NewInt(256), topLevel: "0100", nested: "000000020100"},
+		{name: "big uint zero", valueType: BigUint, value: big.NewInt(0), topLevel: "", nested: "00000000"},
+		{name: "big int negative", valueType: BigInt, value: big.NewInt(-128), topLevel: "80", nested: "0000000180"},
+		{name: "big int positive with sign bit", valueType: BigInt, value: big.NewInt(255), topLevel: "00ff", nested: "0000000200ff"},
+		{name: "bool true", valueType: Bool, value: true, topLevel: "01", nested: "01"},
+		{name: "bool false", valueType: Bool, value: false, topLevel: "", nested: "00"},
+		{name: "address", valueType: Address, value: testAddress, topLevel: hex.EncodeToString(testAddress), nested: hex.EncodeToString(testAddress)},
+		{name: "token identifier", valueType: TokenIdentifier, value: "TKN-01", topLevel: "544b4e2d3031", nested: "00000006544b4e2d3031"},
+		{name: "bytes", valueType: Bytes, value: []byte{1, 2}, topLevel: "0102", nested: "000000020102"},
+		{name: "option none", valueType: Option(U16), value: nil, topLevel: "", nested: "00"},
+		{name: "option some", valueType: Option(U16), value: uint16(7), topLevel: "010007", nested: "010007"},
+		{name: "list", valueType: List(U8), value: []interface{}{uint8(1), uint8(2)}, topLevel: "0102", nested: "000000020102"},
+		{name: "empty list", valueType: List(BigUint), value: []interface{}{}, topLevel: "", nested: "00000000"},
+		{name: "array", valueType: Array(2, U16), value: []interface{}{uint16(1), uint16(2)}, topLevel: "00010002", nested: "00010002"},
+		{name: "tuple", valueType: Tuple(U8, BigUint), value: []interface{}{uint8(1), big.NewInt(2)}, topLevel: "010000000102", nested: "010000000102"},
+		{
+			name:      "struct",
+			valueType: Struct("Payment", StructField{Name: "token", Type: TokenIdentifier}, StructField{Name: "nonce", Type: U64}, StructField{Name: "amount", Type: BigUint}),
+			value:     map[string]interface{}{"token": "A", "nonce": uint64(1), "amount": big.NewInt(10)},
+			topLevel:  "0000000141" + "0000000000000001" + "000000010a",
+			nested:    "0000000141" + "0000000000000001" + "000000010a",
+		},
+		{
+			name:      "list of options",
+			valueType: List(Option(U8)),
+			value:     []interface{}{uint8(3), nil},
+			topLevel:  "010300",
+			nested:    "00000002010300",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			topLevel, err := EncodeTopLevel(tt.valueType, tt.value)
+			require.Nil(t, err)
+			assert.Equal(t, tt.topLevel, hex.EncodeToString(topLevel))
+
+			nested, err := EncodeNested(tt.valueType, tt.value)
+			require.Nil(t, err)
+			assert.Equal(t, tt.nested, hex.EncodeToString(nested))
+
+			decoded, err := DecodeTopLevel(tt.valueType, topLevel)
+			require.Nil(t, err)
+			assert.Equal(t, tt.value, decoded)
+
+			decoded, err = DecodeNested(tt.valueType, nested)
+			require.Nil(t, err)
+			assert.Equal(t, tt.value, decoded)
+		})
+	}
+}
+
+func TestCodec_Names(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Option<List<Address>>", Option(List(Address)).Name())
+	assert.Equal(t, "array4<u8>", Array(4, U8).Name())
+	assert.Equal(t, "tuple<i32,BigInt,bool>", Tuple(I32, BigInt, Bool).Name())
+	assert.Equal(t, "Payment", Struct("Payment").Name())
+}
+
+func TestCodec_EncodeErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := EncodeTopLevel(nil, uint8(1))
+	assert.Equal(t, ErrNilType, err)
+
+	_, err = EncodeTopLevel(U16, uint8(1))
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+	assert.Equal(t, "invalid value for u16: uint8", err.Error())
+
+	_, err = EncodeTopLevel(BigUint, big.NewInt(-1))
+	assert.Equal(t, ErrNegativeBigUint, err)
+
+	_, err = EncodeTopLevel(BigInt, (*big.Int)(nil))
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+
+	_, err = EncodeTopLevel(Address, []byte("short"))
+	assert.Equal(t, ErrInvalidAddressLength, err)
+
+	_, err = EncodeTopLevel(Array(3, U8), []interface{}{uint8(1)})
+	assert.True(t, errors.Is(err, ErrInvalidArrayLength))
+
+	_, err = EncodeTopLevel(Struct("S", StructField{Name: "a", Type: U8}), map[string]interface{}{})
+	assert.True(t, errors.Is(err, ErrMissingStructField))
+
+	_, err = EncodeTopLevel(Struct("S", StructField{Name: "a", Type: U8}), map[string]interface{}{"a": "text"})
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+	assert.Contains(t, err.Error(), "S.a: ")
+}
+
+func TestCodec_DecodeErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := DecodeTopLevel(U8, []byte{1, 0})
+	assert.True(t, errors.Is(err, ErrValueOutOfRange))
+
+	_, err = DecodeTopLevel(I16, []byte{1, 2, 3})
+	assert.True(t, errors.Is(err, ErrValueOutOfRange))
+
+	_, err = DecodeTopLevel(Bool, []byte{2})
+	assert.Equal(t, ErrInvalidBool, err)
+
+	_, err = DecodeTopLevel(Address, []byte{1})
+	assert.Equal(t, ErrInvalidAddressLength, err)
+
+	_, err = DecodeNested(U32, []byte{1, 2})
+	assert.Equal(t, ErrUnexpectedEndOfData, err)
+
+	_, err = DecodeNested(BigUint, []byte{0, 0, 0, 9, 1})
+	assert.Equal(t, ErrUnexpectedEndOfData, err)
+
+	_, err = DecodeNested(List(U8), []byte{0xff, 0xff, 0xff, 0xff})
+	assert.Equal(t, ErrUnexpectedEndOfData, err)
+
+	_, err = DecodeNested(U8, []byte{1, 2})
+	assert.True(t, errors.Is(err, ErrTrailingData))
+
+	_, err = DecodeTopLevel(Option(U8), []byte{2, 1})
+	assert.Equal(t, ErrInvalidOptionTag, err)
+
+	_, err = DecodeTopLevel(Tuple(U8, U8), []byte{1, 2, 3})
+	assert.True(t, errors.Is(err, ErrTrailingData))
+}
+
+func TestDecodeArguments_FromParsedDataAndReturnData(t *testing.T) {
+	t.Parallel()
+
+	types := []Type{TokenIdentifier, U64, BigUint, Option(Address)}
+	values := []interface{}{"TKN-01", uint64(3), big.NewInt(1000), testAddress}
+
+	arguments, err := EncodeArguments(types, values)
+	require.Nil(t, err)
+
+	data := "transfer"
+	for _, argument := range arguments {
+		data += "@" + hex.EncodeToString(argument)
+	}
+	function, parsedArguments, err := parsers.NewCallArgsParser().ParseData(data)
+	require.Nil(t, err)
+	assert.Equal(t, "transfer", function)
+
+	decoded, err := DecodeArguments(types, parsedArguments)
+	require.Nil(t, err)
+	assert.Equal(t, values, decoded)
+
+	vmOutput := &vmcommon.VMOutput{ReturnData: arguments}
+	decoded, err = DecodeArguments(types, vmOutput.ReturnData)
+	require.Nil(t, err)
+	assert.Equal(t, values, decoded)
+
+	_, err = DecodeArguments(types[:1], arguments)
+	assert.True(t, errors.Is(err, ErrArgumentsCountMismatch))
+
+	_, err = EncodeArguments(types, []interface{}{"TKN-01", "3", big.NewInt(1), nil})
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+	assert.Contains(t, err.Error(), "argument 1: ")
+}
@@ -0,0 +1,448 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// StructField is a named field of a struct type
+type StructField struct {
+	Name string
+	Type Type
+}
+
+type optionType struct {
+	inner Type
+}
+
+// Option creates the type of the optional values of the inner type
+func Option(inner Type) Type {
+	return &optionType{inner: inner}
+}
+
+// Name returns the ABI name of the type
+func (t *optionType) Name() string {
+	return fmt.Sprintf("Option<%s>", t.inner.Name())
+}
+
+func (t *optionType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	if value == nil {
+		buffer.WriteByte(0)
+		return nil
+	}
+
+	buffer.WriteByte(1)
+	return t.inner.encodeNested(buffer, value)
+}
+
+func (t *optionType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	return t.encodeNested(buffer, value)
+}
+
+func (t *optionType) decodeNested(reader *nestedReader) (interface{}, error) {
+	tag, err := reader.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag[0] {
+	case 0:
+		return nil, nil
+	case 1:
+		return t.inner.decodeNested(reader)
+	default:
+		return nil, ErrInvalidOptionTag
+	}
+}
+
+func (t *optionType) decodeTopLevel(data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	return decodeWhole(t, data)
+}
+
+type listType struct {
+	inner Type
+}
+
+// List creates the type of the variable length lists of the inner type
+func List(inner Type) Type {
+	return &listType{inner: inner}
+}
+
+// Name returns the ABI name of the type
+func (t *listType) Name() string {
+	return fmt.Sprintf("List<%s>", t.inner.Name())
+}
+
+func (t *listType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return invalidValueError(t, value)
+	}
+
+	prefix := make([]byte, lengthPrefixSize)
+	binary.BigEndian.PutUint32(prefix, uint32(len(items)))
+	buffer.Write(prefix)
+
+	return encodeItemsNested(buffer, repeatType(t.inner, len(items)), items)
+}
+
+func (t *listType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return invalidValueError(t, value)
+	}
+
+	return encodeItemsNested(buffer, repeatType(t.inner, len(items)), items)
+}
+
+func (t *listType) decodeNested(reader *nestedReader) (interface{}, error) {
+	numItems, err := reader.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, 0)
+	for i := 0; i < numItems; i++ {
+		item, errDecode := t.inner.decodeNested(reader)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (t *listType) decodeTopLevel(data []byte) (interface{}, error) {
+	reader := newNestedReader(data)
+	items := make([]interface{}, 0)
+	for !reader.isEmpty() {
+		item, err := t.inner.decodeNested(reader)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+type arrayType struct {
+	length int
+	inner  Type
+}
+
+// Array creates the type of the fixed length arrays of the inner type
+func Array(length int, inner Type) Type {
+	return &arrayType{length: length, inner: inner}
+}
+
+// Name returns the ABI name of the type
+func (t *arrayType) Name() string {
+	return fmt.Sprintf("array%d<%s>", t.length, t.inner.Name())
+}
+
+func (t *arrayType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return invalidValueError(t, value)
+	}
+	if len(items) != t.length {
+		return fmt.Errorf("%w for %s: %d", ErrInvalidArrayLength, t.Name(), len(items))
+	}
+
+	return encodeItemsNested(buffer, repeatType(t.inner, t.length), items)
+}
+
+func (t *arrayType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	return t.encodeNested(buffer, value)
+}
+
+func (t *arrayType) decodeNested(reader *nestedReader) (interface{}, error) {
+	return decodeItemsNested(reader, repeatType(t.inner, t.length))
+}
+
+func (t *arrayType) decodeTopLevel(data []byte) (interface{}, error) {
+	return decodeWhole(t, data)
+}
+
+type tupleType struct {
+	items []Type
+}
+
+// Tuple creates the type of the tuples holding values of the provided types
+func Tuple(items ...Type) Type {
+	return &tupleType{items: items}
+}
+
+// Name returns the ABI name of the type
+func (t *tupleType) Name() string {
+	names := make([]string, 0, len(t.items))
+	for _, item := range t.items {
+		names = append(names, item.Name())
+	}
+
+	return fmt.Sprintf("tuple<%s>", strings.Join(names, ","))
+}
+
+func (t *tupleType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return invalidValueError(t, value)
+	}
+	if len(items) != len(t.items) {
+		return fmt.Errorf("%w for %s: %d", ErrInvalidArrayLength, t.Name(), len(items))
+	}
+
+	return encodeItemsNested(buffer, t.items, items)
+}
+
+func (t *tupleType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	return t.encodeNested(buffer, value)
+}
+
+func (t *tupleType) decodeNested(reader *nestedReader) (interface{}, error) {
+	return decodeItemsNested(reader, t.items)
+}
+
+func (t *tupleType) decodeTopLevel(data []byte) (interface{}, error) {
+	return decodeWhole(t, data)
+}
+
+type structType struct {
+	name   string
+	fields []StructField
+}
+
+// Struct creates a named struct type holding the provided fields, encoded in the order they are provided
+func Struct(name string, fields ...StructField) Type {
+	return &structType{name: name, fields: fields}
+}
+
+// Name returns the ABI name of the type
+func (t *structType) Name() string {
+	return t.name
+}
+
+func (t *structType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return invalidValueError(t, value)
+	}
+
+	for _, field := range t.fields {
+		fieldValue, exists := fields[field.Name]
+		if !exists {
+			return fmt.Errorf("%w for %s: %s", ErrMissingStructField, t.name, field.Name)
+		}
+
+		err := field.Type.encodeNested(buffer, fieldValue)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.name, field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (t *structType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	return t.encodeNested(buffer, value)
+}
+
+func (t *structType) decodeNested(reader *nestedReader) (interface{}, error) {
+	fields := make(map[string]interface{}, len(t.fields))
+	for _, field := range t.fields {
+		fieldValue, err := field.Type.decodeNested(reader)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.name, field.Name, err)
+		}
+		fields[field.Name] = fieldValue
+	}
+
+	return fields, nil
+}
+
+func (t *structType) decodeTopLevel(data []byte) (interface{}, error) {
+	return decodeWhole(t, data)
+}
+
+func repeatType(t Type, count int) []Type {
+	types := make([]Type, count)
+	for i := range types {
+		types[i] = t
+	}
+
+	return types
+}
+
+func encodeItemsNested(buffer *bytes.Buffer, types []Type, items []interface{}) error {
+	for i, item := range items {
+		err := types[i].encodeNested(buffer, item)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decodeItemsNested(reader *nestedReader, types []Type) ([]interface{}, error) {
+	items := make([]interface{}, 0, len(types))
+	for _, t := range types {
+		item, err := t.decodeNested(reader)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// decodeWhole decodes the nested encoded value, requiring all the data to be consumed
+func decodeWhole(t Type, data []byte) (interface{}, error) {
+	reader := newNestedReader(data)
+	value, err := t.decodeNested(reader)
+	if err != nil {
+		return nil, err
+	}
+	if !reader.isEmpty() {
+		return nil, fmt.Errorf("%w for %s", ErrTrailingData, t.Name())
+	}
+
+	return value, nil
+}
+
+// EnumVariant is a variant of an enum type. A variant without fields is encoded as its discriminant only
+type EnumVariant struct {
+	Name         string
+	Discriminant uint8
+	Fields       []StructField
+}
+
+// EnumValue is a value of an enum type. Fields is ignored when encoding a variant without fields
+type EnumValue struct {
+	Name         string
+	Discriminant uint8
+	Fields       map[string]interface{}
+}
+
+type enumType struct {
+	name     string
+	variants []EnumVariant
+}
+
+// Enum creates a named enum type holding the provided variants
+func Enum(name string, variants ...EnumVariant) Type {
+	return &enumType{name: name, variants: variants}
+}
+
+// Name returns the ABI name of the type
+func (t *enumType) Name() string {
+	return t.name
+}
+
+func (t *enumType) variant(discriminant uint8) (EnumVariant, error) {
+	for _, variant := range t.variants {
+		if variant.Discriminant == discriminant {
+			return variant, nil
+		}
+	}
+
+	return EnumVariant{}, fmt.Errorf("%w for %s: %d", ErrInvalidEnumDiscriminant, t.name, discriminant)
+}
+
+func (t *enumType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	enumValue, ok := value.(*EnumValue)
+	if !ok || enumValue == nil {
+		return invalidValueError(t, value)
+	}
+
+	variant, err := t.variant(enumValue.Discriminant)
+	if err != nil {
+		return err
+	}
+
+	buffer.WriteByte(variant.Discriminant)
+	if len(variant.Fields) == 0 {
+		return nil
+	}
+
+	return Struct(t.name+"::"+variant.Name, variant.Fields...).encodeNested(buffer, enumValue.Fields)
+}
+
+func (t *enumType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	enumValue, ok := value.(*EnumValue)
+	if !ok || enumValue == nil {
+		return invalidValueError(t, value)
+	}
+
+	variant, err := t.variant(enumValue.Discriminant)
+	if err != nil {
+		return err
+	}
+	if len(variant.Fields) == 0 {
+		return U8.encodeTopLevel(buffer, variant.Discriminant)
+	}
+
+	return t.encodeNested(buffer, value)
+}
+
+func (t *enumType) decodeNested(reader *nestedReader) (interface{}, error) {
+	discriminant, err := reader.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := t.variant(discriminant[0])
+	if err != nil {
+		return nil, err
+	}
+
+	enumValue := &EnumValue{
+		Name:         variant.Name,
+		Discriminant: variant.Discriminant,
+	}
+	if len(variant.Fields) == 0 {
+		return enumValue, nil
+	}
+
+	fields, err := Struct(t.name+"::"+variant.Name, variant.Fields...).decodeNested(reader)
+	if err != nil {
+		return nil, err
+	}
+	enumValue.Fields = fields.(map[string]interface{})
+
+	return enumValue, nil
+}
+
+func (t *enumType) decodeTopLevel(data []byte) (interface{}, error) {
+	if len(data) > 1 {
+		return decodeWhole(t, data)
+	}
+
+	discriminant, err := U8.decodeTopLevel(data)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := t.variant(discriminant.(uint8))
+	if err != nil {
+		return nil, err
+	}
+	if len(variant.Fields) > 0 {
+		return decodeWhole(t, data)
+	}
+
+	return &EnumValue{Name: variant.Name, Discriminant: variant.Discriminant}, nil
+}
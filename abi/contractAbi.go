@@ -0,0 +1,522 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/parsers"
+)
+
+const (
+	structKind = "struct"
+	enumKind   = "enum"
+)
+
+var primitiveTypes = map[string]Type{
+	"u8":              U8,
+	"u16":             U16,
+	"u32":             U32,
+	"u64":             U64,
+	"usize":           U32,
+	"i8":              I8,
+	"i16":             I16,
+	"i32":             I32,
+	"i64":             I64,
+	"isize":           I32,
+	"BigUint":         BigUint,
+	"BigInt":          BigInt,
+	"bool":            Bool,
+	"Address":         Address,
+	"TokenIdentifier": TokenIdentifier,
+	"bytes":           Bytes,
+	"utf-8 string":    String,
+}
+
+type abiJSON struct {
+	Name      string                 `json:"name"`
+	Endpoints []endpointJSON         `json:"endpoints"`
+	Events    []eventJSON            `json:"events"`
+	Types     map[string]typeDefJSON `json:"types"`
+}
+
+type endpointJSON struct {
+	Name    string      `json:"name"`
+	Inputs  []paramJSON `json:"inputs"`
+	Outputs []paramJSON `json:"outputs"`
+}
+
+type eventJSON struct {
+	Identifier string      `json:"identifier"`
+	Inputs     []paramJSON `json:"inputs"`
+}
+
+type paramJSON struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+}
+
+type typeDefJSON struct {
+	Type     string        `json:"type"`
+	Fields   []paramJSON   `json:"fields"`
+	Variants []variantJSON `json:"variants"`
+}
+
+type variantJSON struct {
+	Name         string      `json:"name"`
+	Discriminant uint8       `json:"discriminant"`
+	Fields       []paramJSON `json:"fields"`
+}
+
+// Param is an input or an output of an endpoint or of an event
+type Param struct {
+	Name     string
+	TypeName string
+	Indexed  bool
+	decoder  argumentsDecoder
+}
+
+// Endpoint is an endpoint declared by the contract ABI
+type Endpoint struct {
+	Name    string
+	Inputs  []Param
+	Outputs []Param
+}
+
+// Event is an event declared by the contract ABI. The indexed inputs are held by the topics of the log entry, while
+// the other inputs are held by its data
+type Event struct {
+	Identifier string
+	Inputs     []Param
+}
+
+// NamedValue is a decoded value together with the name and the type of the param it was decoded for
+type NamedValue struct {
+	Name     string
+	TypeName string
+	Value    interface{}
+}
+
+// DecodedCall is a contract call decoded with the contract ABI
+type DecodedCall struct {
+	Function  string
+	Arguments []NamedValue
+}
+
+// DecodedEvent is a log entry decoded with the contract ABI
+type DecodedEvent struct {
+	Identifier string
+	Fields     []NamedValue
+}
+
+// ContractABI is a loaded contract ABI, able to decode the calls, the return data and the events of the contract
+type ContractABI struct {
+	Name      string
+	Endpoints map[string]*Endpoint
+	Events    map[string]*Event
+	types     map[string]Type
+}
+
+// LoadContractABI loads the contract ABI from its JSON description, resolving all the types used by the endpoints
+// and by the events. Custom types may refer to each other, or to themselves
+func LoadContractABI(jsonData []byte) (*ContractABI, error) {
+	var description abiJSON
+	err := json.Unmarshal(jsonData, &description)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidABI, err)
+	}
+
+	contractABI := &ContractABI{
+		Name:      description.Name,
+		Endpoints: make(map[string]*Endpoint, len(description.Endpoints)),
+		Events:    make(map[string]*Event, len(description.Events)),
+		types:     make(map[string]Type, len(description.Types)),
+	}
+
+	err = contractABI.loadCustomTypes(description.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, endpointDescription := range description.Endpoints {
+		endpoint := &Endpoint{Name: endpointDescription.Name}
+		endpoint.Inputs, err = contractABI.loadParams(endpointDescription.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", endpoint.Name, err)
+		}
+		endpoint.Outputs, err = contractABI.loadParams(endpointDescription.Outputs)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", endpoint.Name, err)
+		}
+		contractABI.Endpoints[endpoint.Name] = endpoint
+	}
+
+	for _, eventDescription := range description.Events {
+		event := &Event{Identifier: eventDescription.Identifier}
+		event.Inputs, err = contractABI.loadParams(eventDescription.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", event.Identifier, err)
+		}
+		contractABI.Events[event.Identifier] = event
+	}
+
+	return contractABI, nil
+}
+
+// loadCustomTypes creates all the custom types before resolving their fields, so that the fields can refer to any
+// of them
+func (contractABI *ContractABI) loadCustomTypes(descriptions map[string]typeDefJSON) error {
+	for name, description := range descriptions {
+		switch description.Type {
+		case structKind:
+			contractABI.types[name] = &structType{name: name}
+		case enumKind:
+			contractABI.types[name] = &enumType{name: name}
+		default:
+			return fmt.Errorf("%w: type %s of kind %s", ErrInvalidABI, name, description.Type)
+		}
+	}
+
+	for name, description := range descriptions {
+		switch t := contractABI.types[name].(type) {
+		case *structType:
+			fields, err := contractABI.loadFields(description.Fields)
+			if err != nil {
+				return fmt.Errorf("type %s: %w", name, err)
+			}
+			t.fields = fields
+		case *enumType:
+			for _, variantDescription := range description.Variants {
+				fields, err := contractABI.loadFields(variantDescription.Fields)
+				if err != nil {
+					return fmt.Errorf("type %s: %w", name, err)
+				}
+				t.variants = append(t.variants, EnumVariant{
+					Name:         variantDescription.Name,
+					Discriminant: variantDescription.Discriminant,
+					Fields:       fields,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+func (contractABI *ContractABI) loadFields(descriptions []paramJSON) ([]StructField, error) {
+	fields := make([]StructField, 0, len(descriptions))
+	for _, description := range descriptions {
+		fieldType, err := contractABI.Type(description.Type)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, StructField{Name: description.Name, Type: fieldType})
+	}
+
+	return fields, nil
+}
+
+func (contractABI *ContractABI) loadParams(descriptions []paramJSON) ([]Param, error) {
+	params := make([]Param, 0, len(descriptions))
+	for _, description := range descriptions {
+		decoder, err := contractABI.argumentsDecoder(description.Type)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, Param{
+			Name:     description.Name,
+			TypeName: description.Type,
+			Indexed:  description.Indexed,
+			decoder:  decoder,
+		})
+	}
+
+	return params, nil
+}
+
+// Type resolves a type name of the contract ABI, such as "u64", "Option<List<Payment>>" or "array32<u8>"
+func (contractABI *ContractABI) Type(typeName string) (Type, error) {
+	typeName = strings.TrimSpace(typeName)
+	base, args, err := splitTypeName(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	if args == nil {
+		primitive, isPrimitive := primitiveTypes[base]
+		if isPrimitive {
+			return primitive, nil
+		}
+		custom, isCustom := contractABI.types[base]
+		if isCustom {
+			return custom, nil
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+	}
+
+	argTypes := make([]Type, 0, len(args))
+	for _, arg := range args {
+		argType, errResolve := contractABI.Type(arg)
+		if errResolve != nil {
+			return nil, errResolve
+		}
+		argTypes = append(argTypes, argType)
+	}
+
+	switch {
+	case base == "tuple":
+		return Tuple(argTypes...), nil
+	case len(argTypes) != 1:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+	case base == "Option":
+		return Option(argTypes[0]), nil
+	case base == "List" || base == "Vec":
+		return List(argTypes[0]), nil
+	case base == "Box":
+		return argTypes[0], nil
+	case strings.HasPrefix(base, "array"):
+		length, errConvert := strconv.Atoi(strings.TrimPrefix(base, "array"))
+		if errConvert != nil || length < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+		}
+		return Array(length, argTypes[0]), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+	}
+}
+
+func (contractABI *ContractABI) argumentsDecoder(typeName string) (argumentsDecoder, error) {
+	typeName = strings.TrimSpace(typeName)
+	base, args, err := splitTypeName(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch base {
+	case "variadic", "optional":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+		}
+		inner, errInner := contractABI.argumentsDecoder(args[0])
+		if errInner != nil {
+			return nil, errInner
+		}
+		if base == "variadic" {
+			return &variadicArguments{inner: inner}, nil
+		}
+		return &optionalArguments{inner: inner}, nil
+	case "multi":
+		items := make([]argumentsDecoder, 0, len(args))
+		for _, arg := range args {
+			item, errItem := contractABI.argumentsDecoder(arg)
+			if errItem != nil {
+				return nil, errItem
+			}
+			items = append(items, item)
+		}
+		return &multiArguments{items: items}, nil
+	default:
+		argType, errResolve := contractABI.Type(typeName)
+		if errResolve != nil {
+			return nil, errResolve
+		}
+		return &singleArgument{argType: argType}, nil
+	}
+}
+
+// DecodeCallData parses the data field of a transaction calling the contract and decodes its arguments as the
+// inputs of the called endpoint
+func (contractABI *ContractABI) DecodeCallData(data string) (*DecodedCall, error) {
+	function, arguments, err := parsers.NewCallArgsParser().ParseData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return contractABI.DecodeCall(function, arguments)
+}
+
+// DecodeCall decodes the arguments, as returned by the call arguments parser, as the inputs of the endpoint
+func (contractABI *ContractABI) DecodeCall(function string, arguments [][]byte) (*DecodedCall, error) {
+	endpoint, err := contractABI.endpoint(function)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := decodeParams(endpoint.Inputs, arguments)
+	if err != nil {
+		return nil, fmt.Errorf("endpoint %s: %w", function, err)
+	}
+
+	return &DecodedCall{
+		Function:  function,
+		Arguments: values,
+	}, nil
+}
+
+// DecodeReturnData decodes the return data of a VM output as the outputs of the endpoint
+func (contractABI *ContractABI) DecodeReturnData(function string, returnData [][]byte) ([]NamedValue, error) {
+	endpoint, err := contractABI.endpoint(function)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := decodeParams(endpoint.Outputs, returnData)
+	if err != nil {
+		return nil, fmt.Errorf("endpoint %s: %w", function, err)
+	}
+
+	return values, nil
+}
+
+// DecodeEvent decodes a log entry of a declared event. The first topic holds the event identifier and the next
+// topics hold the indexed inputs. The data holds the other inputs: a single one is top-level encoded, while several
+// ones are nested encoded one after the other
+func (contractABI *ContractABI) DecodeEvent(logEntry *vmcommon.LogEntry) (*DecodedEvent, error) {
+	if logEntry == nil {
+		return nil, ErrNilLogEntry
+	}
+	if len(logEntry.Topics) == 0 {
+		return nil, fmt.Errorf("%w: no topics", ErrUnknownEvent)
+	}
+
+	identifier := string(logEntry.Topics[0])
+	event, exists := contractABI.Events[identifier]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, identifier)
+	}
+
+	indexedParams := make([]Param, 0, len(event.Inputs))
+	dataParams := make([]Param, 0, len(event.Inputs))
+	for _, param := range event.Inputs {
+		if param.Indexed {
+			indexedParams = append(indexedParams, param)
+		} else {
+			dataParams = append(dataParams, param)
+		}
+	}
+
+	indexedValues, err := decodeParams(indexedParams, logEntry.Topics[1:])
+	if err != nil {
+		return nil, fmt.Errorf("event %s: %w", identifier, err)
+	}
+	dataValues, err := decodeEventData(dataParams, logEntry.Data)
+	if err != nil {
+		return nil, fmt.Errorf("event %s: %w", identifier, err)
+	}
+
+	return &DecodedEvent{
+		Identifier: identifier,
+		Fields:     orderEventFields(event.Inputs, indexedValues, dataValues),
+	}, nil
+}
+
+func (contractABI *ContractABI) endpoint(function string) (*Endpoint, error) {
+	endpoint, exists := contractABI.Endpoints[function]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEndpoint, function)
+	}
+
+	return endpoint, nil
+}
+
+func decodeParams(params []Param, arguments [][]byte) ([]NamedValue, error) {
+	values := make([]NamedValue, 0, len(params))
+	for _, param := range params {
+		value, remaining, err := param.decoder.decode(arguments)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", param.Name, err)
+		}
+		arguments = remaining
+		values = append(values, NamedValue{Name: param.Name, TypeName: param.TypeName, Value: value})
+	}
+	if len(arguments) > 0 {
+		return nil, fmt.Errorf("%w: %d left", ErrTooManyArguments, len(arguments))
+	}
+
+	return values, nil
+}
+
+func decodeEventData(params []Param, data []byte) ([]NamedValue, error) {
+	if len(params) == 0 {
+		return make([]NamedValue, 0), nil
+	}
+	if len(params) == 1 {
+		return decodeParams(params, [][]byte{data})
+	}
+
+	reader := newNestedReader(data)
+	values := make([]NamedValue, 0, len(params))
+	for _, param := range params {
+		single, isSingle := param.decoder.(*singleArgument)
+		if !isSingle {
+			return nil, fmt.Errorf("%s: %w: multi-value in event data", param.Name, ErrUnknownType)
+		}
+		value, err := single.argType.decodeNested(reader)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", param.Name, err)
+		}
+		values = append(values, NamedValue{Name: param.Name, TypeName: param.TypeName, Value: value})
+	}
+	if !reader.isEmpty() {
+		return nil, ErrTrailingData
+	}
+
+	return values, nil
+}
+
+func orderEventFields(inputs []Param, indexedValues []NamedValue, dataValues []NamedValue) []NamedValue {
+	fields := make([]NamedValue, 0, len(inputs))
+	for _, param := range inputs {
+		if param.Indexed {
+			fields = append(fields, indexedValues[0])
+			indexedValues = indexedValues[1:]
+		} else {
+			fields = append(fields, dataValues[0])
+			dataValues = dataValues[1:]
+		}
+	}
+
+	return fields
+}
+
+// splitTypeName splits a type name such as "tuple<u8,List<u16>>" into its base name and its top-level type arguments.
+// A type name without arguments returns nil arguments
+func splitTypeName(typeName string) (string, []string, error) {
+	start := strings.Index(typeName, "<")
+	if start < 0 {
+		return typeName, nil, nil
+	}
+	if !strings.HasSuffix(typeName, ">") {
+		return "", nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+	}
+
+	args := make([]string, 0)
+	depth := 0
+	argStart := start + 1
+	for i := argStart; i < len(typeName)-1; i++ {
+		switch typeName[i] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth < 0 {
+				return "", nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+			}
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(typeName[argStart:i]))
+				argStart = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return "", nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+	}
+	args = append(args, strings.TrimSpace(typeName[argStart:len(typeName)-1]))
+
+	return strings.TrimSpace(typeName[:start]), args, nil
+}
@@ -0,0 +1,286 @@
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContractABI = `{
+	"name": "Vault",
+	"endpoints": [
+		{
+			"name": "deposit",
+			"inputs": [
+				{"name": "payment", "type": "Payment"},
+				{"name": "memo", "type": "optional<bytes>"}
+			],
+			"outputs": [{"type": "BigUint"}]
+		},
+		{
+			"name": "batch",
+			"inputs": [
+				{"name": "kind", "type": "u8"},
+				{"name": "transfers", "type": "variadic<multi<Address,BigUint>>"}
+			],
+			"outputs": [
+				{"name": "status", "type": "Status"},
+				{"name": "values", "type": "List<u16>"}
+			]
+		},
+		{
+			"name": "chain",
+			"inputs": [{"name": "head", "type": "Node"}],
+			"outputs": []
+		}
+	],
+	"events": [
+		{
+			"identifier": "deposit",
+			"inputs": [
+				{"name": "caller", "type": "Address", "indexed": true},
+				{"name": "amount", "type": "BigUint"},
+				{"name": "token", "type": "TokenIdentifier", "indexed": true}
+			]
+		},
+		{
+			"identifier": "pair",
+			"inputs": [
+				{"name": "first", "type": "u8"},
+				{"name": "second", "type": "BigUint"}
+			]
+		}
+	],
+	"types": {
+		"Payment": {
+			"type": "struct",
+			"fields": [
+				{"name": "token", "type": "TokenIdentifier"},
+				{"name": "nonce", "type": "u64"},
+				{"name": "amount", "type": "BigUint"}
+			]
+		},
+		"Status": {
+			"type": "enum",
+			"variants": [
+				{"name": "Inactive", "discriminant": 0},
+				{"name": "Active", "discriminant": 1},
+				{"name": "Paused", "discriminant": 2, "fields": [{"name": "until", "type": "u64"}]}
+			]
+		},
+		"Node": {
+			"type": "struct",
+			"fields": [
+				{"name": "value", "type": "u32"},
+				{"name": "next", "type": "Option<Box<Node>>"}
+			]
+		}
+	}
+}`
+
+func loadTestContractABI(t *testing.T) *ContractABI {
+	contractABI, err := LoadContractABI([]byte(testContractABI))
+	require.Nil(t, err)
+
+	return contractABI
+}
+
+func encodeTestArgument(t *testing.T, argType Type, value interface{}) []byte {
+	encoded, err := EncodeTopLevel(argType, value)
+	require.Nil(t, err)
+
+	return encoded
+}
+
+func TestLoadContractABI(t *testing.T) {
+	t.Parallel()
+
+	contractABI := loadTestContractABI(t)
+	assert.Equal(t, "Vault", contractABI.Name)
+	assert.Equal(t, 3, len(contractABI.Endpoints))
+	assert.Equal(t, 2, len(contractABI.Events))
+
+	paymentType, err := contractABI.Type("List<Payment>")
+	require.Nil(t, err)
+	assert.Equal(t, "List<Payment>", paymentType.Name())
+
+	arrayType, err := contractABI.Type("array32<u8>")
+	require.Nil(t, err)
+	assert.Equal(t, "array32<u8>", arrayType.Name())
+
+	tupleType, err := contractABI.Type("tuple<u8, Option<Status>>")
+	require.Nil(t, err)
+	assert.Equal(t, "tuple<u8,Option<Status>>", tupleType.Name())
+}
+
+func TestLoadContractABI_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadContractABI([]byte("not json"))
+	assert.True(t, errors.Is(err, ErrInvalidABI))
+
+	_, err = LoadContractABI([]byte(`{"types": {"T": {"type": "union"}}}`))
+	assert.True(t, errors.Is(err, ErrInvalidABI))
+
+	_, err = LoadContractABI([]byte(`{"types": {"T": {"type": "struct", "fields": [{"name": "a", "type": "Missing"}]}}}`))
+	assert.True(t, errors.Is(err, ErrUnknownType))
+
+	_, err = LoadContractABI([]byte(`{"endpoints": [{"name": "e", "inputs": [{"name": "a", "type": "List<u8"}]}]}`))
+	assert.True(t, errors.Is(err, ErrUnknownType))
+
+	_, err = LoadContractABI([]byte(`{"events": [{"identifier": "e", "inputs": [{"name": "a", "type": "array-1<u8>"}]}]}`))
+	assert.True(t, errors.Is(err, ErrUnknownType))
+}
+
+func TestContractABI_DecodeCallData(t *testing.T) {
+	t.Parallel()
+
+	contractABI := loadTestContractABI(t)
+	payment := map[string]interface{}{"token": "TKN-01", "nonce": uint64(0), "amount": big.NewInt(500)}
+	paymentType, _ := contractABI.Type("Payment")
+
+	data := "deposit@" + hex.EncodeToString(encodeTestArgument(t, paymentType, payment))
+	decoded, err := contractABI.DecodeCallData(data)
+	require.Nil(t, err)
+	assert.Equal(t, &DecodedCall{
+		Function: "deposit",
+		Arguments: []NamedValue{
+			{Name: "payment", TypeName: "Payment", Value: payment},
+			{Name: "memo", TypeName: "optional<bytes>", Value: nil},
+		},
+	}, decoded)
+
+	decoded, err = contractABI.DecodeCallData(data + "@6d656d6f")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("memo"), decoded.Arguments[1].Value)
+
+	_, err = contractABI.DecodeCallData(data + "@6d656d6f@00")
+	assert.True(t, errors.Is(err, ErrTooManyArguments))
+
+	_, err = contractABI.DecodeCallData("deposit")
+	assert.True(t, errors.Is(err, ErrNotEnoughArguments))
+	assert.Equal(t, "endpoint deposit: payment: not enough arguments", err.Error())
+
+	_, err = contractABI.DecodeCallData("withdraw@01")
+	assert.True(t, errors.Is(err, ErrUnknownEndpoint))
+}
+
+func TestContractABI_DecodeCallVariadicMulti(t *testing.T) {
+	t.Parallel()
+
+	contractABI := loadTestContractABI(t)
+	first := []byte(strings.Repeat("a", AddressLength))
+	second := []byte(strings.Repeat("b", AddressLength))
+	arguments := [][]byte{{1}, first, {10}, second, {20}}
+
+	decoded, err := contractABI.DecodeCall("batch", arguments)
+	require.Nil(t, err)
+	assert.Equal(t, uint8(1), decoded.Arguments[0].Value)
+	assert.Equal(t, []interface{}{
+		[]interface{}{first, big.NewInt(10)},
+		[]interface{}{second, big.NewInt(20)},
+	}, decoded.Arguments[1].Value)
+
+	decoded, err = contractABI.DecodeCall("batch", [][]byte{{1}})
+	require.Nil(t, err)
+	assert.Equal(t, []interface{}{}, decoded.Arguments[1].Value)
+
+	_, err = contractABI.DecodeCall("batch", arguments[:4])
+	assert.True(t, errors.Is(err, ErrNotEnoughArguments))
+}
+
+func TestContractABI_DecodeCallRecursiveType(t *testing.T) {
+	t.Parallel()
+
+	contractABI := loadTestContractABI(t)
+	nodeType, _ := contractABI.Type("Node")
+	node := map[string]interface{}{
+		"value": uint32(1),
+		"next":  map[string]interface{}{"value": uint32(2), "next": nil},
+	}
+
+	decoded, err := contractABI.DecodeCall("chain", [][]byte{encodeTestArgument(t, nodeType, node)})
+	require.Nil(t, err)
+	assert.Equal(t, node, decoded.Arguments[0].Value)
+}
+
+func TestContractABI_DecodeReturnData(t *testing.T) {
+	t.Parallel()
+
+	contractABI := loadTestContractABI(t)
+	statusType, _ := contractABI.Type("Status")
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnData: [][]byte{
+			encodeTestArgument(t, statusType, &EnumValue{Discriminant: 2, Fields: map[string]interface{}{"until": uint64(7)}}),
+			{0, 1, 0, 2},
+		},
+	}
+	values, err := contractABI.DecodeReturnData("batch", vmOutput.ReturnData)
+	require.Nil(t, err)
+	assert.Equal(t, []NamedValue{
+		{Name: "status", TypeName: "Status", Value: &EnumValue{Name: "Paused", Discriminant: 2, Fields: map[string]interface{}{"until": uint64(7)}}},
+		{Name: "values", TypeName: "List<u16>", Value: []interface{}{uint16(1), uint16(2)}},
+	}, values)
+
+	values, err = contractABI.DecodeReturnData("batch", [][]byte{{1}, {}})
+	require.Nil(t, err)
+	assert.Equal(t, &EnumValue{Name: "Active", Discriminant: 1}, values[0].Value)
+
+	_, err = contractABI.DecodeReturnData("batch", [][]byte{{5}, {}})
+	assert.True(t, errors.Is(err, ErrInvalidEnumDiscriminant))
+
+	values, err = contractABI.DecodeReturnData("deposit", [][]byte{{0x01, 0x00}})
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(256), values[0].Value)
+}
+
+func TestContractABI_DecodeEvent(t *testing.T) {
+	t.Parallel()
+
+	contractABI := loadTestContractABI(t)
+	caller := []byte(strings.Repeat("c", AddressLength))
+
+	decoded, err := contractABI.DecodeEvent(&vmcommon.LogEntry{
+		Identifier: []byte("deposit"),
+		Topics:     [][]byte{[]byte("deposit"), caller, []byte("TKN-01")},
+		Data:       []byte{0x03, 0xe8},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, &DecodedEvent{
+		Identifier: "deposit",
+		Fields: []NamedValue{
+			{Name: "caller", TypeName: "Address", Value: caller},
+			{Name: "amount", TypeName: "BigUint", Value: big.NewInt(1000)},
+			{Name: "token", TypeName: "TokenIdentifier", Value: "TKN-01"},
+		},
+	}, decoded)
+
+	decoded, err = contractABI.DecodeEvent(&vmcommon.LogEntry{
+		Topics: [][]byte{[]byte("pair")},
+		Data:   []byte{0x07, 0, 0, 0, 1, 0x09},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []NamedValue{
+		{Name: "first", TypeName: "u8", Value: uint8(7)},
+		{Name: "second", TypeName: "BigUint", Value: big.NewInt(9)},
+	}, decoded.Fields)
+
+	_, err = contractABI.DecodeEvent(nil)
+	assert.Equal(t, ErrNilLogEntry, err)
+
+	_, err = contractABI.DecodeEvent(&vmcommon.LogEntry{})
+	assert.True(t, errors.Is(err, ErrUnknownEvent))
+
+	_, err = contractABI.DecodeEvent(&vmcommon.LogEntry{Topics: [][]byte{[]byte("other")}})
+	assert.True(t, errors.Is(err, ErrUnknownEvent))
+
+	_, err = contractABI.DecodeEvent(&vmcommon.LogEntry{Topics: [][]byte{[]byte("pair")}, Data: []byte{0x07, 0, 0, 0, 1, 0x09, 0}})
+	assert.True(t, errors.Is(err, ErrTrailingData))
+}
@@ -0,0 +1,63 @@
+package abi
+
+import "errors"
+
+// ErrNilType signals that a nil type was provided
+var ErrNilType = errors.New("nil type")
+
+// ErrInvalidValue signals that the value does not have the Go type expected by the codec type
+var ErrInvalidValue = errors.New("invalid value")
+
+// ErrValueOutOfRange signals that the encoded value does not fit the codec type
+var ErrValueOutOfRange = errors.New("value out of range")
+
+// ErrNegativeBigUint signals that a negative value was provided for an unsigned big integer
+var ErrNegativeBigUint = errors.New("negative big uint")
+
+// ErrInvalidAddressLength signals that an address does not have the expected length
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrInvalidBool signals that the encoded value is not a boolean
+var ErrInvalidBool = errors.New("invalid bool")
+
+// ErrInvalidOptionTag signals that the encoded option starts with an unknown tag
+var ErrInvalidOptionTag = errors.New("invalid option tag")
+
+// ErrInvalidArrayLength signals that an array value does not have the length of the array type
+var ErrInvalidArrayLength = errors.New("invalid array length")
+
+// ErrMissingStructField signals that a struct value does not hold one of the fields of the struct type
+var ErrMissingStructField = errors.New("missing struct field")
+
+// ErrUnexpectedEndOfData signals that the encoded data ended before the value was decoded
+var ErrUnexpectedEndOfData = errors.New("unexpected end of data")
+
+// ErrTrailingData signals that the encoded data holds more bytes than the decoded value
+var ErrTrailingData = errors.New("trailing data")
+
+// ErrArgumentsCountMismatch signals that the number of arguments differs from the number of types
+var ErrArgumentsCountMismatch = errors.New("arguments count mismatch")
+
+// ErrInvalidEnumDiscriminant signals that the enum type has no variant with the discriminant
+var ErrInvalidEnumDiscriminant = errors.New("invalid enum discriminant")
+
+// ErrInvalidABI signals that the contract ABI JSON could not be loaded
+var ErrInvalidABI = errors.New("invalid contract abi")
+
+// ErrUnknownType signals that a type of the contract ABI could not be resolved
+var ErrUnknownType = errors.New("unknown type")
+
+// ErrUnknownEndpoint signals that the contract ABI does not declare the endpoint
+var ErrUnknownEndpoint = errors.New("unknown endpoint")
+
+// ErrUnknownEvent signals that the contract ABI does not declare the event
+var ErrUnknownEvent = errors.New("unknown event")
+
+// ErrNilLogEntry signals that a nil log entry was provided
+var ErrNilLogEntry = errors.New("nil log entry")
+
+// ErrNotEnoughArguments signals that there are fewer arguments than the declared inputs or outputs
+var ErrNotEnoughArguments = errors.New("not enough arguments")
+
+// ErrTooManyArguments signals that there are more arguments than the declared inputs or outputs
+var ErrTooManyArguments = errors.New("too many arguments")
@@ -0,0 +1,78 @@
+package abi
+
+// argumentsDecoder decodes a param from the arguments, returning the arguments left for the next params. Most params
+// take a single argument, while the multi-value ones may take none, or several
+type argumentsDecoder interface {
+	decode(arguments [][]byte) (interface{}, [][]byte, error)
+}
+
+type singleArgument struct {
+	argType Type
+}
+
+func (s *singleArgument) decode(arguments [][]byte) (interface{}, [][]byte, error) {
+	if len(arguments) == 0 {
+		return nil, nil, ErrNotEnoughArguments
+	}
+
+	value, err := s.argType.decodeTopLevel(arguments[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return value, arguments[1:], nil
+}
+
+// optionalArguments decodes optional<T>, which is nil when there are no arguments left
+type optionalArguments struct {
+	inner argumentsDecoder
+}
+
+func (o *optionalArguments) decode(arguments [][]byte) (interface{}, [][]byte, error) {
+	if len(arguments) == 0 {
+		return nil, arguments, nil
+	}
+
+	return o.inner.decode(arguments)
+}
+
+// variadicArguments decodes variadic<T> as a []interface{}, taking all the arguments left
+type variadicArguments struct {
+	inner argumentsDecoder
+}
+
+func (v *variadicArguments) decode(arguments [][]byte) (interface{}, [][]byte, error) {
+	values := make([]interface{}, 0)
+	for len(arguments) > 0 {
+		value, remaining, err := v.inner.decode(arguments)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(remaining) == len(arguments) {
+			break
+		}
+		values = append(values, value)
+		arguments = remaining
+	}
+
+	return values, arguments, nil
+}
+
+// multiArguments decodes multi<A,B,...> as a []interface{}, each item taking its own arguments
+type multiArguments struct {
+	items []argumentsDecoder
+}
+
+func (m *multiArguments) decode(arguments [][]byte) (interface{}, [][]byte, error) {
+	values := make([]interface{}, 0, len(m.items))
+	for _, item := range m.items {
+		value, remaining, err := item.decode(arguments)
+		if err != nil {
+			return nil, nil, err
+		}
+		values = append(values, value)
+		arguments = remaining
+	}
+
+	return values, arguments, nil
+}
@@ -0,0 +1,532 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// AddressLength is the length of an encoded address
+const AddressLength = 32
+
+const lengthPrefixSize = 4
+
+// Type is a type of the contract ABI, able to encode and decode the values of its Go representation:
+//   - u8, u16, u32 and u64 are represented as uint8, uint16, uint32 and uint64
+//   - i8, i16, i32 and i64 are represented as int8, int16, int32 and int64
+//   - BigUint and BigInt are represented as *big.Int
+//   - bool is represented as bool
+//   - Address and bytes are represented as []byte
+//   - TokenIdentifier and utf-8 string are represented as string
+//   - Option<T> is represented as nil for None, or as the value of T
+//   - List<T>, fixed arrays and tuples are represented as []interface{}
+//   - structs are represented as map[string]interface{}, keyed by field name
+//   - enums are represented as *EnumValue
+//
+// A value is encoded either top-level, as a whole argument, or nested, inside another value. The nested encoding of
+// the variable length values is prefixed by their length, while the top-level one is not
+type Type interface {
+	Name() string
+	encodeNested(buffer *bytes.Buffer, value interface{}) error
+	encodeTopLevel(buffer *bytes.Buffer, value interface{}) error
+	decodeNested(reader *nestedReader) (interface{}, error)
+	decodeTopLevel(data []byte) (interface{}, error)
+}
+
+// U8 is the type of the 8 bits unsigned integers
+var U8 Type = &uintType{name: "u8", size: 1}
+
+// U16 is the type of the 16 bits unsigned integers
+var U16 Type = &uintType{name: "u16", size: 2}
+
+// U32 is the type of the 32 bits unsigned integers
+var U32 Type = &uintType{name: "u32", size: 4}
+
+// U64 is the type of the 64 bits unsigned integers
+var U64 Type = &uintType{name: "u64", size: 8}
+
+// I8 is the type of the 8 bits signed integers
+var I8 Type = &intType{name: "i8", size: 1}
+
+// I16 is the type of the 16 bits signed integers
+var I16 Type = &intType{name: "i16", size: 2}
+
+// I32 is the type of the 32 bits signed integers
+var I32 Type = &intType{name: "i32", size: 4}
+
+// I64 is the type of the 64 bits signed integers
+var I64 Type = &intType{name: "i64", size: 8}
+
+// BigUint is the type of the arbitrary size unsigned integers
+var BigUint Type = &bigIntType{name: "BigUint", signed: false}
+
+// BigInt is the type of the arbitrary size signed integers
+var BigInt Type = &bigIntType{name: "BigInt", signed: true}
+
+// Bool is the type of the booleans
+var Bool Type = &boolType{}
+
+// Address is the type of the addresses
+var Address Type = &addressType{}
+
+// TokenIdentifier is the type of the token identifiers
+var TokenIdentifier Type = &bytesType{name: "TokenIdentifier", isString: true}
+
+// Bytes is the type of the byte slices
+var Bytes Type = &bytesType{name: "bytes"}
+
+// String is the type of the UTF-8 strings
+var String Type = &bytesType{name: "utf-8 string", isString: true}
+
+type uintType struct {
+	name string
+	size int
+}
+
+// Name returns the ABI name of the type
+func (t *uintType) Name() string {
+	return t.name
+}
+
+func (t *uintType) toUint64(value interface{}) (uint64, error) {
+	switch t.size {
+	case 1:
+		v, ok := value.(uint8)
+		return uint64(v), checkValue(ok, t, value)
+	case 2:
+		v, ok := value.(uint16)
+		return uint64(v), checkValue(ok, t, value)
+	case 4:
+		v, ok := value.(uint32)
+		return uint64(v), checkValue(ok, t, value)
+	default:
+		v, ok := value.(uint64)
+		return v, checkValue(ok, t, value)
+	}
+}
+
+func (t *uintType) fromUint64(value uint64) interface{} {
+	switch t.size {
+	case 1:
+		return uint8(value)
+	case 2:
+		return uint16(value)
+	case 4:
+		return uint32(value)
+	default:
+		return value
+	}
+}
+
+func (t *uintType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	v, err := t.toUint64(value)
+	if err != nil {
+		return err
+	}
+
+	encoded := make([]byte, 8)
+	binary.BigEndian.PutUint64(encoded, v)
+	buffer.Write(encoded[8-t.size:])
+
+	return nil
+}
+
+func (t *uintType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	v, err := t.toUint64(value)
+	if err != nil {
+		return err
+	}
+
+	buffer.Write(new(big.Int).SetUint64(v).Bytes())
+
+	return nil
+}
+
+func (t *uintType) decodeNested(reader *nestedReader) (interface{}, error) {
+	data, err := reader.read(t.size)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.decodeTopLevel(data)
+}
+
+func (t *uintType) decodeTopLevel(data []byte) (interface{}, error) {
+	v := new(big.Int).SetBytes(data)
+	if v.BitLen() > t.size*8 {
+		return nil, fmt.Errorf("%w for %s", ErrValueOutOfRange, t.name)
+	}
+
+	return t.fromUint64(v.Uint64()), nil
+}
+
+type intType struct {
+	name string
+	size int
+}
+
+// Name returns the ABI name of the type
+func (t *intType) Name() string {
+	return t.name
+}
+
+func (t *intType) toInt64(value interface{}) (int64, error) {
+	switch t.size {
+	case 1:
+		v, ok := value.(int8)
+		return int64(v), checkValue(ok, t, value)
+	case 2:
+		v, ok := value.(int16)
+		return int64(v), checkValue(ok, t, value)
+	case 4:
+		v, ok := value.(int32)
+		return int64(v), checkValue(ok, t, value)
+	default:
+		v, ok := value.(int64)
+		return v, checkValue(ok, t, value)
+	}
+}
+
+func (t *intType) fromInt64(value int64) interface{} {
+	switch t.size {
+	case 1:
+		return int8(value)
+	case 2:
+		return int16(value)
+	case 4:
+		return int32(value)
+	default:
+		return value
+	}
+}
+
+func (t *intType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	v, err := t.toInt64(value)
+	if err != nil {
+		return err
+	}
+
+	encoded := make([]byte, 8)
+	binary.BigEndian.PutUint64(encoded, uint64(v))
+	buffer.Write(encoded[8-t.size:])
+
+	return nil
+}
+
+func (t *intType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	v, err := t.toInt64(value)
+	if err != nil {
+		return err
+	}
+
+	buffer.Write(signedBigIntToBytes(big.NewInt(v)))
+
+	return nil
+}
+
+func (t *intType) decodeNested(reader *nestedReader) (interface{}, error) {
+	data, err := reader.read(t.size)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.decodeTopLevel(data)
+}
+
+func (t *intType) decodeTopLevel(data []byte) (interface{}, error) {
+	if len(data) > t.size {
+		return nil, fmt.Errorf("%w for %s", ErrValueOutOfRange, t.name)
+	}
+
+	return t.fromInt64(bytesToSignedBigInt(data).Int64()), nil
+}
+
+type bigIntType struct {
+	name   string
+	signed bool
+}
+
+// Name returns the ABI name of the type
+func (t *bigIntType) Name() string {
+	return t.name
+}
+
+func (t *bigIntType) encode(value interface{}) ([]byte, error) {
+	v, ok := value.(*big.Int)
+	if !ok || v == nil {
+		return nil, invalidValueError(t, value)
+	}
+	if t.signed {
+		return signedBigIntToBytes(v), nil
+	}
+	if v.Sign() < 0 {
+		return nil, ErrNegativeBigUint
+	}
+
+	return v.Bytes(), nil
+}
+
+func (t *bigIntType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	encoded, err := t.encode(value)
+	if err != nil {
+		return err
+	}
+
+	writeLengthPrefixed(buffer, encoded)
+
+	return nil
+}
+
+func (t *bigIntType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	encoded, err := t.encode(value)
+	if err != nil {
+		return err
+	}
+
+	buffer.Write(encoded)
+
+	return nil
+}
+
+func (t *bigIntType) decodeNested(reader *nestedReader) (interface{}, error) {
+	data, err := reader.readLengthPrefixed()
+	if err != nil {
+		return nil, err
+	}
+
+	return t.decodeTopLevel(data)
+}
+
+func (t *bigIntType) decodeTopLevel(data []byte) (interface{}, error) {
+	if t.signed {
+		return bytesToSignedBigInt(data), nil
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+type boolType struct {
+}
+
+// Name returns the ABI name of the type
+func (t *boolType) Name() string {
+	return "bool"
+}
+
+func (t *boolType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	v, ok := value.(bool)
+	if !ok {
+		return invalidValueError(t, value)
+	}
+	if v {
+		buffer.WriteByte(1)
+	} else {
+		buffer.WriteByte(0)
+	}
+
+	return nil
+}
+
+func (t *boolType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	v, ok := value.(bool)
+	if !ok {
+		return invalidValueError(t, value)
+	}
+	if v {
+		buffer.WriteByte(1)
+	}
+
+	return nil
+}
+
+func (t *boolType) decodeNested(reader *nestedReader) (interface{}, error) {
+	data, err := reader.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch data[0] {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return nil, ErrInvalidBool
+	}
+}
+
+func (t *boolType) decodeTopLevel(data []byte) (interface{}, error) {
+	switch {
+	case len(data) == 0:
+		return false, nil
+	case len(data) == 1 && data[0] == 1:
+		return true, nil
+	default:
+		return nil, ErrInvalidBool
+	}
+}
+
+type addressType struct {
+}
+
+// Name returns the ABI name of the type
+func (t *addressType) Name() string {
+	return "Address"
+}
+
+func (t *addressType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	v, ok := value.([]byte)
+	if !ok {
+		return invalidValueError(t, value)
+	}
+	if len(v) != AddressLength {
+		return ErrInvalidAddressLength
+	}
+
+	buffer.Write(v)
+
+	return nil
+}
+
+func (t *addressType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	return t.encodeNested(buffer, value)
+}
+
+func (t *addressType) decodeNested(reader *nestedReader) (interface{}, error) {
+	data, err := reader.read(AddressLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloneBytes(data), nil
+}
+
+func (t *addressType) decodeTopLevel(data []byte) (interface{}, error) {
+	if len(data) != AddressLength {
+		return nil, ErrInvalidAddressLength
+	}
+
+	return cloneBytes(data), nil
+}
+
+type bytesType struct {
+	name     string
+	isString bool
+}
+
+// Name returns the ABI name of the type
+func (t *bytesType) Name() string {
+	return t.name
+}
+
+func (t *bytesType) toBytes(value interface{}) ([]byte, error) {
+	if t.isString {
+		v, ok := value.(string)
+		return []byte(v), checkValue(ok, t, value)
+	}
+
+	v, ok := value.([]byte)
+	return v, checkValue(ok, t, value)
+}
+
+func (t *bytesType) encodeNested(buffer *bytes.Buffer, value interface{}) error {
+	v, err := t.toBytes(value)
+	if err != nil {
+		return err
+	}
+
+	writeLengthPrefixed(buffer, v)
+
+	return nil
+}
+
+func (t *bytesType) encodeTopLevel(buffer *bytes.Buffer, value interface{}) error {
+	v, err := t.toBytes(value)
+	if err != nil {
+		return err
+	}
+
+	buffer.Write(v)
+
+	return nil
+}
+
+func (t *bytesType) decodeNested(reader *nestedReader) (interface{}, error) {
+	data, err := reader.readLengthPrefixed()
+	if err != nil {
+		return nil, err
+	}
+
+	return t.decodeTopLevel(data)
+}
+
+func (t *bytesType) decodeTopLevel(data []byte) (interface{}, error) {
+	if t.isString {
+		return string(data), nil
+	}
+
+	return cloneBytes(data), nil
+}
+
+// signedBigIntToBytes returns the shortest two's complement big endian representation of the value, zero being
+// encoded as an empty slice
+func signedBigIntToBytes(value *big.Int) []byte {
+	if value.Sign() >= 0 {
+		encoded := value.Bytes()
+		if len(encoded) > 0 && encoded[0]&0x80 != 0 {
+			encoded = append([]byte{0}, encoded...)
+		}
+		return encoded
+	}
+
+	complement := new(big.Int).Neg(value)
+	complement.Sub(complement, big.NewInt(1))
+	encoded := complement.Bytes()
+	for i := range encoded {
+		encoded[i] = ^encoded[i]
+	}
+	if len(encoded) == 0 || encoded[0]&0x80 == 0 {
+		encoded = append([]byte{0xff}, encoded...)
+	}
+
+	return encoded
+}
+
+func bytesToSignedBigInt(data []byte) *big.Int {
+	if len(data) == 0 || data[0]&0x80 == 0 {
+		return new(big.Int).SetBytes(data)
+	}
+
+	complement := make([]byte, len(data))
+	for i := range data {
+		complement[i] = ^data[i]
+	}
+	value := new(big.Int).SetBytes(complement)
+	value.Add(value, big.NewInt(1))
+
+	return value.Neg(value)
+}
+
+func writeLengthPrefixed(buffer *bytes.Buffer, data []byte) {
+	prefix := make([]byte, lengthPrefixSize)
+	binary.BigEndian.PutUint32(prefix, uint32(len(data)))
+	buffer.Write(prefix)
+	buffer.Write(data)
+}
+
+func checkValue(ok bool, t Type, value interface{}) error {
+	if ok {
+		return nil
+	}
+
+	return invalidValueError(t, value)
+}
+
+func invalidValueError(t Type, value interface{}) error {
+	return fmt.Errorf("%w for %s: %T", ErrInvalidValue, t.Name(), value)
+}
+
+func cloneBytes(data []byte) []byte {
+	return append(make([]byte, 0, len(data)), data...)
+}
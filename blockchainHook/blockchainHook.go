@@ -0,0 +1,518 @@
+package blockchainHook
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+
+	logger "github.com/kalyan3104/k-core-logger-go"
+	"github.com/kalyan3104/k-core/core"
+	"github.com/kalyan3104/k-core/core/check"
+	"github.com/kalyan3104/k-core/data/dct"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.BlockchainHook = (*blockchainHook)(nil)
+
+var log = logger.GetOrCreate("blockchainHook")
+
+const dctKeyPrefix = core.ProtectedKeyPrefix + core.DCTKeyIdentifier
+
+// BlockInfo holds the block header fields exposed by the blockchain hook
+type BlockInfo struct {
+	Nonce      uint64
+	Round      uint64
+	TimeStamp  uint64
+	Epoch      uint32
+	RandomSeed []byte
+	RootHash   []byte
+}
+
+// accountDataGetter is implemented by accounts able to return their whole data trie
+type accountDataGetter interface {
+	GetAllData() map[string][]byte
+}
+
+// ArgsBlockchainHook defines the arguments needed to create a new in-memory blockchain hook
+type ArgsBlockchainHook struct {
+	Accounts              vmcommon.AccountsAdapter
+	BuiltInFunctions      vmcommon.BuiltInFunctionContainer
+	NFTStorageHandler     vmcommon.SimpleDCTNFTStorageHandler
+	GlobalSettingsHandler vmcommon.DCTGlobalSettingsHandler
+	Marshalizer           vmcommon.Marshalizer
+	ShardCoordinator      vmcommon.Coordinator
+	Hasher                vmcommon.Hasher
+	DebugGasTrace         bool
+}
+
+// blockchainHook is a configurable in-memory implementation of the blockchain hook, backed by an accounts adapter
+type blockchainHook struct {
+	accounts              vmcommon.AccountsAdapter
+	builtInFunctions      vmcommon.BuiltInFunctionContainer
+	nftStorageHandler     vmcommon.SimpleDCTNFTStorageHandler
+	globalSettingsHandler vmcommon.DCTGlobalSettingsHandler
+	marshaller            vmcommon.Marshalizer
+	shardCoordinator      vmcommon.Coordinator
+	hasher                vmcommon.Hasher
+	debugGasTrace         bool
+
+	mutBlockInfo     sync.RWMutex
+	currentBlockInfo BlockInfo
+	lastBlockInfo    BlockInfo
+	blockHashes      map[uint64][]byte
+
+	mutCompiledCode sync.RWMutex
+	compiledCode    map[string][]byte
+}
+
+// NewBlockchainHook creates a new in-memory blockchain hook
+func NewBlockchainHook(args ArgsBlockchainHook) (*blockchainHook, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.BuiltInFunctions) {
+		return nil, ErrNilBuiltInFunctionsContainer
+	}
+	if check.IfNil(args.NFTStorageHandler) {
+		return nil, ErrNilNFTStorageHandler
+	}
+	if check.IfNil(args.GlobalSettingsHandler) {
+		return nil, ErrNilGlobalSettingsHandler
+	}
+	if check.IfNil(args.Marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+
+	return &blockchainHook{
+		accounts:              args.Accounts,
+		builtInFunctions:      args.BuiltInFunctions,
+		nftStorageHandler:     args.NFTStorageHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		marshaller:            args.Marshalizer,
+		shardCoordinator:      args.ShardCoordinator,
+		hasher:                args.Hasher,
+		debugGasTrace:         args.DebugGasTrace,
+		blockHashes:           make(map[uint64][]byte),
+		compiledCode:          make(map[string][]byte),
+	}, nil
+}
+
+// SetCurrentBlockInfo sets the header information of the block in execution
+func (bh *blockchainHook) SetCurrentBlockInfo(blockInfo BlockInfo) {
+	bh.mutBlockInfo.Lock()
+	bh.currentBlockInfo = blockInfo
+	bh.mutBlockInfo.Unlock()
+}
+
+// SetLastBlockInfo sets the header information of the last committed block
+func (bh *blockchainHook) SetLastBlockInfo(blockInfo BlockInfo) {
+	bh.mutBlockInfo.Lock()
+	bh.lastBlockInfo = blockInfo
+	bh.mutBlockInfo.Unlock()
+}
+
+// SetBlockhash sets the hash of the block with the provided nonce
+func (bh *blockchainHook) SetBlockhash(nonce uint64, hash []byte) {
+	bh.mutBlockInfo.Lock()
+	bh.blockHashes[nonce] = hash
+	bh.mutBlockInfo.Unlock()
+}
+
+// NewAddress computes the address of a new SC account from the creator address and nonce
+func (bh *blockchainHook) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	addressLength := bh.hasher.Size()
+	if len(creatorAddress) != addressLength {
+		return nil, ErrAddressLengthNotCorrect
+	}
+	if len(vmType) != vmcommon.VMTypeLen {
+		return nil, fmt.Errorf("%w, vm type length should be %d", ErrAddressLengthNotCorrect, vmcommon.VMTypeLen)
+	}
+
+	nonceBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonceBytes, creatorNonce)
+	base := bh.hasher.Compute(string(append(cloneBytes(creatorAddress), nonceBytes...)))
+
+	prefixMask := make([]byte, vmcommon.NumInitCharactersForScAddress-vmcommon.VMTypeLen)
+	prefixMask = append(prefixMask, vmType...)
+
+	copy(base[:vmcommon.NumInitCharactersForScAddress], prefixMask)
+	copy(base[addressLength-vmcommon.ShardIdentiferLen:], creatorAddress[addressLength-vmcommon.ShardIdentiferLen:])
+
+	return base, nil
+}
+
+// GetStorageData returns the value saved under the provided key for the provided account.
+// Returns an empty value if the account does not exist
+func (bh *blockchainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	userAcc, err := bh.GetUserAccount(accountAddress)
+	if err != nil {
+		return make([]byte, 0), 0, nil
+	}
+
+	return userAcc.AccountDataHandler().RetrieveValue(index)
+}
+
+// GetBlockhash returns the hash of the block with the provided nonce, if it was set
+func (bh *blockchainHook) GetBlockhash(nonce uint64) ([]byte, error) {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	hash, ok := bh.blockHashes[nonce]
+	if !ok {
+		return nil, fmt.Errorf("%w for nonce %d", ErrBlockhashNotFound, nonce)
+	}
+
+	return hash, nil
+}
+
+// LastNonce returns the nonce from the last committed block
+func (bh *blockchainHook) LastNonce() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlockInfo.Nonce
+}
+
+// LastRound returns the round from the last committed block
+func (bh *blockchainHook) LastRound() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlockInfo.Round
+}
+
+// LastTimeStamp returns the timestamp from the last committed block
+func (bh *blockchainHook) LastTimeStamp() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlockInfo.TimeStamp
+}
+
+// LastRandomSeed returns the random seed from the last committed block
+func (bh *blockchainHook) LastRandomSeed() []byte {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlockInfo.RandomSeed
+}
+
+// LastEpoch returns the epoch from the last committed block
+func (bh *blockchainHook) LastEpoch() uint32 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlockInfo.Epoch
+}
+
+// GetStateRootHash returns the state root hash from the last committed block
+func (bh *blockchainHook) GetStateRootHash() []byte {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.lastBlockInfo.RootHash
+}
+
+// CurrentNonce returns the nonce from the current block
+func (bh *blockchainHook) CurrentNonce() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.currentBlockInfo.Nonce
+}
+
+// CurrentRound returns the round from the current block
+func (bh *blockchainHook) CurrentRound() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.currentBlockInfo.Round
+}
+
+// CurrentTimeStamp returns the timestamp from the current block
+func (bh *blockchainHook) CurrentTimeStamp() uint64 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.currentBlockInfo.TimeStamp
+}
+
+// CurrentRandomSeed returns the random seed from the current block
+func (bh *blockchainHook) CurrentRandomSeed() []byte {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.currentBlockInfo.RandomSeed
+}
+
+// CurrentEpoch returns the epoch from the current block
+func (bh *blockchainHook) CurrentEpoch() uint32 {
+	bh.mutBlockInfo.RLock()
+	defer bh.mutBlockInfo.RUnlock()
+
+	return bh.currentBlockInfo.Epoch
+}
+
+// ProcessBuiltInFunction dispatches the input into the matching built-in function and saves the touched accounts.
+// In debug mode, the gas charges made by the built-in function are attached to the returned VMOutput
+func (bh *blockchainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	if input == nil {
+		return nil, ErrNilVmInput
+	}
+	if bh.debugGasTrace && input.GasTrace == nil {
+		inputWithGasTrace := *input
+		inputWithGasTrace.GasTrace = vmcommon.NewGasTrace()
+		input = &inputWithGasTrace
+	}
+
+	function, err := bh.builtInFunctions.Get(input.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	sndAccount, dstAccount, err := bh.getUserAccounts(input)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput, err := function.ProcessBuiltinFunction(sndAccount, dstAccount, input)
+	if err != nil {
+		if bh.debugGasTrace {
+			log.Debug("blockchainHook.ProcessBuiltInFunction failed", "function", input.Function, "error", err,
+				"gas provided", input.GasProvided, "gas trace", input.GasTrace.Entries())
+		}
+		return nil, err
+	}
+	if bh.debugGasTrace {
+		vmOutput.GasTrace = input.GasTrace
+	}
+
+	if !check.IfNil(sndAccount) {
+		err = bh.accounts.SaveAccount(sndAccount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !check.IfNil(dstAccount) && !bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		err = bh.accounts.SaveAccount(dstAccount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vmOutput, nil
+}
+
+func (bh *blockchainHook) getUserAccounts(input *vmcommon.ContractCallInput) (vmcommon.UserAccountHandler, vmcommon.UserAccountHandler, error) {
+	var sndAccount vmcommon.UserAccountHandler
+	var err error
+	if bh.isInSelfShard(input.CallerAddr) {
+		sndAccount, err = bh.loadUserAccount(input.CallerAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		return sndAccount, sndAccount, nil
+	}
+
+	var dstAccount vmcommon.UserAccountHandler
+	if bh.isInSelfShard(input.RecipientAddr) {
+		dstAccount, err = bh.loadUserAccount(input.RecipientAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return sndAccount, dstAccount, nil
+}
+
+func (bh *blockchainHook) isInSelfShard(address []byte) bool {
+	return bh.shardCoordinator.ComputeId(address) == bh.shardCoordinator.SelfId()
+}
+
+func (bh *blockchainHook) loadUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := bh.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAcc, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAcc, nil
+}
+
+// GetBuiltinFunctionNames returns the names of the built-in functions from the container
+func (bh *blockchainHook) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	return bh.builtInFunctions.Keys()
+}
+
+// GetAllState returns all the key-value pairs saved in the account data
+func (bh *blockchainHook) GetAllState(address []byte) (map[string][]byte, error) {
+	userAcc, err := bh.GetUserAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	dataGetter, ok := userAcc.(accountDataGetter)
+	if !ok {
+		return nil, ErrOperationNotSupported
+	}
+
+	return dataGetter.GetAllData(), nil
+}
+
+// GetUserAccount returns the existing user account saved under the provided address
+func (bh *blockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := bh.accounts.GetExistingAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAcc, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAcc, nil
+}
+
+// GetCode returns the code of the provided account
+func (bh *blockchainHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	if check.IfNil(account) {
+		return nil
+	}
+
+	return bh.accounts.GetCode(account.GetCodeHash())
+}
+
+// GetShardOfAddress returns the shard ID of the provided address
+func (bh *blockchainHook) GetShardOfAddress(address []byte) uint32 {
+	return bh.shardCoordinator.ComputeId(address)
+}
+
+// IsSmartContract returns whether the address points to a smart contract
+func (bh *blockchainHook) IsSmartContract(address []byte) bool {
+	return vmcommon.IsSmartContractAddress(address)
+}
+
+// IsPayable checks whether the provided receiver can receive MOA from the provided sender
+func (bh *blockchainHook) IsPayable(sndAddress []byte, recvAddress []byte) (bool, error) {
+	if !vmcommon.IsSmartContractAddress(recvAddress) {
+		return true, nil
+	}
+
+	userAcc, err := bh.GetUserAccount(recvAddress)
+	if err != nil {
+		return false, nil
+	}
+
+	metadata := vmcommon.CodeMetadataFromBytes(userAcc.GetCodeMetadata())
+	if metadata.Payable {
+		return true, nil
+	}
+
+	return metadata.PayableBySC && vmcommon.IsSmartContractAddress(sndAddress), nil
+}
+
+// SaveCompiledCode saves the compiled code under the provided code hash
+func (bh *blockchainHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	bh.mutCompiledCode.Lock()
+	bh.compiledCode[string(codeHash)] = cloneBytes(code)
+	bh.mutCompiledCode.Unlock()
+}
+
+// GetCompiledCode returns the compiled code saved under the provided code hash, if any
+func (bh *blockchainHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	bh.mutCompiledCode.RLock()
+	defer bh.mutCompiledCode.RUnlock()
+
+	code, found := bh.compiledCode[string(codeHash)]
+	return found, code
+}
+
+// ClearCompiledCodes removes all the saved compiled codes
+func (bh *blockchainHook) ClearCompiledCodes() {
+	bh.mutCompiledCode.Lock()
+	bh.compiledCode = make(map[string][]byte)
+	bh.mutCompiledCode.Unlock()
+}
+
+// GetDCTToken returns the DCT data of the provided account. Returns an empty token if the account does not exist
+func (bh *blockchainHook) GetDCTToken(address []byte, tokenID []byte, nonce uint64) (*dct.DCToken, error) {
+	dctData := &dct.DCToken{
+		Value: big.NewInt(0),
+		Type:  uint32(core.Fungible),
+	}
+
+	userAcc, err := bh.GetUserAccount(address)
+	if err != nil {
+		return dctData, nil
+	}
+
+	dctTokenKey := []byte(dctKeyPrefix + string(tokenID))
+	if nonce == 0 {
+		marshaledData, _, errRetrieve := userAcc.AccountDataHandler().RetrieveValue(dctTokenKey)
+		if errRetrieve != nil || len(marshaledData) == 0 {
+			return dctData, nil
+		}
+
+		err = bh.marshaller.Unmarshal(dctData, marshaledData)
+		if err != nil {
+			return nil, err
+		}
+
+		return dctData, nil
+	}
+
+	dctData, _, err = bh.nftStorageHandler.GetDCTNFTTokenOnDestination(userAcc, dctTokenKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return dctData, nil
+}
+
+// IsPaused returns true if the provided token is paused globally
+func (bh *blockchainHook) IsPaused(tokenID []byte) bool {
+	return bh.globalSettingsHandler.IsPaused([]byte(dctKeyPrefix + string(tokenID)))
+}
+
+// IsLimitedTransfer returns true if the provided token has limited transfers
+func (bh *blockchainHook) IsLimitedTransfer(tokenID []byte) bool {
+	return bh.globalSettingsHandler.IsLimitedTransfer([]byte(dctKeyPrefix + string(tokenID)))
+}
+
+// GetSnapshot returns the number of entries in the accounts journal
+func (bh *blockchainHook) GetSnapshot() int {
+	return bh.accounts.JournalLen()
+}
+
+// RevertToSnapshot reverts the accounts journal up to the provided snapshot
+func (bh *blockchainHook) RevertToSnapshot(snapshot int) error {
+	return bh.accounts.RevertToSnapshot(snapshot)
+}
+
+func cloneBytes(b []byte) []byte {
+	clone := make([]byte, len(b))
+	copy(clone, b)
+	return clone
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bh *blockchainHook) IsInterfaceNil() bool {
+	return bh == nil
+}
@@ -0,0 +1,318 @@
+package blockchainHook
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core"
+	"github.com/kalyan3104/k-core/core/check"
+	"github.com/kalyan3104/k-core/data/dct"
+	"github.com/kalyan3104/k-core/hashing/sha256"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/builtInFunctions"
+	"github.com/kalyan3104/k-vm-common-go/mock"
+	"github.com/kalyan3104/k-vm-common-go/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	tokenID        = []byte("TKN-abcdef")
+	tokenKey       = []byte(dctKeyPrefix + string(tokenID))
+	senderAddress  = bytes.Repeat([]byte{1}, 32)
+	receiverAddr   = bytes.Repeat([]byte{2}, 32)
+	contractAddr   = append(make([]byte, vmcommon.NumInitCharactersForScAddress), bytes.Repeat([]byte{3}, 22)...)
+	testMarshaller = &mock.MarshalizerMock{}
+)
+
+func createMockArgs(t *testing.T) ArgsBlockchainHook {
+	accounts, err := state.NewAccountsDB(state.ArgsNewAccountsDB{Hasher: sha256.NewSha256()})
+	require.Nil(t, err)
+
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           mock.CreateGasMap(1),
+		MapDNSAddresses:                  make(map[string]struct{}),
+		Marshalizer:                      testMarshaller,
+		Accounts:                         accounts,
+		ShardCoordinator:                 mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler:              &mock.EnableEpochsHandlerStub{},
+		MaxNumOfAddressesForTransferRole: 100,
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	return ArgsBlockchainHook{
+		Accounts:              accounts,
+		BuiltInFunctions:      creator.BuiltInFunctionContainer(),
+		NFTStorageHandler:     creator.NFTStorageHandler(),
+		GlobalSettingsHandler: creator.DCTGlobalSettingsHandler(),
+		Marshalizer:           testMarshaller,
+		ShardCoordinator:      mock.NewMultiShardsCoordinatorMock(1),
+		Hasher:                sha256.NewSha256(),
+	}
+}
+
+func saveAccount(t *testing.T, accounts vmcommon.AccountsAdapter, address []byte, handler func(acc vmcommon.UserAccountHandler)) {
+	account, err := accounts.LoadAccount(address)
+	require.Nil(t, err)
+
+	userAcc := account.(vmcommon.UserAccountHandler)
+	handler(userAcc)
+	require.Nil(t, accounts.SaveAccount(userAcc))
+}
+
+func TestNewBlockchainHook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		changeArgs  func(args *ArgsBlockchainHook)
+		expectedErr error
+	}{
+		{"nil accounts", func(args *ArgsBlockchainHook) { args.Accounts = nil }, ErrNilAccountsAdapter},
+		{"nil built-in functions", func(args *ArgsBlockchainHook) { args.BuiltInFunctions = nil }, ErrNilBuiltInFunctionsContainer},
+		{"nil nft storage handler", func(args *ArgsBlockchainHook) { args.NFTStorageHandler = nil }, ErrNilNFTStorageHandler},
+		{"nil global settings handler", func(args *ArgsBlockchainHook) { args.GlobalSettingsHandler = nil }, ErrNilGlobalSettingsHandler},
+		{"nil marshaller", func(args *ArgsBlockchainHook) { args.Marshalizer = nil }, ErrNilMarshalizer},
+		{"nil shard coordinator", func(args *ArgsBlockchainHook) { args.ShardCoordinator = nil }, ErrNilShardCoordinator},
+		{"nil hasher", func(args *ArgsBlockchainHook) { args.Hasher = nil }, ErrNilHasher},
+		{"should work", func(args *ArgsBlockchainHook) {}, nil},
+	}
+
+	for _, tt := range tests {
+		args := createMockArgs(t)
+		tt.changeArgs(&args)
+
+		hook, err := NewBlockchainHook(args)
+		assert.Equal(t, tt.expectedErr, err, tt.name)
+		assert.Equal(t, tt.expectedErr != nil, check.IfNil(hook), tt.name)
+	}
+}
+
+func TestBlockchainHook_BlockInfo(t *testing.T) {
+	t.Parallel()
+
+	hook, _ := NewBlockchainHook(createMockArgs(t))
+	hook.SetCurrentBlockInfo(BlockInfo{Nonce: 10, Round: 11, TimeStamp: 12, Epoch: 13, RandomSeed: []byte("current")})
+	hook.SetLastBlockInfo(BlockInfo{Nonce: 9, Round: 10, TimeStamp: 11, Epoch: 12, RandomSeed: []byte("last"), RootHash: []byte("root")})
+	hook.SetBlockhash(9, []byte("hash"))
+
+	assert.Equal(t, uint64(10), hook.CurrentNonce())
+	assert.Equal(t, uint64(11), hook.CurrentRound())
+	assert.Equal(t, uint64(12), hook.CurrentTimeStamp())
+	assert.Equal(t, uint32(13), hook.CurrentEpoch())
+	assert.Equal(t, []byte("current"), hook.CurrentRandomSeed())
+	assert.Equal(t, uint64(9), hook.LastNonce())
+	assert.Equal(t, uint64(10), hook.LastRound())
+	assert.Equal(t, uint64(11), hook.LastTimeStamp())
+	assert.Equal(t, uint32(12), hook.LastEpoch())
+	assert.Equal(t, []byte("last"), hook.LastRandomSeed())
+	assert.Equal(t, []byte("root"), hook.GetStateRootHash())
+
+	hash, err := hook.GetBlockhash(9)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("hash"), hash)
+
+	_, err = hook.GetBlockhash(8)
+	assert.True(t, errors.Is(err, ErrBlockhashNotFound))
+}
+
+func TestBlockchainHook_NewAddress(t *testing.T) {
+	t.Parallel()
+
+	hook, _ := NewBlockchainHook(createMockArgs(t))
+	vmType := []byte{5, 0}
+
+	_, err := hook.NewAddress([]byte("short"), 0, vmType)
+	assert.True(t, errors.Is(err, ErrAddressLengthNotCorrect))
+
+	_, err = hook.NewAddress(senderAddress, 0, []byte{5})
+	assert.True(t, errors.Is(err, ErrAddressLengthNotCorrect))
+
+	address, err := hook.NewAddress(senderAddress, 7, vmType)
+	assert.Nil(t, err)
+	assert.True(t, vmcommon.IsSmartContractAddress(address))
+	assert.Equal(t, vmType, address[vmcommon.NumInitCharactersForScAddress-vmcommon.VMTypeLen:vmcommon.NumInitCharactersForScAddress])
+	assert.Equal(t, senderAddress[30:], address[30:])
+
+	sameAddress, _ := hook.NewAddress(senderAddress, 7, vmType)
+	assert.Equal(t, address, sameAddress)
+	otherAddress, _ := hook.NewAddress(senderAddress, 8, vmType)
+	assert.NotEqual(t, address, otherAddress)
+}
+
+func TestBlockchainHook_StorageAndState(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgs(t)
+	hook, _ := NewBlockchainHook(args)
+
+	value, _, err := hook.GetStorageData(senderAddress, []byte("key"))
+	assert.Nil(t, err)
+	assert.Len(t, value, 0)
+
+	saveAccount(t, args.Accounts, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		_ = acc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+	})
+
+	value, _, err = hook.GetStorageData(senderAddress, []byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	allState, err := hook.GetAllState(senderAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"key": []byte("value")}, allState)
+
+	_, err = hook.GetAllState(receiverAddr)
+	assert.NotNil(t, err)
+}
+
+func TestBlockchainHook_IsPayable(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgs(t)
+	hook, _ := NewBlockchainHook(args)
+
+	isPayable, err := hook.IsPayable(senderAddress, receiverAddr)
+	assert.Nil(t, err)
+	assert.True(t, isPayable)
+
+	isPayable, _ = hook.IsPayable(senderAddress, contractAddr)
+	assert.False(t, isPayable)
+
+	metadata := &vmcommon.CodeMetadata{PayableBySC: true}
+	saveAccount(t, args.Accounts, contractAddr, func(acc vmcommon.UserAccountHandler) {
+		acc.(interface{ SetCodeMetadata([]byte) }).SetCodeMetadata(metadata.ToBytes())
+	})
+
+	isPayable, _ = hook.IsPayable(senderAddress, contractAddr)
+	assert.False(t, isPayable)
+	isPayable, _ = hook.IsPayable(contractAddr, contractAddr)
+	assert.True(t, isPayable)
+}
+
+func TestBlockchainHook_CompiledCode(t *testing.T) {
+	t.Parallel()
+
+	hook, _ := NewBlockchainHook(createMockArgs(t))
+
+	found, _ := hook.GetCompiledCode([]byte("hash"))
+	assert.False(t, found)
+
+	hook.SaveCompiledCode([]byte("hash"), []byte("code"))
+	found, code := hook.GetCompiledCode([]byte("hash"))
+	assert.True(t, found)
+	assert.Equal(t, []byte("code"), code)
+
+	hook.ClearCompiledCodes()
+	found, _ = hook.GetCompiledCode([]byte("hash"))
+	assert.False(t, found)
+}
+
+func TestBlockchainHook_ProcessBuiltInFunctionAndRevert(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgs(t)
+	hook, _ := NewBlockchainHook(args)
+	assert.Equal(t, args.BuiltInFunctions.Len(), len(hook.GetBuiltinFunctionNames()))
+
+	saveAccount(t, args.Accounts, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		marshaledData, _ := testMarshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+		_ = acc.AccountDataHandler().SaveKeyValue(tokenKey, marshaledData)
+	})
+	snapshot := hook.GetSnapshot()
+
+	_, err := hook.ProcessBuiltInFunction(nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  senderAddress,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{tokenID, big.NewInt(40).Bytes()},
+			GasProvided: 100,
+		},
+		RecipientAddr: receiverAddr,
+		Function:      "missing",
+	}
+	_, err = hook.ProcessBuiltInFunction(input)
+	assert.True(t, errors.Is(err, builtInFunctions.ErrInvalidContainerKey))
+
+	input.Function = core.BuiltInFunctionDCTTransfer
+	vmOutput, err := hook.ProcessBuiltInFunction(input)
+	require.Nil(t, err)
+	assert.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	senderToken, err := hook.GetDCTToken(senderAddress, tokenID, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(60), senderToken.Value)
+	receiverToken, _ := hook.GetDCTToken(receiverAddr, tokenID, 0)
+	assert.Equal(t, big.NewInt(40), receiverToken.Value)
+	assert.False(t, hook.IsPaused(tokenID))
+	assert.False(t, hook.IsLimitedTransfer(tokenID))
+
+	err = hook.RevertToSnapshot(snapshot)
+	assert.Nil(t, err)
+	senderToken, _ = hook.GetDCTToken(senderAddress, tokenID, 0)
+	assert.Equal(t, big.NewInt(100), senderToken.Value)
+	receiverToken, _ = hook.GetDCTToken(receiverAddr, tokenID, 0)
+	assert.Equal(t, big.NewInt(0), receiverToken.Value)
+}
+
+func TestBlockchainHook_GetDCTTokenNFT(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgs(t)
+	hook, _ := NewBlockchainHook(args)
+
+	nftKey := append(append([]byte{}, tokenKey...), big.NewInt(3).Bytes()...)
+	saveAccount(t, args.Accounts, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		marshaledData, _ := testMarshaller.Marshal(&dct.DCToken{
+			Value:         big.NewInt(1),
+			Type:          uint32(core.NonFungible),
+			TokenMetaData: &dct.MetaData{Nonce: 3, Name: []byte("name")},
+		})
+		_ = acc.AccountDataHandler().SaveKeyValue(nftKey, marshaledData)
+	})
+
+	nft, err := hook.GetDCTToken(senderAddress, tokenID, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1), nft.Value)
+	assert.Equal(t, []byte("name"), nft.TokenMetaData.Name)
+}
+
+func TestBlockchainHook_ProcessBuiltInFunctionWithDebugGasTrace(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgs(t)
+	saveAccount(t, args.Accounts, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		marshaledData, _ := testMarshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+		_ = acc.AccountDataHandler().SaveKeyValue(tokenKey, marshaledData)
+	})
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  senderAddress,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{tokenID, big.NewInt(10).Bytes()},
+			GasProvided: 100,
+		},
+		RecipientAddr: receiverAddr,
+		Function:      core.BuiltInFunctionDCTTransfer,
+	}
+
+	hook, _ := NewBlockchainHook(args)
+	vmOutput, err := hook.ProcessBuiltInFunction(input)
+	require.Nil(t, err)
+	assert.Nil(t, vmOutput.GasTrace)
+
+	args.DebugGasTrace = true
+	hook, _ = NewBlockchainHook(args)
+	vmOutput, err = hook.ProcessBuiltInFunction(input)
+	require.Nil(t, err)
+	assert.Nil(t, input.GasTrace)
+	assert.Equal(t, []vmcommon.GasTraceEntry{{Label: "FunctionCost", Units: 1, Total: 1}}, vmOutput.GasTrace.Entries())
+	assert.Equal(t, input.GasProvided-vmOutput.GasRemaining, vmOutput.GasTrace.Total())
+}
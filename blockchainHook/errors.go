@@ -0,0 +1,39 @@
+package blockchainHook
+
+import "errors"
+
+// ErrNilAccountsAdapter signals that a nil accounts adapter has been provided
+var ErrNilAccountsAdapter = errors.New("nil accounts adapter")
+
+// ErrNilBuiltInFunctionsContainer signals that a nil built-in functions container has been provided
+var ErrNilBuiltInFunctionsContainer = errors.New("nil built-in functions container")
+
+// ErrNilNFTStorageHandler signals that a nil NFT storage handler has been provided
+var ErrNilNFTStorageHandler = errors.New("nil NFT storage handler")
+
+// ErrNilGlobalSettingsHandler signals that a nil global settings handler has been provided
+var ErrNilGlobalSettingsHandler = errors.New("nil global settings handler")
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilShardCoordinator signals that a nil shard coordinator has been provided
+var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrNilVmInput signals that a nil vm input has been provided
+var ErrNilVmInput = errors.New("nil vm input")
+
+// ErrAddressLengthNotCorrect signals that the provided address does not have the expected length
+var ErrAddressLengthNotCorrect = errors.New("address length is not correct")
+
+// ErrBlockhashNotFound signals that no block hash is known for the requested nonce
+var ErrBlockhashNotFound = errors.New("block hash not found")
+
+// ErrWrongTypeAssertion signals that a type assertion failed
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrOperationNotSupported signals that the accounts adapter does not support the requested operation
+var ErrOperationNotSupported = errors.New("operation not supported by the accounts adapter")
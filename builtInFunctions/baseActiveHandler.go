@@ -1,5 +1,7 @@
 package builtInFunctions
 
+import vmcommon "github.com/kalyan3104/k-vm-common-go"
+
 type baseAlwaysActiveHandler struct {
 }
 
@@ -26,3 +28,9 @@ func (b *baseActiveHandler) IsActive() bool {
 func (b *baseActiveHandler) IsInterfaceNil() bool {
 	return b == nil
 }
+
+func flagActiveHandler(enableEpochsHandler vmcommon.EnableEpochsHandler, flag vmcommon.EnableEpochFlag) func() bool {
+	return func() bool {
+		return enableEpochsHandler.IsFlagEnabled(flag)
+	}
+}
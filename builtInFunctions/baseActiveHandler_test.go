@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
 	"github.com/kalyan3104/k-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 )
@@ -30,6 +31,14 @@ func TestBaseActiveHandler_IsActive(t *testing.T) {
 
 	enableEpochsHandler.IsSCDeployFlagEnabledField = true
 	assert.True(t, handler.IsActive())
+
+	handler = &baseActiveHandler{
+		activeHandler: flagActiveHandler(&enableEpochsHandler, vmcommon.SendAlwaysFlag),
+	}
+	assert.False(t, handler.IsActive())
+
+	enableEpochsHandler.IsSendAlwaysFlagEnabledField = true
+	assert.True(t, handler.IsActive())
 }
 
 func TestBaseAlwaysActiveHandler_IsActive(t *testing.T) {
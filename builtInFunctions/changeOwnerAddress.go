@@ -7,19 +7,40 @@ import (
 
 	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type changeOwnerAddress struct {
 	baseAlwaysActiveHandler
-	gasCost      uint64
-	mutExecution sync.RWMutex
+	gasCost             uint64
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+	mutExecution        sync.RWMutex
 }
 
-// NewChangeOwnerAddressFunc create a new change owner built in function
+// ArgsNewChangeOwnerAddress defines the argument list for new change owner built in function
+type ArgsNewChangeOwnerAddress struct {
+	GasCost             uint64
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewChangeOwnerAddressFunc create a new change owner built in function that does not emit logs
 func NewChangeOwnerAddressFunc(gasCost uint64) *changeOwnerAddress {
 	return &changeOwnerAddress{gasCost: gasCost}
 }
 
+// NewChangeOwnerAddress create a new change owner built in function that emits logs once the built-in functions
+// logs flag is enabled
+func NewChangeOwnerAddress(args ArgsNewChangeOwnerAddress) (*changeOwnerAddress, error) {
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	c := NewChangeOwnerAddressFunc(args.GasCost)
+	c.enableEpochsHandler = args.EnableEpochsHandler
+
+	return c, nil
+}
+
 // SetNewGasConfig is called whenever gas cost is changed
 func (c *changeOwnerAddress) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	if gasCost == nil {
@@ -51,6 +72,7 @@ func (c *changeOwnerAddress) ProcessBuiltinFunction(
 	if len(vmInput.Arguments[0]) != len(vmInput.CallerAddr) {
 		return nil, ErrInvalidAddressLength
 	}
+	vmInput.GasTrace.Charge(gasTraceFunctionCost, c.gasCost)
 	if vmInput.GasProvided < c.gasCost {
 		return nil, ErrNotEnoughGas
 	}
@@ -69,7 +91,16 @@ func (c *changeOwnerAddress) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	return &vmcommon.VMOutput{GasRemaining: gasRemaining, ReturnCode: vmcommon.Ok}, nil
+	vmOutput := &vmcommon.VMOutput{GasRemaining: gasRemaining, ReturnCode: vmcommon.Ok}
+	if isBuiltInFunctionsLogsFlagEnabled(c.enableEpochsHandler) {
+		events.AddToVMOutput(vmOutput, &events.ChangeOwnerAddress{
+			Contract: acntDst.AddressBytes(),
+			OldOwner: vmInput.CallerAddr,
+			NewOwner: vmInput.Arguments[0],
+		})
+	}
+
+	return vmOutput, nil
 }
 
 func computeGasRemaining(snd vmcommon.UserAccountHandler, gasProvided uint64, gasToUse uint64) uint64 {
@@ -85,6 +116,30 @@ func computeGasRemaining(snd vmcommon.UserAccountHandler, gasProvided uint64, ga
 	return gasProvided - gasToUse
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (c *changeOwnerAddress) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+
+	c.mutExecution.RLock()
+	defer c.mutExecution.RUnlock()
+
+	return c.gasCost, nil
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (c *changeOwnerAddress) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+
+	b := newReadWriteSetBuilder()
+	b.readWrite(vmInput.RecipientAddr, nil)
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (c *changeOwnerAddress) IsInterfaceNil() bool {
 	return c == nil
@@ -7,6 +7,7 @@ import (
 	"github.com/kalyan3104/k-core/core/check"
 	"github.com/kalyan3104/k-vm-common-go/mock"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,6 +21,24 @@ func TestNewChangeOwnerAddressFunc(t *testing.T) {
 	require.True(t, coa.IsActive())
 }
 
+func TestNewChangeOwnerAddress(t *testing.T) {
+	t.Parallel()
+
+	coa, err := NewChangeOwnerAddress(ArgsNewChangeOwnerAddress{GasCost: 100})
+	require.Equal(t, ErrNilEnableEpochsHandler, err)
+	require.True(t, check.IfNil(coa))
+
+	gasCost := uint64(100)
+	coa, err = NewChangeOwnerAddress(ArgsNewChangeOwnerAddress{
+		GasCost:             gasCost,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+	})
+	require.Nil(t, err)
+	require.False(t, check.IfNil(coa))
+	require.Equal(t, gasCost, coa.gasCost)
+	require.True(t, coa.IsActive())
+}
+
 func TestChangeOwnerAddress_SetNewGasConfig(t *testing.T) {
 	t.Parallel()
 
@@ -35,7 +54,7 @@ func TestChangeOwnerAddress_SetNewGasConfig(t *testing.T) {
 func TestChangeOwnerAddress_ProcessBuiltinFunction(t *testing.T) {
 	t.Parallel()
 
-	coa := changeOwnerAddress{}
+	coa := changeOwnerAddress{enableEpochsHandler: &mock.EnableEpochsHandlerStub{}}
 
 	owner := []byte("send")
 	addr := []byte("addr")
@@ -70,3 +89,29 @@ func TestChangeOwnerAddress_ProcessBuiltinFunction(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, vmOutput.GasRemaining, vmInput.GasProvided-coa.gasCost)
 }
+
+func TestChangeOwnerAddress_ProcessBuiltinFunctionLogs(t *testing.T) {
+	t.Parallel()
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{IsBuiltInFunctionsLogsFlagEnabledField: true}
+	coa, _ := NewChangeOwnerAddress(ArgsNewChangeOwnerAddress{GasCost: 1, EnableEpochsHandler: enableEpochsHandler})
+
+	owner := []byte("send")
+	newOwner := []byte("0000")
+	acc := mock.NewUserAccount([]byte("addr"))
+	acc.OwnerAddress = owner
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{CallerAddr: owner, CallValue: big.NewInt(0), GasProvided: 10, Arguments: [][]byte{newOwner}},
+	}
+
+	vmOutput, err := coa.ProcessBuiltinFunction(acc, acc, vmInput)
+	require.Nil(t, err)
+	expectedLog := (&events.ChangeOwnerAddress{Contract: []byte("addr"), OldOwner: owner, NewOwner: newOwner}).LogEntry()
+	require.Equal(t, []*vmcommon.LogEntry{expectedLog}, vmOutput.Logs)
+
+	enableEpochsHandler.IsBuiltInFunctionsLogsFlagEnabledField = false
+	acc.OwnerAddress = owner
+	vmOutput, err = coa.ProcessBuiltinFunction(acc, acc, vmInput)
+	require.Nil(t, err)
+	require.Empty(t, vmOutput.Logs)
+}
@@ -8,19 +8,40 @@ import (
 	"github.com/kalyan3104/k-core/core/check"
 	"github.com/kalyan3104/k-core/data/vm"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type claimDeveloperRewards struct {
 	baseAlwaysActiveHandler
-	gasCost      uint64
-	mutExecution sync.RWMutex
+	gasCost             uint64
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+	mutExecution        sync.RWMutex
 }
 
-// NewClaimDeveloperRewardsFunc returns a new developer rewards implementation
+// ArgsNewClaimDeveloperRewards defines the argument list for new developer rewards built in function
+type ArgsNewClaimDeveloperRewards struct {
+	GasCost             uint64
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewClaimDeveloperRewardsFunc returns a new developer rewards implementation that does not emit logs
 func NewClaimDeveloperRewardsFunc(gasCost uint64) *claimDeveloperRewards {
 	return &claimDeveloperRewards{gasCost: gasCost}
 }
 
+// NewClaimDeveloperRewards returns a new developer rewards implementation that emits logs once the built-in
+// functions logs flag is enabled
+func NewClaimDeveloperRewards(args ArgsNewClaimDeveloperRewards) (*claimDeveloperRewards, error) {
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	c := NewClaimDeveloperRewardsFunc(args.GasCost)
+	c.enableEpochsHandler = args.EnableEpochsHandler
+
+	return c, nil
+}
+
 // SetNewGasConfig is called whenever gas cost is changed
 func (c *claimDeveloperRewards) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	if gasCost == nil {
@@ -46,6 +67,7 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 	if vmInput.CallValue.Cmp(zero) != 0 {
 		return nil, ErrBuiltInFunctionCalledWithValue
 	}
+	vmInput.GasTrace.Charge(gasTraceFunctionCost, c.gasCost)
 	gasRemaining := computeGasRemaining(acntSnd, vmInput.GasProvided, c.gasCost)
 	if check.IfNil(acntDst) {
 		// cross-shard call, in sender shard only the gas is taken out
@@ -86,6 +108,13 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 
 	vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
 	vmOutput.OutputAccounts[string(outputAcc.Address)] = outputAcc
+	if isBuiltInFunctionsLogsFlagEnabled(c.enableEpochsHandler) {
+		events.AddToVMOutput(vmOutput, &events.ClaimDeveloperRewards{
+			Contract: acntDst.AddressBytes(),
+			Receiver: vmInput.CallerAddr,
+			Value:    big.NewInt(0).Set(value),
+		})
+	}
 
 	if check.IfNil(acntSnd) {
 		return vmOutput, nil
@@ -103,6 +132,31 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (c *claimDeveloperRewards) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+
+	c.mutExecution.RLock()
+	defer c.mutExecution.RUnlock()
+
+	return c.gasCost, nil
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (c *claimDeveloperRewards) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+
+	b := newReadWriteSetBuilder()
+	b.readWrite(vmInput.RecipientAddr, nil)
+	b.readWrite(vmInput.CallerAddr, nil)
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object is nil
 func (c *claimDeveloperRewards) IsInterfaceNil() bool {
 	return c == nil
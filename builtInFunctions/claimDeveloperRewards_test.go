@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 	"github.com/kalyan3104/k-vm-common-go/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -51,3 +52,33 @@ func TestClaimDeveloperRewards_ProcessBuiltinFunction(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, vmOutput.GasRemaining, vmInput.GasProvided-cdr.gasCost)
 }
+
+func TestClaimDeveloperRewards_ProcessBuiltinFunctionLogs(t *testing.T) {
+	t.Parallel()
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{IsBuiltInFunctionsLogsFlagEnabledField: true}
+	cdr, _ := NewClaimDeveloperRewards(ArgsNewClaimDeveloperRewards{GasCost: 1, EnableEpochsHandler: enableEpochsHandler})
+
+	sender := []byte("sender")
+	acc := mock.NewUserAccount([]byte("addr12"))
+	acc.OwnerAddress = sender
+	acc.AddToDeveloperReward(big.NewInt(100))
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  sender,
+			GasProvided: 100,
+			CallValue:   big.NewInt(0),
+		},
+	}
+
+	vmOutput, err := cdr.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Nil(t, err)
+	expectedLog := (&events.ClaimDeveloperRewards{Contract: []byte("addr12"), Receiver: sender, Value: big.NewInt(100)}).LogEntry()
+	require.Equal(t, []*vmcommon.LogEntry{expectedLog}, vmOutput.Logs)
+
+	enableEpochsHandler.IsBuiltInFunctionsLogsFlagEnabledField = false
+	acc.AddToDeveloperReward(big.NewInt(100))
+	vmOutput, err = cdr.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Nil(t, err)
+	require.Empty(t, vmOutput.Logs)
+}
@@ -95,6 +95,11 @@ func (f *functionContainer) Keys() map[string]struct{} {
 	return keys
 }
 
+// EstimateGas returns the gas consumed by the built-in function named in the provided input
+func (f *functionContainer) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	return EstimateBuiltInFunctionGas(f, vmInput)
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (f *functionContainer) IsInterfaceNil() bool {
 	return f == nil
@@ -23,6 +23,8 @@ type ArgsCreateBuiltInFunctionContainer struct {
 	EnableEpochsHandler              vmcommon.EnableEpochsHandler
 	MaxNumOfAddressesForTransferRole uint32
 	ConfigAddress                    []byte
+	BuiltInFunctionsRegistry         BuiltInFunctionsRegistryHandler
+	RecordStorageUpdates             bool
 }
 
 type builtInFuncCreator struct {
@@ -38,6 +40,9 @@ type builtInFuncCreator struct {
 	enableEpochsHandler              vmcommon.EnableEpochsHandler
 	maxNumOfAddressesForTransferRole uint32
 	configAddress                    []byte
+	registry                         BuiltInFunctionsRegistryHandler
+	recordStorageUpdates             bool
+	storageUpdatesRecorder           *storageUpdatesRecorder
 }
 
 // NewBuiltInFunctionsCreator creates a component which will instantiate the built in functions contracts
@@ -67,6 +72,7 @@ func NewBuiltInFunctionsCreator(args ArgsCreateBuiltInFunctionContainer) (*built
 		enableEpochsHandler:              args.EnableEpochsHandler,
 		maxNumOfAddressesForTransferRole: args.MaxNumOfAddressesForTransferRole,
 		configAddress:                    args.ConfigAddress,
+		recordStorageUpdates:             args.RecordStorageUpdates,
 	}
 
 	var err error
@@ -76,6 +82,14 @@ func NewBuiltInFunctionsCreator(args ArgsCreateBuiltInFunctionContainer) (*built
 	}
 	b.builtInFunctions = NewBuiltInFunctionContainer()
 
+	b.registry = args.BuiltInFunctionsRegistry
+	if check.IfNil(b.registry) {
+		b.registry, err = NewDefaultBuiltInFunctionsRegistry()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return b, nil
 }
 
@@ -107,51 +121,32 @@ func (b *builtInFuncCreator) DCTGlobalSettingsHandler() vmcommon.DCTGlobalSettin
 	return b.dctGlobalSettingsHandler
 }
 
-// BuiltInFunctionContainer will return the built in function container
+// BuiltInFunctionContainer will return the built in function container. The functions activated by a flag and, when
+// the storage updates are recorded, all the functions are stored wrapped: use UnwrapBuiltInFunction before asserting
+// their optional interfaces
 func (b *builtInFuncCreator) BuiltInFunctionContainer() vmcommon.BuiltInFunctionContainer {
 	return b.builtInFunctions
 }
 
-// CreateBuiltInFunctionContainer will create the list of built-in functions
+// CreateBuiltInFunctionContainer will create the list of built-in functions from the registry
 func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
-
 	b.builtInFunctions = NewBuiltInFunctionContainer()
-	var newFunc vmcommon.BuiltinFunction
-	newFunc = NewClaimDeveloperRewardsFunc(b.gasConfig.BuiltInCost.ClaimDeveloperRewards)
-	err := b.builtInFunctions.Add(core.BuiltInFunctionClaimDeveloperRewards, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc = NewChangeOwnerAddressFunc(b.gasConfig.BuiltInCost.ChangeOwnerAddress)
-	err = b.builtInFunctions.Add(core.BuiltInFunctionChangeOwnerAddress, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewSaveUserNameFunc(b.gasConfig.BuiltInCost.SaveUserName, b.mapDNSAddresses, b.enableUserNameChange)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionSetUserName, newFunc)
-	if err != nil {
-		return err
-	}
 
-	newFunc, err = NewSaveKeyValueStorageFunc(b.gasConfig.BaseOperationCost, b.gasConfig.BuiltInCost.SaveKeyValue)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionSaveKeyValue, newFunc)
-	if err != nil {
-		return err
+	accounts := b.accounts
+	b.storageUpdatesRecorder = nil
+	if b.recordStorageUpdates {
+		b.storageUpdatesRecorder = NewStorageUpdatesRecorder()
+		accounts = b.storageUpdatesRecorder.WrapAccounts(b.accounts)
 	}
 
-	globalSettingsFunc, err := NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, core.BuiltInFunctionDCTPause, trueHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTPause, globalSettingsFunc)
+	globalSettingsFunc, err := NewDCTGlobalSettings(ArgsNewDCTGlobalSettings{
+		Accounts:            accounts,
+		Marshaller:          b.marshaller,
+		Set:                 true,
+		Function:            core.BuiltInFunctionDCTPause,
+		ActiveHandler:       trueHandler,
+		EnableEpochsHandler: b.enableEpochsHandler,
+	})
 	if err != nil {
 		return err
 	}
@@ -161,73 +156,9 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 	if err != nil {
 		return err
 	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionSetDCTRole, setRoleFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTTransferFunc(
-		b.gasConfig.BuiltInCost.DCTTransfer,
-		b.marshaller,
-		globalSettingsFunc,
-		b.shardCoordinator,
-		setRoleFunc,
-		b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTTransfer, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTBurnFunc(b.gasConfig.BuiltInCost.DCTBurn, b.marshaller, globalSettingsFunc, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTBurn, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, core.BuiltInFunctionDCTUnPause, trueHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnPause, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTRolesFunc(b.marshaller, false)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionUnSetDCTRole, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTLocalBurnFunc(b.gasConfig.BuiltInCost.DCTLocalBurn, b.marshaller, globalSettingsFunc, setRoleFunc)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTLocalBurn, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTLocalMintFunc(b.gasConfig.BuiltInCost.DCTLocalMint, b.marshaller, globalSettingsFunc, setRoleFunc)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTLocalMint, newFunc)
-	if err != nil {
-		return err
-	}
 
 	args := ArgsNewDCTDataStorage{
-		Accounts:              b.accounts,
+		Accounts:              accounts,
 		GlobalSettingsHandler: globalSettingsFunc,
 		Marshalizer:           b.marshaller,
 		EnableEpochsHandler:   b.enableEpochsHandler,
@@ -238,203 +169,63 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewDCTNFTAddQuantityFunc(b.gasConfig.BuiltInCost.DCTNFTAddQuantity, b.dctStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTAddQuantity, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTBurnFunc(b.gasConfig.BuiltInCost.DCTNFTBurn, b.dctStorageHandler, globalSettingsFunc, setRoleFunc)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTBurn, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTCreateFunc(b.gasConfig.BuiltInCost.DCTNFTCreate, b.gasConfig.BaseOperationCost, b.marshaller, globalSettingsFunc, setRoleFunc, b.dctStorageHandler, b.accounts, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTCreate, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, true, false)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTFreeze, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, false, false)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnFreeze, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTFreezeWipeFunc(b.dctStorageHandler, b.enableEpochsHandler, b.marshaller, false, true)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTWipe, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTTransferFunc(b.gasConfig.BuiltInCost.DCTNFTTransfer,
-		b.marshaller,
-		globalSettingsFunc,
-		b.accounts,
-		b.shardCoordinator,
-		b.gasConfig.BaseOperationCost,
-		setRoleFunc,
-		b.dctStorageHandler,
-		b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTTransfer, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTCreateRoleTransfer(b.marshaller, b.accounts, b.shardCoordinator)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTCreateRoleTransfer, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTUpdateAttributesFunc(b.gasConfig.BuiltInCost.DCTNFTUpdateAttributes, b.gasConfig.BaseOperationCost, b.dctStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTUpdateAttributes, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTAddUriFunc(b.gasConfig.BuiltInCost.DCTNFTAddURI, b.gasConfig.BaseOperationCost, b.dctStorageHandler, globalSettingsFunc, setRoleFunc, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTNFTAddURI, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTNFTMultiTransferFunc(b.gasConfig.BuiltInCost.DCTNFTMultiTransfer,
-		b.marshaller,
-		globalSettingsFunc,
-		b.accounts,
-		b.shardCoordinator,
-		b.gasConfig.BaseOperationCost,
-		b.enableEpochsHandler,
-		setRoleFunc,
-		b.dctStorageHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionMultiDCTNFTTransfer, newFunc)
-	if err != nil {
-		return err
+	argsNewFunc := ArgsNewBuiltInFunction{
+		GasConfig:                        *b.gasConfig,
+		Marshalizer:                      b.marshaller,
+		Accounts:                         accounts,
+		ShardCoordinator:                 b.shardCoordinator,
+		EnableEpochsHandler:              b.enableEpochsHandler,
+		GlobalSettingsHandler:            globalSettingsFunc,
+		RolesHandler:                     setRoleFunc,
+		DCTStorageHandler:                b.dctStorageHandler,
+		MapDNSAddresses:                  b.mapDNSAddresses,
+		EnableUserNameChange:             b.enableUserNameChange,
+		MaxNumOfAddressesForTransferRole: b.maxNumOfAddressesForTransferRole,
+		ConfigAddress:                    b.configAddress,
 	}
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, core.BuiltInFunctionDCTSetLimitedTransfer, b.enableEpochsHandler.IsDCTTransferRoleFlagEnabled)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTSetLimitedTransfer, newFunc)
-	if err != nil {
-		return err
-	}
-
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, core.BuiltInFunctionDCTUnSetLimitedTransfer, b.enableEpochsHandler.IsDCTTransferRoleFlagEnabled)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(core.BuiltInFunctionDCTUnSetLimitedTransfer, newFunc)
-	if err != nil {
-		return err
+	for _, descriptor := range b.registry.Descriptors() {
+		err = b.createAndAddBuiltInFunction(descriptor, argsNewFunc)
+		if err != nil {
+			return err
+		}
 	}
 
-	argsNewDeleteFunc := ArgsNewDCTDeleteMetadata{
-		FuncGasCost:         b.gasConfig.BuiltInCost.DCTNFTBurn,
-		Marshalizer:         b.marshaller,
-		Accounts:            b.accounts,
-		AllowedAddress:      b.configAddress,
-		Delete:              true,
-		EnableEpochsHandler: b.enableEpochsHandler,
-	}
-	newFunc, err = NewDCTDeleteMetadataFunc(argsNewDeleteFunc)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.DCTDeleteMetadata, newFunc)
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	argsNewDeleteFunc.Delete = false
-	newFunc, err = NewDCTDeleteMetadataFunc(argsNewDeleteFunc)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.DCTAddMetadata, newFunc)
-	if err != nil {
-		return err
+func (b *builtInFuncCreator) createAndAddBuiltInFunction(descriptor BuiltInFunctionDescriptor, args ArgsNewBuiltInFunction) error {
+	if descriptor.GasCost != nil {
+		args.FuncGasCost = descriptor.GasCost(b.gasConfig.BuiltInCost)
 	}
+	args.ActiveHandler = activeHandlerFromFlag(b.enableEpochsHandler, descriptor.ActivationFlag)
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, true, vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, b.enableEpochsHandler.IsSendAlwaysFlagEnabled)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTSetBurnRoleForAll, newFunc)
+	createdFunc, err := descriptor.Constructor(args)
 	if err != nil {
 		return err
 	}
 
-	newFunc, err = NewDCTGlobalSettingsFunc(b.accounts, b.marshaller, false, vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll, b.enableEpochsHandler.IsSendAlwaysFlagEnabled)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll, newFunc)
-	if err != nil {
-		return err
+	newFunc := createdFunc
+	if len(descriptor.ActivationFlag) > 0 {
+		newFunc, err = newFlagActivatedFunction(createdFunc, args.ActiveHandler)
+		if err != nil {
+			return err
+		}
 	}
 
-	newFunc, err = NewDCTTransferRoleAddressFunc(b.accounts, b.marshaller, b.maxNumOfAddressesForTransferRole, false, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress, newFunc)
-	if err != nil {
-		return err
+	if b.storageUpdatesRecorder != nil {
+		newFunc, err = NewStorageRecordingFunction(newFunc, b.storageUpdatesRecorder)
+		if err != nil {
+			return err
+		}
 	}
 
-	newFunc, err = NewDCTTransferRoleAddressFunc(b.accounts, b.marshaller, b.maxNumOfAddressesForTransferRole, true, b.enableEpochsHandler)
-	if err != nil {
-		return err
-	}
-	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionDCTTransferRoleAddAddress, newFunc)
-	if err != nil {
-		return err
-	}
+	return b.builtInFunctions.Add(descriptor.Name, newFunc)
+}
 
-	return nil
+// BuiltInFunctionsRegistry returns the registry the built-in functions container is created from
+func (b *builtInFuncCreator) BuiltInFunctionsRegistry() BuiltInFunctionsRegistryHandler {
+	return b.registry
 }
 
 func createGasConfig(gasMap map[string]map[string]uint64) (*vmcommon.GasCost, error) {
@@ -489,7 +280,7 @@ func (b *builtInFuncCreator) SetPayableHandler(payableHandler vmcommon.PayableHa
 			return err
 		}
 
-		dctTransferFunc, ok := builtInFunc.(vmcommon.AcceptPayableChecker)
+		dctTransferFunc, ok := UnwrapBuiltInFunction(builtInFunc).(vmcommon.AcceptPayableChecker)
 		if !ok {
 			return ErrWrongTypeAssertion
 		}
@@ -1,12 +1,16 @@
 package builtInFunctions
 
 import (
+	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
 	"github.com/kalyan3104/k-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func createMockArguments() ArgsCreateBuiltInFunctionContainer {
@@ -143,3 +147,113 @@ func TestCreateBuiltInContainter_Create(t *testing.T) {
 	nftStorageHandler := f.NFTStorageHandler()
 	assert.False(t, check.IfNil(nftStorageHandler))
 }
+
+func TestCreateBuiltInContainter_CreateWithAdditionalBuiltInFunction(t *testing.T) {
+	registry, err := NewDefaultBuiltInFunctionsRegistry()
+	require.Nil(t, err)
+
+	var providedArgs ArgsNewBuiltInFunction
+	customFunc := &mock.BuiltInFunctionStub{}
+	err = registry.Register(BuiltInFunctionDescriptor{
+		Name: "customBuiltInFunction",
+		Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+			providedArgs = args
+			return customFunc, nil
+		},
+		GasCost:        func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTNFTAddURI },
+		ActivationFlag: vmcommon.SendAlwaysFlag,
+	})
+	require.Nil(t, err)
+
+	args := createMockArguments()
+	args.GasMap[core.BuiltInCostString]["DCTNFTAddURI"] = 7
+	args.BuiltInFunctionsRegistry = registry
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err = f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+	assert.Equal(t, 32, f.BuiltInFunctionContainer().Len())
+
+	builtInFunc, err := f.BuiltInFunctionContainer().Get("customBuiltInFunction")
+	assert.Nil(t, err)
+	assert.True(t, UnwrapBuiltInFunction(builtInFunc) == customFunc)
+	assert.Equal(t, uint64(7), providedArgs.FuncGasCost)
+	assert.False(t, providedArgs.ActiveHandler())
+	assert.False(t, builtInFunc.IsActive())
+	assert.False(t, check.IfNil(providedArgs.GlobalSettingsHandler))
+	assert.False(t, check.IfNil(providedArgs.RolesHandler))
+	assert.False(t, check.IfNil(providedArgs.DCTStorageHandler))
+
+	expectedErr := errors.New("expected error")
+	_ = registry.Register(BuiltInFunctionDescriptor{
+		Name: "failingBuiltInFunction",
+		Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+			return nil, expectedErr
+		},
+	})
+	err = f.CreateBuiltInFunctionContainer()
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestCreateBuiltInContainter_OnlyFlagActivatedFunctionsShouldBeWrapped(t *testing.T) {
+	f, _ := NewBuiltInFunctionsCreator(createMockArguments())
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	for _, descriptor := range f.BuiltInFunctionsRegistry().Descriptors() {
+		builtInFunc, errGet := f.BuiltInFunctionContainer().Get(descriptor.Name)
+		require.Nil(t, errGet)
+
+		_, isWrapped := builtInFunc.(*flagActivatedFunction)
+		assert.Equal(t, len(descriptor.ActivationFlag) > 0, isWrapped, descriptor.Name)
+	}
+
+	builtInFunc, _ := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+	_, ok := builtInFunc.(vmcommon.AcceptPayableChecker)
+	assert.True(t, ok)
+}
+
+func TestCreateBuiltInContainter_CreateWithStorageUpdatesRecording(t *testing.T) {
+	systemAccount := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	args := createMockArguments()
+	args.RecordStorageUpdates = true
+	args.Accounts = &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAccount, nil
+		},
+		SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+			assert.True(t, account == systemAccount)
+			return nil
+		},
+	}
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+	assert.Equal(t, 31, f.BuiltInFunctionContainer().Len())
+
+	err = f.SetPayableHandler(&mock.PayableHandlerStub{})
+	assert.Nil(t, err)
+
+	pauseFunc, err := f.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTPause)
+	require.Nil(t, err)
+	_, isRecording := pauseFunc.(*storageRecordingFunction)
+	assert.True(t, isRecording)
+
+	vmOutput, err := pauseFunc.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.DCTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{[]byte("TOKEN-abcdef")},
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+	})
+	require.Nil(t, err)
+
+	tokenKey := string(append([]byte(baseDCTKeyPrefix), []byte("TOKEN-abcdef")...))
+	outputAccount := vmOutput.OutputAccounts[string(vmcommon.SystemAccountAddress)]
+	require.NotNil(t, outputAccount)
+	require.Contains(t, outputAccount.StorageUpdates, tokenKey)
+	assert.True(t, DCTGlobalMetadataFromBytes(outputAccount.StorageUpdates[tokenKey].Data).Paused)
+	assert.Equal(t, uint64(len(outputAccount.StorageUpdates[tokenKey].Data)), outputAccount.BytesAddedToStorage)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type dctBurn struct {
@@ -43,7 +44,7 @@ func NewDCTBurnFunc(
 		globalSettingsHandler: globalSettingsHandler,
 	}
 
-	e.baseActiveHandler.activeHandler = enableEpochsHandler.IsGlobalMintBurnFlagEnabled
+	e.baseActiveHandler.activeHandler = flagActiveHandler(enableEpochsHandler, vmcommon.GlobalMintBurnFlag)
 
 	return e, nil
 }
@@ -87,6 +88,7 @@ func (e *dctBurn) ProcessBuiltinFunction(
 
 	dctTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
 
+	vmInput.GasTrace.Charge(gasTraceFunctionCost, e.funcGasCost)
 	if vmInput.GasProvided < e.funcGasCost {
 		return nil, ErrNotEnoughGas
 	}
@@ -109,11 +111,45 @@ func (e *dctBurn) ProcessBuiltinFunction(
 			vmOutput)
 	}
 
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTBurn), vmInput.Arguments[0], 0, value, vmInput.CallerAddr)
+	events.AddToVMOutput(vmOutput, &events.DCTSupplyChange{
+		Function: core.BuiltInFunctionDCTBurn,
+		Account:  vmInput.CallerAddr,
+		TokenID:  vmInput.Arguments[0],
+		Value:    value,
+	})
 
 	return vmOutput, nil
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (e *dctBurn) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost, nil
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctBurn) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) != 2 {
+		return nil, ErrInvalidArguments
+	}
+
+	tokenID := vmInput.Arguments[0]
+	b := newReadWriteSetBuilder()
+	b.readWrite(vmInput.CallerAddr, dctTokenKeyFor(tokenID))
+	b.read(vmcommon.SystemAccountAddress, dctTokenKeyFor(tokenID))
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctBurn) IsInterfaceNil() bool {
 	return e == nil
@@ -140,7 +140,7 @@ func (e *dctDataStorage) getDCTNFTTokenOnDestinationWithAccountsAdapterOptions(
 		return nil, false, err
 	}
 
-	if !e.enableEpochsHandler.IsSaveToSystemAccountFlagEnabled() || nonce == 0 {
+	if !e.enableEpochsHandler.IsFlagEnabled(vmcommon.SaveToSystemAccountFlag) || nonce == 0 {
 		return dctData, false, nil
 	}
 
@@ -200,7 +200,7 @@ func (e *dctDataStorage) checkCollectionIsFrozenForAccount(
 	nonce uint64,
 	isReturnWithError bool,
 ) error {
-	if !e.enableEpochsHandler.IsCheckFrozenCollectionFlagEnabled() {
+	if !e.enableEpochsHandler.IsFlagEnabled(vmcommon.CheckFrozenCollectionFlag) {
 		return nil
 	}
 	if nonce == 0 || isReturnWithError {
@@ -261,8 +261,8 @@ func (e *dctDataStorage) AddToLiquiditySystemAcc(
 	nonce uint64,
 	transferValue *big.Int,
 ) error {
-	isSaveToSystemAccountFlagEnabled := e.enableEpochsHandler.IsSaveToSystemAccountFlagEnabled()
-	isSendAlwaysFlagEnabled := e.enableEpochsHandler.IsSendAlwaysFlagEnabled()
+	isSaveToSystemAccountFlagEnabled := e.enableEpochsHandler.IsFlagEnabled(vmcommon.SaveToSystemAccountFlag)
+	isSendAlwaysFlagEnabled := e.enableEpochsHandler.IsFlagEnabled(vmcommon.SendAlwaysFlag)
 	if !isSaveToSystemAccountFlagEnabled || !isSendAlwaysFlagEnabled || nonce == 0 {
 		return nil
 	}
@@ -282,7 +282,7 @@ func (e *dctDataStorage) AddToLiquiditySystemAcc(
 		return nil
 	}
 
-	if e.enableEpochsHandler.IsFixOldTokenLiquidityEnabled() {
+	if e.enableEpochsHandler.IsFlagEnabled(vmcommon.FixOldTokenLiquidityFlag) {
 		// old tokens which were transferred intra shard before the activation of this flag
 		if dctData.Value.Cmp(zero) == 0 && transferValue.Cmp(zero) < 0 {
 			dctData.Reserved = nil
@@ -329,7 +329,7 @@ func (e *dctDataStorage) SaveDCTNFTToken(
 
 	dctNFTTokenKey := computeDCTNFTTokenKey(dctTokenKey, nonce)
 	senderShardID := e.shardCoordinator.ComputeId(senderAddress)
-	if e.enableEpochsHandler.IsSaveToSystemAccountFlagEnabled() {
+	if e.enableEpochsHandler.IsFlagEnabled(vmcommon.SaveToSystemAccountFlag) {
 		err = e.saveDCTMetaDataToSystemAccount(acnt, senderShardID, dctNFTTokenKey, nonce, dctData, mustUpdateAllFields)
 		if err != nil {
 			return nil, err
@@ -340,7 +340,7 @@ func (e *dctDataStorage) SaveDCTNFTToken(
 		return nil, acnt.AccountDataHandler().SaveKeyValue(dctNFTTokenKey, nil)
 	}
 
-	if !e.enableEpochsHandler.IsSaveToSystemAccountFlagEnabled() {
+	if !e.enableEpochsHandler.IsFlagEnabled(vmcommon.SaveToSystemAccountFlag) {
 		marshaledData, errMarshal := e.marshaller.Marshal(dctData)
 		if errMarshal != nil {
 			return nil, errMarshal
@@ -398,7 +398,7 @@ func (e *dctDataStorage) saveDCTMetaDataToSystemAccount(
 		TokenMetaData: dctData.TokenMetaData,
 		Properties:    make([]byte, e.shardCoordinator.NumberOfShards()),
 	}
-	isSendAlwaysFlagEnabled := e.enableEpochsHandler.IsSendAlwaysFlagEnabled()
+	isSendAlwaysFlagEnabled := e.enableEpochsHandler.IsFlagEnabled(vmcommon.SendAlwaysFlag)
 	if len(currentSaveData) == 0 && isSendAlwaysFlagEnabled {
 		dctDataOnSystemAcc.Properties = nil
 		dctDataOnSystemAcc.Reserved = []byte{1}
@@ -428,10 +428,10 @@ func (e *dctDataStorage) saveMetadataIfRequired(
 	currentSaveData []byte,
 	dctData *dct.DCToken,
 ) error {
-	if !e.enableEpochsHandler.IsAlwaysSaveTokenMetaDataEnabled() {
+	if !e.enableEpochsHandler.IsFlagEnabled(vmcommon.AlwaysSaveTokenMetaDataFlag) {
 		return nil
 	}
-	if !e.enableEpochsHandler.IsSendAlwaysFlagEnabled() {
+	if !e.enableEpochsHandler.IsFlagEnabled(vmcommon.SendAlwaysFlag) {
 		// do not re-write the metadata if it is not sent, as it will cause data loss
 		return nil
 	}
@@ -458,7 +458,7 @@ func (e *dctDataStorage) setReservedToNilForOldToken(
 	userAcc vmcommon.UserAccountHandler,
 	dctNFTTokenKey []byte,
 ) error {
-	if !e.enableEpochsHandler.IsFixOldTokenLiquidityEnabled() {
+	if !e.enableEpochsHandler.IsFlagEnabled(vmcommon.FixOldTokenLiquidityFlag) {
 		return nil
 	}
 
@@ -535,7 +535,7 @@ func (e *dctDataStorage) WasAlreadySentToDestinationShardAndUpdateState(
 	nonce uint64,
 	dstAddress []byte,
 ) (bool, error) {
-	if !e.enableEpochsHandler.IsSaveToSystemAccountFlagEnabled() {
+	if !e.enableEpochsHandler.IsFlagEnabled(vmcommon.SaveToSystemAccountFlag) {
 		return false, nil
 	}
 
@@ -547,7 +547,7 @@ func (e *dctDataStorage) WasAlreadySentToDestinationShardAndUpdateState(
 		return true, nil
 	}
 
-	if e.enableEpochsHandler.IsSendAlwaysFlagEnabled() {
+	if e.enableEpochsHandler.IsFlagEnabled(vmcommon.SendAlwaysFlag) {
 		return false, nil
 	}
 
@@ -585,10 +585,10 @@ func (e *dctDataStorage) WasAlreadySentToDestinationShardAndUpdateState(
 func (e *dctDataStorage) SaveNFTMetaDataToSystemAccount(
 	tx data.TransactionHandler,
 ) error {
-	if !e.enableEpochsHandler.IsSaveToSystemAccountFlagEnabled() {
+	if !e.enableEpochsHandler.IsFlagEnabled(vmcommon.SaveToSystemAccountFlag) {
 		return nil
 	}
-	if e.enableEpochsHandler.IsSendAlwaysFlagEnabled() {
+	if e.enableEpochsHandler.IsFlagEnabled(vmcommon.SendAlwaysFlag) {
 		return nil
 	}
 	if check.IfNil(tx) {
@@ -8,19 +8,21 @@ import (
 	"github.com/kalyan3104/k-core/core/check"
 	"github.com/kalyan3104/k-core/data/dct"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 const numArgsPerAdd = 3
 
 type dctDeleteMetaData struct {
 	baseActiveHandler
-	allowedAddress []byte
-	delete         bool
-	accounts       vmcommon.AccountsAdapter
-	keyPrefix      []byte
-	marshaller     vmcommon.Marshalizer
-	funcGasCost    uint64
-	function       string
+	allowedAddress      []byte
+	delete              bool
+	accounts            vmcommon.AccountsAdapter
+	keyPrefix           []byte
+	marshaller          vmcommon.Marshalizer
+	funcGasCost         uint64
+	function            string
+	enableEpochsHandler vmcommon.EnableEpochsHandler
 }
 
 // ArgsNewDCTDeleteMetadata defines the argument list for new dct delete metadata built in function
@@ -48,16 +50,17 @@ func NewDCTDeleteMetadataFunc(
 	}
 
 	e := &dctDeleteMetaData{
-		keyPrefix:      []byte(baseDCTKeyPrefix),
-		marshaller:     args.Marshalizer,
-		funcGasCost:    args.FuncGasCost,
-		accounts:       args.Accounts,
-		allowedAddress: args.AllowedAddress,
-		delete:         args.Delete,
-		function:       core.BuiltInFunctionMultiDCTNFTTransfer,
+		keyPrefix:           []byte(baseDCTKeyPrefix),
+		marshaller:          args.Marshalizer,
+		funcGasCost:         args.FuncGasCost,
+		accounts:            args.Accounts,
+		allowedAddress:      args.AllowedAddress,
+		delete:              args.Delete,
+		function:            core.BuiltInFunctionMultiDCTNFTTransfer,
+		enableEpochsHandler: args.EnableEpochsHandler,
 	}
 
-	e.baseActiveHandler.activeHandler = args.EnableEpochsHandler.IsSendAlwaysFlagEnabled
+	e.baseActiveHandler.activeHandler = flagActiveHandler(args.EnableEpochsHandler, vmcommon.SendAlwaysFlag)
 
 	return e, nil
 }
@@ -97,10 +100,50 @@ func (e *dctDeleteMetaData) ProcessBuiltinFunction(
 	}
 
 	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
+	if isBuiltInFunctionsLogsFlagEnabled(e.enableEpochsHandler) {
+		e.addLogs(vmOutput, vmInput)
+	}
 
 	return vmOutput, nil
 }
 
+// addLogs adds one log entry per token, as the arguments were already validated by the processing
+func (e *dctDeleteMetaData) addLogs(vmOutput *vmcommon.VMOutput, vmInput *vmcommon.ContractCallInput) {
+	args := vmInput.Arguments
+	if !e.delete {
+		for i := 0; i+numArgsPerAdd <= len(args); i += numArgsPerAdd {
+			events.AddToVMOutput(vmOutput, &events.DCTAddMetadata{
+				Caller:   vmInput.CallerAddr,
+				TokenID:  args[i],
+				Nonce:    big.NewInt(0).SetBytes(args[i+1]).Uint64(),
+				Metadata: args[i+2],
+			})
+		}
+		return
+	}
+
+	for i := 0; i+1 < len(args); {
+		tokenID := args[i]
+		numIntervals := int(big.NewInt(0).SetBytes(args[i+1]).Uint64())
+		i += 2
+
+		intervals := make([]events.NonceInterval, 0)
+		for j := 0; j < numIntervals && i+1 < len(args); j++ {
+			intervals = append(intervals, events.NonceInterval{
+				Start: big.NewInt(0).SetBytes(args[i]).Uint64(),
+				End:   big.NewInt(0).SetBytes(args[i+1]).Uint64(),
+			})
+			i += 2
+		}
+
+		events.AddToVMOutput(vmOutput, &events.DCTDeleteMetadata{
+			Caller:    vmInput.CallerAddr,
+			TokenID:   tokenID,
+			Intervals: intervals,
+		})
+	}
+}
+
 // input is list(tokenID-numIntervals-list(start,end))
 func (e *dctDeleteMetaData) deleteMetadata(args [][]byte) error {
 	lenArgs := uint64(len(args))
@@ -305,6 +348,46 @@ func (e *dctDeleteMetaData) marshalAndSaveData(
 	return nil
 }
 
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctDeleteMetaData) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+
+	b := newReadWriteSetBuilder()
+	args := vmInput.Arguments
+	lenArgs := uint64(len(args))
+	if !e.delete {
+		if lenArgs == 0 || lenArgs%numArgsPerAdd != 0 {
+			return nil, ErrInvalidNumOfArgs
+		}
+
+		for i := uint64(0); i < lenArgs; i += numArgsPerAdd {
+			nonce := big.NewInt(0).SetBytes(args[i+1]).Uint64()
+			b.readWrite(vmcommon.SystemAccountAddress, computeDCTNFTTokenKey(concatKey(e.keyPrefix, args[i]), nonce))
+		}
+
+		return b.build(), nil
+	}
+
+	if lenArgs < 4 {
+		return nil, ErrInvalidNumOfArgs
+	}
+	for i := uint64(0); i+1 < lenArgs; {
+		tokenID := args[i]
+		numIntervals := big.NewInt(0).SetBytes(args[i+1]).Uint64()
+		i += 2
+		if i >= lenArgs || numIntervals > (lenArgs-i)/2 {
+			return nil, ErrInvalidNumOfArgs
+		}
+
+		b.readWritePrefix(vmcommon.SystemAccountAddress, concatKey(e.keyPrefix, tokenID))
+		i += numIntervals * 2
+	}
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object is nil
 func (e *dctDeleteMetaData) IsInterfaceNil() bool {
 	return e == nil
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kalyan3104/k-core/data/dct"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 	"github.com/kalyan3104/k-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 )
@@ -257,3 +258,81 @@ func TestDctDeleteMetaData_ProcessBuiltinFunctionDelete(t *testing.T) {
 	vmOutput, err = e.ProcessBuiltinFunction(nil, nil, vmInput)
 	assert.NotNil(t, vmOutput)
 }
+
+func TestDctDeleteMetaData_ProcessBuiltinFunctionLogs(t *testing.T) {
+	t.Parallel()
+
+	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	args := createMockArgsForNewDCTDelete()
+	args.Accounts = &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return acnt, nil
+		}}
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsSendAlwaysFlagEnabledField:           true,
+		IsBuiltInFunctionsLogsFlagEnabledField: true,
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := NewDCTDeleteMetadataFunc(args)
+		vmInput := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(0)}}
+		vmInput.CallerAddr = e.allowedAddress
+		vmInput.RecipientAddr = e.allowedAddress
+		vmInput.Arguments = [][]byte{[]byte("TOKEN-ababab"), {2}, {1}, {2}, {4}, {10}, []byte("TOKEN-cdcdcd"), {1}, {3}, {3}}
+
+		vmOutput, err := e.ProcessBuiltinFunction(nil, nil, vmInput)
+		assert.Nil(t, err)
+		expectedLogs := []*vmcommon.LogEntry{
+			(&events.DCTDeleteMetadata{
+				Caller:    e.allowedAddress,
+				TokenID:   []byte("TOKEN-ababab"),
+				Intervals: []events.NonceInterval{{Start: 1, End: 2}, {Start: 4, End: 10}},
+			}).LogEntry(),
+			(&events.DCTDeleteMetadata{
+				Caller:    e.allowedAddress,
+				TokenID:   []byte("TOKEN-cdcdcd"),
+				Intervals: []events.NonceInterval{{Start: 3, End: 3}},
+			}).LogEntry(),
+		}
+		assert.Equal(t, expectedLogs, vmOutput.Logs)
+	})
+	t.Run("add", func(t *testing.T) {
+		t.Parallel()
+
+		argsAdd := args
+		argsAdd.Delete = false
+		e, _ := NewDCTDeleteMetadataFunc(argsAdd)
+		marshalledData, _ := e.marshaller.Marshal(&dct.MetaData{Name: []byte("something"), Nonce: 7})
+		vmInput := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(0)}}
+		vmInput.CallerAddr = e.allowedAddress
+		vmInput.RecipientAddr = e.allowedAddress
+		vmInput.Arguments = [][]byte{[]byte("TOKEN-efefef"), {7}, marshalledData}
+
+		vmOutput, err := e.ProcessBuiltinFunction(nil, nil, vmInput)
+		assert.Nil(t, err)
+		expectedLog := (&events.DCTAddMetadata{
+			Caller:   e.allowedAddress,
+			TokenID:  []byte("TOKEN-efefef"),
+			Nonce:    7,
+			Metadata: marshalledData,
+		}).LogEntry()
+		assert.Equal(t, []*vmcommon.LogEntry{expectedLog}, vmOutput.Logs)
+	})
+	t.Run("flag not enabled should not add logs", func(t *testing.T) {
+		t.Parallel()
+
+		argsNoLogs := args
+		argsNoLogs.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{IsSendAlwaysFlagEnabledField: true}
+		e, _ := NewDCTDeleteMetadataFunc(argsNoLogs)
+		vmInput := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{CallValue: big.NewInt(0)}}
+		vmInput.CallerAddr = e.allowedAddress
+		vmInput.RecipientAddr = e.allowedAddress
+		vmInput.Arguments = [][]byte{[]byte("TOKEN-ababab"), {1}, {1}, {2}}
+
+		vmOutput, err := e.ProcessBuiltinFunction(nil, nil, vmInput)
+		assert.Nil(t, err)
+		assert.Empty(t, vmOutput.Logs)
+	})
+}
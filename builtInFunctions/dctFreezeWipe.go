@@ -7,6 +7,7 @@ import (
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type dctFreezeWipe struct {
@@ -94,7 +95,14 @@ func (e *dctFreezeWipe) ProcessBuiltinFunction(
 	}
 
 	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
-	addDCTEntryInVMOutput(vmOutput, []byte(vmInput.Function), identifier, nonce, amount, vmInput.CallerAddr, acntDst.AddressBytes())
+	events.AddToVMOutput(vmOutput, &events.DCTFreezeWipe{
+		Function: vmInput.Function,
+		Caller:   vmInput.CallerAddr,
+		Account:  acntDst.AddressBytes(),
+		TokenID:  identifier,
+		Nonce:    nonce,
+		Value:    amount,
+	})
 
 	return vmOutput, nil
 }
@@ -125,7 +133,7 @@ func (e *dctFreezeWipe) wipeIfApplicable(acntDst vmcommon.UserAccountHandler, to
 }
 
 func (e *dctFreezeWipe) removeLiquidity(tokenIdentifier []byte, nonce uint64, value *big.Int) error {
-	if !e.enableEpochsHandler.IsWipeSingleNFTLiquidityDecreaseEnabled() {
+	if !e.enableEpochsHandler.IsFlagEnabled(vmcommon.WipeSingleNFTLiquidityDecreaseFlag) {
 		return nil
 	}
 
@@ -152,6 +160,25 @@ func (e *dctFreezeWipe) toggleFreeze(acntDst vmcommon.UserAccountHandler, tokenK
 	return frozenAmount, nil
 }
 
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctFreezeWipe) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) != 1 {
+		return nil, ErrInvalidArguments
+	}
+
+	b := newReadWriteSetBuilder()
+	b.readWrite(vmInput.RecipientAddr, concatKey(e.keyPrefix, vmInput.Arguments[0]))
+	if e.wipe {
+		identifier, nonce := extractTokenIdentifierAndNonceDCTWipe(vmInput.Arguments[0])
+		b.readWrite(vmcommon.SystemAccountAddress, dctNFTTokenKeyFor(identifier, nonce))
+	}
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctFreezeWipe) IsInterfaceNil() bool {
 	return e == nil
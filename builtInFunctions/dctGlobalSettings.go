@@ -7,18 +7,30 @@ import (
 	"github.com/kalyan3104/k-core/core/check"
 	"github.com/kalyan3104/k-core/marshal"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type dctGlobalSettings struct {
 	baseActiveHandler
-	keyPrefix  []byte
-	set        bool
-	accounts   vmcommon.AccountsAdapter
-	marshaller marshal.Marshalizer
-	function   string
+	keyPrefix           []byte
+	set                 bool
+	accounts            vmcommon.AccountsAdapter
+	marshaller          marshal.Marshalizer
+	function            string
+	enableEpochsHandler vmcommon.EnableEpochsHandler
 }
 
-// NewDCTGlobalSettingsFunc returns the dct pause/un-pause built-in function component
+// ArgsNewDCTGlobalSettings defines the argument list for new dct global settings built in function
+type ArgsNewDCTGlobalSettings struct {
+	Accounts            vmcommon.AccountsAdapter
+	Marshaller          marshal.Marshalizer
+	Set                 bool
+	Function            string
+	ActiveHandler       func() bool
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewDCTGlobalSettingsFunc returns the dct pause/un-pause built-in function component that does not emit logs
 func NewDCTGlobalSettingsFunc(
 	accounts vmcommon.AccountsAdapter,
 	marshaller marshal.Marshalizer,
@@ -52,6 +64,22 @@ func NewDCTGlobalSettingsFunc(
 	return e, nil
 }
 
+// NewDCTGlobalSettings returns the dct pause/un-pause built-in function component that emits logs once the
+// built-in functions logs flag is enabled
+func NewDCTGlobalSettings(args ArgsNewDCTGlobalSettings) (*dctGlobalSettings, error) {
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	e, err := NewDCTGlobalSettingsFunc(args.Accounts, args.Marshaller, args.Set, args.Function, args.ActiveHandler)
+	if err != nil {
+		return nil, err
+	}
+	e.enableEpochsHandler = args.EnableEpochsHandler
+
+	return e, nil
+}
+
 func isCorrectFunction(function string) bool {
 	switch function {
 	case core.BuiltInFunctionDCTPause, core.BuiltInFunctionDCTUnPause, core.BuiltInFunctionDCTSetLimitedTransfer, core.BuiltInFunctionDCTUnSetLimitedTransfer:
@@ -96,6 +124,14 @@ func (e *dctGlobalSettings) ProcessBuiltinFunction(
 	}
 
 	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
+	if isBuiltInFunctionsLogsFlagEnabled(e.enableEpochsHandler) {
+		events.AddToVMOutput(vmOutput, &events.DCTGlobalSettings{
+			Function: e.function,
+			Caller:   vmInput.CallerAddr,
+			TokenID:  vmInput.Arguments[0],
+		})
+	}
+
 	return vmOutput, nil
 }
 
@@ -211,6 +247,21 @@ func (e *dctGlobalSettings) getGlobalMetadata(dctTokenKey []byte) (*DCTGlobalMet
 	return &dctMetaData, nil
 }
 
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctGlobalSettings) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) != 1 {
+		return nil, ErrInvalidArguments
+	}
+
+	b := newReadWriteSetBuilder()
+	b.readWrite(vmcommon.SystemAccountAddress, dctTokenKeyFor(vmInput.Arguments[0]))
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctGlobalSettings) IsInterfaceNil() bool {
 	return e == nil
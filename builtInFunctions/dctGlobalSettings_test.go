@@ -8,6 +8,7 @@ import (
 	"github.com/kalyan3104/k-core/core/check"
 	"github.com/kalyan3104/k-vm-common-go/mock"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,6 +45,47 @@ func TestNewDCTGlobalSettingsFunc(t *testing.T) {
 	})
 }
 
+func TestNewDCTGlobalSettings(t *testing.T) {
+	t.Parallel()
+
+	createArgs := func() ArgsNewDCTGlobalSettings {
+		return ArgsNewDCTGlobalSettings{
+			Accounts:            &mock.AccountsStub{},
+			Marshaller:          &mock.MarshalizerMock{},
+			Set:                 true,
+			Function:            core.BuiltInFunctionDCTPause,
+			ActiveHandler:       trueHandler,
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		}
+	}
+
+	t.Run("nil enable epochs handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgs()
+		args.EnableEpochsHandler = nil
+		globalSettingsFunc, err := NewDCTGlobalSettings(args)
+		assert.Equal(t, ErrNilEnableEpochsHandler, err)
+		assert.True(t, check.IfNil(globalSettingsFunc))
+	})
+	t.Run("nil accounts should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgs()
+		args.Accounts = nil
+		globalSettingsFunc, err := NewDCTGlobalSettings(args)
+		assert.Equal(t, ErrNilAccountsAdapter, err)
+		assert.True(t, check.IfNil(globalSettingsFunc))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		globalSettingsFunc, err := NewDCTGlobalSettings(createArgs())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(globalSettingsFunc))
+	})
+}
+
 func TestDCTGlobalSettingsPause_ProcessBuiltInFunction(t *testing.T) {
 	t.Parallel()
 
@@ -260,3 +302,45 @@ func TestDCTGlobalSettingsBurnForAll_ProcessBuiltInFunction(t *testing.T) {
 
 	assert.False(t, globalSettingsFunc.IsLimitedTransfer(tokenID))
 }
+
+func TestDCTGlobalSettings_ProcessBuiltinFunctionLogs(t *testing.T) {
+	t.Parallel()
+
+	acnt := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return acnt, nil
+		},
+	}
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.DCTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{[]byte("TOKEN-ababab")},
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+	}
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{IsBuiltInFunctionsLogsFlagEnabledField: true}
+	globalSettingsFunc, _ := NewDCTGlobalSettings(ArgsNewDCTGlobalSettings{
+		Accounts:            accounts,
+		Marshaller:          &mock.MarshalizerMock{},
+		Set:                 true,
+		Function:            core.BuiltInFunctionDCTSetLimitedTransfer,
+		ActiveHandler:       trueHandler,
+		EnableEpochsHandler: enableEpochsHandler,
+	})
+	vmOutput, err := globalSettingsFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Nil(t, err)
+	expectedLog := (&events.DCTGlobalSettings{
+		Function: core.BuiltInFunctionDCTSetLimitedTransfer,
+		Caller:   core.DCTSCAddress,
+		TokenID:  []byte("TOKEN-ababab"),
+	}).LogEntry()
+	assert.Equal(t, []*vmcommon.LogEntry{expectedLog}, vmOutput.Logs)
+
+	enableEpochsHandler.IsBuiltInFunctionsLogsFlagEnabledField = false
+	vmOutput, err = globalSettingsFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Nil(t, err)
+	assert.Empty(t, vmOutput.Logs)
+}
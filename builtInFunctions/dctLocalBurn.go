@@ -8,6 +8,7 @@ import (
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type dctLocalBurn struct {
@@ -88,7 +89,12 @@ func (e *dctLocalBurn) ProcessBuiltinFunction(
 
 	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
 
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTLocalBurn), vmInput.Arguments[0], 0, value, vmInput.CallerAddr)
+	events.AddToVMOutput(vmOutput, &events.DCTSupplyChange{
+		Function: core.BuiltInFunctionDCTLocalBurn,
+		Account:  vmInput.CallerAddr,
+		TokenID:  vmInput.Arguments[0],
+		Value:    value,
+	})
 
 	return vmOutput, nil
 }
@@ -103,6 +109,36 @@ func (e *dctLocalBurn) isAllowedToBurn(acntSnd vmcommon.UserAccountHandler, toke
 	return e.rolesHandler.CheckAllowedToExecute(acntSnd, tokenID, []byte(core.DCTRoleLocalBurn))
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (e *dctLocalBurn) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost, nil
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctLocalBurn) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 2 {
+		return nil, ErrInvalidArguments
+	}
+
+	tokenID := vmInput.Arguments[0]
+	b := newReadWriteSetBuilder()
+	b.read(vmInput.CallerAddr, roleKeyFor(tokenID))
+	b.readWrite(vmInput.CallerAddr, dctTokenKeyFor(tokenID))
+	b.read(vmcommon.SystemAccountAddress, dctTokenKeyFor(tokenID))
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctLocalBurn) IsInterfaceNil() bool {
 	return e == nil
@@ -143,6 +179,7 @@ func checkInputArgumentsForLocalAction(
 	if value.Cmp(zero) <= 0 {
 		return ErrNegativeValue
 	}
+	vmInput.GasTrace.Charge(gasTraceFunctionCost, funcGasCost)
 	if vmInput.GasProvided < funcGasCost {
 		return ErrNotEnoughGas
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 const maxLenForAddNFTQuantity = 32
@@ -103,7 +104,7 @@ func (e *dctNFTAddQuantity) ProcessBuiltinFunction(
 		return nil, ErrNFTDoesNotHaveMetadata
 	}
 
-	isValueLengthCheckFlagEnabled := e.enableEpochsHandler.IsValueLengthCheckFlagEnabled()
+	isValueLengthCheckFlagEnabled := e.enableEpochsHandler.IsFlagEnabled(vmcommon.ValueLengthCheckFlag)
 	if isValueLengthCheckFlagEnabled && len(vmInput.Arguments[2]) > maxLenForAddNFTQuantity {
 		return nil, fmt.Errorf("%w max length for add nft quantity is %d", ErrInvalidArguments, maxLenForAddNFTQuantity)
 	}
@@ -125,11 +126,47 @@ func (e *dctNFTAddQuantity) ProcessBuiltinFunction(
 		GasRemaining: vmInput.GasProvided - e.funcGasCost,
 	}
 
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTAddQuantity), vmInput.Arguments[0], nonce, value, vmInput.CallerAddr)
+	events.AddToVMOutput(vmOutput, &events.DCTSupplyChange{
+		Function: core.BuiltInFunctionDCTNFTAddQuantity,
+		Account:  vmInput.CallerAddr,
+		TokenID:  vmInput.Arguments[0],
+		Nonce:    nonce,
+		Value:    value,
+	})
 
 	return vmOutput, nil
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (e *dctNFTAddQuantity) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost, nil
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctNFTAddQuantity) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 3 {
+		return nil, ErrInvalidArguments
+	}
+
+	tokenID := vmInput.Arguments[0]
+	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	b := newReadWriteSetBuilder()
+	b.read(vmInput.CallerAddr, roleKeyFor(tokenID))
+	addNFTSaveAccesses(b, vmInput.CallerAddr, tokenID, nonce)
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTAddQuantity) IsInterfaceNil() bool {
 	return e == nil
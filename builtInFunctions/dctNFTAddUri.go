@@ -7,6 +7,7 @@ import (
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type dctNFTAddUri struct {
@@ -52,7 +53,7 @@ func NewDCTNFTAddUriFunc(
 		rolesHandler:          rolesHandler,
 	}
 
-	e.baseActiveHandler.activeHandler = enableEpochsHandler.IsDCTNFTImprovementV1FlagEnabled
+	e.baseActiveHandler.activeHandler = flagActiveHandler(enableEpochsHandler, vmcommon.DCTNFTImprovementV1Flag)
 
 	return e, nil
 }
@@ -95,6 +96,7 @@ func (e *dctNFTAddUri) ProcessBuiltinFunction(
 	}
 
 	gasCostForStore := e.getGasCostForURIStore(vmInput)
+	vmInput.GasTrace.Charge(gasTraceStorePerByte, gasCostForStore)
 	if vmInput.GasProvided < e.funcGasCost+gasCostForStore {
 		return nil, ErrNotEnoughGas
 	}
@@ -121,8 +123,12 @@ func (e *dctNFTAddUri) ProcessBuiltinFunction(
 		GasRemaining: vmInput.GasProvided - e.funcGasCost - gasCostForStore,
 	}
 
-	extraTopics := append([][]byte{vmInput.CallerAddr}, vmInput.Arguments[2:]...)
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTAddURI), vmInput.Arguments[0], nonce, big.NewInt(0), extraTopics...)
+	events.AddToVMOutput(vmOutput, &events.DCTNFTAddURI{
+		Account: vmInput.CallerAddr,
+		TokenID: vmInput.Arguments[0],
+		Nonce:   nonce,
+		URIs:    vmInput.Arguments[2:],
+	})
 
 	return vmOutput, nil
 }
@@ -135,6 +141,39 @@ func (e *dctNFTAddUri) getGasCostForURIStore(vmInput *vmcommon.ContractCallInput
 	return uint64(lenURIs) * e.gasConfig.StorePerByte
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (e *dctNFTAddUri) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 3 {
+		return 0, ErrInvalidArguments
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost + e.getGasCostForURIStore(vmInput), nil
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctNFTAddUri) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 3 {
+		return nil, ErrInvalidArguments
+	}
+
+	tokenID := vmInput.Arguments[0]
+	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	b := newReadWriteSetBuilder()
+	b.read(vmInput.CallerAddr, roleKeyFor(tokenID))
+	addNFTSaveAccesses(b, vmInput.CallerAddr, tokenID, nonce)
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTAddUri) IsInterfaceNil() bool {
 	return e == nil
@@ -7,6 +7,7 @@ import (
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type dctNFTBurn struct {
@@ -116,7 +117,13 @@ func (e *dctNFTBurn) ProcessBuiltinFunction(
 		GasRemaining: vmInput.GasProvided - e.funcGasCost,
 	}
 
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTBurn), vmInput.Arguments[0], nonce, quantityToBurn, vmInput.CallerAddr)
+	events.AddToVMOutput(vmOutput, &events.DCTSupplyChange{
+		Function: core.BuiltInFunctionDCTNFTBurn,
+		Account:  vmInput.CallerAddr,
+		TokenID:  vmInput.Arguments[0],
+		Nonce:    nonce,
+		Value:    quantityToBurn,
+	})
 
 	return vmOutput, nil
 }
@@ -131,6 +138,36 @@ func (e *dctNFTBurn) isAllowedToBurn(acntSnd vmcommon.UserAccountHandler, tokenI
 	return e.rolesHandler.CheckAllowedToExecute(acntSnd, tokenID, []byte(core.DCTRoleNFTBurn))
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (e *dctNFTBurn) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost, nil
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctNFTBurn) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 3 {
+		return nil, ErrInvalidArguments
+	}
+
+	tokenID := vmInput.Arguments[0]
+	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	b := newReadWriteSetBuilder()
+	b.read(vmInput.CallerAddr, roleKeyFor(tokenID))
+	addNFTSaveAccesses(b, vmInput.CallerAddr, tokenID, nonce)
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTBurn) IsInterfaceNil() bool {
 	return e == nil
@@ -12,8 +12,11 @@ import (
 	"github.com/kalyan3104/k-core/data/dct"
 	"github.com/kalyan3104/k-core/data/vm"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
+const minNumOfArgsForNFTCreate = 7
+
 var (
 	log         = logger.GetOrCreate("builtInFunctions")
 	noncePrefix = []byte(core.ProtectedKeyPrefix + core.DCTNFTLatestNonceIdentifier)
@@ -112,9 +115,9 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	minNumOfArgs := 7
+	minNumOfArgs := minNumOfArgsForNFTCreate
 	if vmInput.CallType == vm.ExecOnDestByCaller {
-		minNumOfArgs = 8
+		minNumOfArgs = minNumOfArgsForNFTCreate + 1
 	}
 	lenArgs := len(vmInput.Arguments)
 	if lenArgs < minNumOfArgs {
@@ -151,11 +154,8 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	totalLength := uint64(0)
-	for _, arg := range vmInput.Arguments {
-		totalLength += uint64(len(arg))
-	}
-	gasToUse := totalLength*e.gasConfig.StorePerByte + e.funcGasCost
+	gasToUse := e.computeGasToUse(vmInput)
+	vmInput.GasTrace.Charge(gasTraceStorePerByte, gasToUse-e.funcGasCost)
 	if vmInput.GasProvided < gasToUse {
 		return nil, ErrNotEnoughGas
 	}
@@ -176,7 +176,7 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 			return nil, err
 		}
 	}
-	isValueLengthCheckFlagEnabled := e.enableEpochsHandler.IsValueLengthCheckFlagEnabled()
+	isValueLengthCheckFlagEnabled := e.enableEpochsHandler.IsFlagEnabled(vmcommon.ValueLengthCheckFlag)
 	if isValueLengthCheckFlagEnabled && len(vmInput.Arguments[1]) > maxLenForAddNFTQuantity {
 		return nil, fmt.Errorf("%w max length for quantity in nft create is %d", ErrInvalidArguments, maxLenForAddNFTQuantity)
 	}
@@ -228,7 +228,13 @@ func (e *dctNFTCreate) ProcessBuiltinFunction(
 		log.Warn("dctNFTCreate.ProcessBuiltinFunction: cannot marshall dct data for log", "error", err)
 	}
 
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTCreate), vmInput.Arguments[0], nextNonce, quantity, vmInput.CallerAddr, dctDataBytes)
+	events.AddToVMOutput(vmOutput, &events.DCTNFTCreate{
+		Creator:   vmInput.CallerAddr,
+		TokenID:   vmInput.Arguments[0],
+		Nonce:     nextNonce,
+		Quantity:  quantity,
+		TokenData: dctDataBytes,
+	})
 
 	return vmOutput, nil
 }
@@ -285,6 +291,7 @@ func checkDCTNFTCreateBurnAddInput(
 	if check.IfNil(account) && vmInput.CallType != vm.ExecOnDestByCaller {
 		return ErrNilUserAccount
 	}
+	vmInput.GasTrace.Charge(gasTraceFunctionCost, funcGasCost)
 	if vmInput.GasProvided < funcGasCost {
 		return ErrNotEnoughGas
 	}
@@ -295,6 +302,64 @@ func getNonceKey(tokenID []byte) []byte {
 	return append(noncePrefix, tokenID...)
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (e *dctNFTCreate) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < minNumOfArgsForNFTCreate {
+		return 0, fmt.Errorf("%w, wrong number of arguments", ErrInvalidArguments)
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	estimate := newGasEstimate()
+	estimate.add(e.funcGasCost, 1)
+	estimate.add(e.gasConfig.StorePerByte, computeArgumentsLength(vmInput.Arguments))
+
+	return estimate.value()
+}
+
+func (e *dctNFTCreate) computeGasToUse(vmInput *vmcommon.ContractCallInput) uint64 {
+	totalLength := uint64(0)
+	for _, arg := range vmInput.Arguments {
+		totalLength += uint64(len(arg))
+	}
+
+	return totalLength*e.gasConfig.StorePerByte + e.funcGasCost
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctNFTCreate) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	minNumOfArgs := minNumOfArgsForNFTCreate
+	if vmInput.CallType == vm.ExecOnDestByCaller {
+		minNumOfArgs = minNumOfArgsForNFTCreate + 1
+	}
+	lenArgs := len(vmInput.Arguments)
+	if lenArgs < minNumOfArgs {
+		return nil, fmt.Errorf("%w, wrong number of arguments", ErrInvalidArguments)
+	}
+
+	accountWithRoles := vmInput.CallerAddr
+	if vmInput.CallType == vm.ExecOnDestByCaller {
+		accountWithRoles = vmInput.Arguments[lenArgs-1]
+	}
+
+	// the nonce of the created token is read from the account with roles, so all the token nonces are covered
+	tokenID := vmInput.Arguments[0]
+	b := newReadWriteSetBuilder()
+	b.read(accountWithRoles, roleKeyFor(tokenID))
+	b.readWrite(accountWithRoles, nonceKeyFor(tokenID))
+	b.readWritePrefix(accountWithRoles, dctTokenKeyFor(tokenID))
+	b.readWritePrefix(vmcommon.SystemAccountAddress, dctTokenKeyFor(tokenID))
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTCreate) IsInterfaceNil() bool {
 	return e == nil
@@ -9,6 +9,7 @@ import (
 	"github.com/kalyan3104/k-core/core/check"
 	"github.com/kalyan3104/k-core/data/dct"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type dctNFTCreateRoleTransfer struct {
@@ -113,8 +114,11 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtCurrentOwner(
 		return nil, err
 	}
 
-	logData := [][]byte{acntDst.AddressBytes(), boolToSlice(false)}
-	addDCTEntryInVMOutput(vmOutput, []byte(vmInput.Function), tokenID, 0, big.NewInt(0), logData...)
+	events.AddToVMOutput(vmOutput, &events.DCTNFTCreateRoleTransfer{
+		Account:    acntDst.AddressBytes(),
+		TokenID:    tokenID,
+		IsNewOwner: false,
+	})
 
 	destAddress := vmInput.Arguments[1]
 	if e.shardCoordinator.ComputeId(destAddress) == e.shardCoordinator.SelfId() {
@@ -142,8 +146,11 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtCurrentOwner(
 			return nil, err
 		}
 
-		logData = [][]byte{destAddress, boolToSlice(true)}
-		addDCTEntryInVMOutput(vmOutput, []byte(vmInput.Function), tokenID, 0, big.NewInt(0), logData...)
+		events.AddToVMOutput(vmOutput, &events.DCTNFTCreateRoleTransfer{
+			Account:    destAddress,
+			TokenID:    tokenID,
+			IsNewOwner: true,
+		})
 	}
 
 	outAcc := &vmcommon.OutputAccount{
@@ -236,12 +243,36 @@ func (e *dctNFTCreateRoleTransfer) executeTransferNFTCreateChangeAtNextOwner(
 		return err
 	}
 
-	logData := [][]byte{acntDst.AddressBytes(), boolToSlice(true)}
-	addDCTEntryInVMOutput(vmOutput, []byte(vmInput.Function), tokenID, 0, big.NewInt(0), logData...)
+	events.AddToVMOutput(vmOutput, &events.DCTNFTCreateRoleTransfer{
+		Account:    acntDst.AddressBytes(),
+		TokenID:    tokenID,
+		IsNewOwner: true,
+	})
 
 	return nil
 }
 
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctNFTCreateRoleTransfer) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 2 {
+		return nil, ErrInvalidArguments
+	}
+
+	tokenID := vmInput.Arguments[0]
+	b := newReadWriteSetBuilder()
+	b.readWrite(vmInput.RecipientAddr, nonceKeyFor(tokenID))
+	b.readWrite(vmInput.RecipientAddr, roleKeyFor(tokenID))
+	if bytes.Equal(vmInput.CallerAddr, core.DCTSCAddress) {
+		b.readWrite(vmInput.Arguments[1], nonceKeyFor(tokenID))
+		b.readWrite(vmInput.Arguments[1], roleKeyFor(tokenID))
+	}
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTCreateRoleTransfer) IsInterfaceNil() bool {
 	return e == nil
@@ -3,6 +3,7 @@ package builtInFunctions
 import (
 	"bytes"
 	"errors"
+	"math"
 	"math/big"
 	"testing"
 
@@ -451,3 +452,27 @@ func readNFTData(t *testing.T, account vmcommon.UserAccountHandler, marshaller v
 
 	return dctData, latestNonce
 }
+
+func TestDCTNFTCreate_EstimateGasOverflowShouldError(t *testing.T) {
+	t.Parallel()
+
+	nftCreate := createNftCreateWithStubArguments()
+	nftCreate.SetNewGasConfig(&vmcommon.GasCost{
+		BaseOperationCost: vmcommon.BaseOperationCost{StorePerByte: math.MaxUint64 / 8},
+		BuiltInCost:       vmcommon.BuiltInCost{DCTNFTCreate: 1},
+	})
+
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			Arguments: [][]byte{[]byte("token"), {1}, {}, {}, {}, {}, {}},
+		},
+	}
+	gas, err := nftCreate.EstimateGas(vmInput)
+	require.Nil(t, err)
+	require.Equal(t, uint64(math.MaxUint64/8*6+1), gas)
+
+	vmInput.Arguments[2] = []byte("name")
+	gas, err = nftCreate.EstimateGas(vmInput)
+	require.Zero(t, gas)
+	require.Equal(t, ErrGasEstimationOverflow, err)
+}
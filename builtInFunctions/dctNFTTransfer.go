@@ -13,6 +13,7 @@ import (
 	"github.com/kalyan3104/k-core/data/dct"
 	"github.com/kalyan3104/k-core/data/vm"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 const baseDCTKeyPrefix = core.ProtectedKeyPrefix + core.DCTKeyIdentifier
@@ -188,7 +189,13 @@ func (e *dctNFTTransfer) ProcessBuiltinFunction(
 			vmOutput)
 	}
 
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTTransfer), vmInput.Arguments[0], nonce, value, vmInput.CallerAddr, acntDst.AddressBytes())
+	events.AddToVMOutput(vmOutput, &events.DCTNFTTransfer{
+		Sender:   vmInput.CallerAddr,
+		Receiver: acntDst.AddressBytes(),
+		TokenID:  vmInput.Arguments[0],
+		Nonce:    nonce,
+		Value:    value,
+	})
 
 	return vmOutput, nil
 }
@@ -204,11 +211,12 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 	if bytes.Equal(dstAddress, vmInput.CallerAddr) {
 		return nil, fmt.Errorf("%w, can not transfer to self", ErrInvalidArguments)
 	}
-	isTransferToMetaFlagEnabled := e.enableEpochsHandler.IsTransferToMetaFlagEnabled()
+	isTransferToMetaFlagEnabled := e.enableEpochsHandler.IsFlagEnabled(vmcommon.TransferToMetaFlag)
 	isInvalidTransferToMeta := e.shardCoordinator.ComputeId(dstAddress) == core.MetachainShardId && !isTransferToMetaFlagEnabled
 	if isInvalidTransferToMeta {
 		return nil, ErrInvalidRcvAddr
 	}
+	vmInput.GasTrace.Charge(gasTraceFunctionCost, e.funcGasCost)
 	if vmInput.GasProvided < e.funcGasCost {
 		return nil, ErrNotEnoughGas
 	}
@@ -228,7 +236,7 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 	if dctData.Value.Cmp(quantityToTransfer) < 0 {
 		return nil, ErrInvalidNFTQuantity
 	}
-	isCheckTransferFlagEnabled := e.enableEpochsHandler.IsCheckTransferFlagEnabled()
+	isCheckTransferFlagEnabled := e.enableEpochsHandler.IsFlagEnabled(vmcommon.CheckTransferFlag)
 	if isCheckTransferFlagEnabled && quantityToTransfer.Cmp(zero) <= 0 {
 		return nil, ErrInvalidNFTQuantity
 	}
@@ -275,7 +283,7 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 	}
 
 	tokenID := dctTokenKey
-	if e.enableEpochsHandler.IsCheckCorrectTokenIDForTransferRoleFlagEnabled() {
+	if e.enableEpochsHandler.IsFlagEnabled(vmcommon.CheckCorrectTokenIDForTransferRoleFlag) {
 		tokenID = tickerID
 	}
 
@@ -293,7 +301,13 @@ func (e *dctNFTTransfer) processNFTTransferOnSenderShard(
 		return nil, err
 	}
 
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTTransfer), vmInput.Arguments[0], nonce, quantityToTransfer, vmInput.CallerAddr, dstAddress)
+	events.AddToVMOutput(vmOutput, &events.DCTNFTTransfer{
+		Sender:   vmInput.CallerAddr,
+		Receiver: dstAddress,
+		TokenID:  vmInput.Arguments[0],
+		Nonce:    nonce,
+		Value:    quantityToTransfer,
+	})
 
 	return vmOutput, nil
 }
@@ -321,6 +335,7 @@ func (e *dctNFTTransfer) createNFTOutputTransfers(
 		}
 
 		gasForTransfer := uint64(len(marshaledNFTTransfer)) * e.gasConfig.DataCopyPerByte
+		vmInput.GasTrace.Charge(gasTraceDataCopyPerByte, gasForTransfer)
 		if gasForTransfer > vmOutput.GasRemaining {
 			return ErrNotEnoughGas
 		}
@@ -440,6 +455,55 @@ func addNFTTransferToVMOutput(
 	}
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input. When the destination is in
+// another shard, the copy of the token data is charged by the length of the token identifier, nonce and quantity
+// arguments, as the metadata stored by the sender is not read
+func (e *dctNFTTransfer) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < core.MinLenArgumentsDCTNFTTransfer {
+		return 0, ErrInvalidArguments
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	estimate := newGasEstimate()
+	estimate.add(e.funcGasCost, 1)
+	if e.shardCoordinator.SelfId() == e.shardCoordinator.ComputeId(vmInput.Arguments[3]) {
+		return estimate.value()
+	}
+
+	estimate.add(e.gasConfig.DataCopyPerByte, computeArgumentsLength(vmInput.Arguments[:3]))
+
+	return estimate.value()
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctNFTTransfer) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < core.MinLenArgumentsDCTNFTTransfer {
+		return nil, ErrInvalidArguments
+	}
+
+	tokenID := vmInput.Arguments[0]
+	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	b := newReadWriteSetBuilder()
+	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		addTransferAccesses(b, vmInput.CallerAddr, vmInput.Arguments[3], tokenID, nonce)
+		addFunctionCallAccesses(b, vmInput.Arguments[3], vmInput.Arguments, core.MinLenArgumentsDCTNFTTransfer)
+		return b.build(), nil
+	}
+
+	addTransferAccesses(b, nil, vmInput.RecipientAddr, tokenID, nonce)
+	addFunctionCallAccesses(b, vmInput.RecipientAddr, vmInput.Arguments, core.MinLenArgumentsDCTNFTTransfer-1)
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTTransfer) IsInterfaceNil() bool {
 	return e == nil
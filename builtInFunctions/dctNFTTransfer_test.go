@@ -1029,3 +1029,29 @@ func createSetupToSendNFTCrossShard(t *testing.T) (*vmcommon.ContractCallInput,
 
 	return vmInput, sender, nftTransferSenderShard, dctDataStorageHandler, tokenName, tokenNonce
 }
+
+func TestDctNFTTransfer_EstimateGasCrossShardShouldNotReadAccounts(t *testing.T) {
+	t.Parallel()
+
+	nftTransfer := createNftTransferWithMockArguments(0, 2, &mock.GlobalSettingsHandlerStub{})
+	nftTransfer.accounts = &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			require.Fail(t, "accounts should not be read")
+			return nil, nil
+		},
+	}
+	gasCost := createMockGasCost()
+	nftTransfer.SetNewGasConfig(&gasCost)
+
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:  big.NewInt(0),
+			CallerAddr: bytes.Repeat([]byte{2}, 32),
+			Arguments:  [][]byte{[]byte("token"), {1}, {1, 2}, bytes.Repeat([]byte{1}, 32)},
+		},
+	}
+
+	gas, err := nftTransfer.EstimateGas(vmInput)
+	require.Nil(t, err)
+	require.Equal(t, gasCost.BuiltInCost.DCTNFTTransfer+8*gasCost.BaseOperationCost.DataCopyPerByte, gas)
+}
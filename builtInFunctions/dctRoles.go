@@ -3,12 +3,12 @@ package builtInFunctions
 import (
 	"bytes"
 	"math"
-	"math/big"
 
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
 	"github.com/kalyan3104/k-core/data/dct"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 var roleKeyPrefix = []byte(core.ProtectedKeyPrefix + core.DCTRoleIdentifier + core.DCTKeyIdentifier)
@@ -89,8 +89,12 @@ func (e *dctRoles) ProcessBuiltinFunction(
 
 	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
 
-	logData := append([][]byte{acntDst.AddressBytes()}, vmInput.Arguments[1:]...)
-	addDCTEntryInVMOutput(vmOutput, []byte(vmInput.Function), vmInput.Arguments[0], 0, big.NewInt(0), logData...)
+	events.AddToVMOutput(vmOutput, &events.DCTRoles{
+		Function: vmInput.Function,
+		Account:  acntDst.AddressBytes(),
+		TokenID:  vmInput.Arguments[0],
+		Roles:    vmInput.Arguments[1:],
+	})
 
 	return vmOutput, nil
 }
@@ -170,6 +174,27 @@ func (e *dctRoles) CheckAllowedToExecute(account vmcommon.UserAccountHandler, to
 	return nil
 }
 
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctRoles) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 2 {
+		return nil, ErrInvalidArguments
+	}
+
+	tokenID := vmInput.Arguments[0]
+	b := newReadWriteSetBuilder()
+	b.readWrite(vmInput.RecipientAddr, roleKeyFor(tokenID))
+	for _, arg := range vmInput.Arguments[1:] {
+		if bytes.Equal(arg, []byte(core.DCTRoleNFTCreateMultiShard)) {
+			b.readWrite(vmInput.RecipientAddr, nonceKeyFor(tokenID))
+		}
+	}
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctRoles) IsInterfaceNil() bool {
 	return e == nil
@@ -11,6 +11,7 @@ import (
 	"github.com/kalyan3104/k-core/data/dct"
 	"github.com/kalyan3104/k-core/data/vm"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 var zero = big.NewInt(0)
@@ -91,7 +92,7 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
-	isInvalidTransferToMeta := e.shardCoordinator.ComputeId(vmInput.RecipientAddr) == core.MetachainShardId && !e.enableEpochsHandler.IsTransferToMetaFlagEnabled()
+	isInvalidTransferToMeta := e.shardCoordinator.ComputeId(vmInput.RecipientAddr) == core.MetachainShardId && !e.enableEpochsHandler.IsFlagEnabled(vmcommon.TransferToMetaFlag)
 	if isInvalidTransferToMeta {
 		return nil, ErrInvalidRcvAddr
 	}
@@ -106,7 +107,7 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 	tokenID := vmInput.Arguments[0]
 
 	keyToCheck := dctTokenKey
-	if e.enableEpochsHandler.IsCheckCorrectTokenIDForTransferRoleFlagEnabled() {
+	if e.enableEpochsHandler.IsFlagEnabled(vmcommon.CheckCorrectTokenIDForTransferRoleFlag) {
 		keyToCheck = tokenID
 	}
 
@@ -117,6 +118,7 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 
 	if !check.IfNil(acntSnd) {
 		// gas is paid only by sender
+		vmInput.GasTrace.Charge(gasTraceFunctionCost, e.funcGasCost)
 		if vmInput.GasProvided < e.funcGasCost {
 			return nil, ErrNotEnoughGas
 		}
@@ -156,7 +158,12 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 				vmInput.CallType,
 				vmOutput)
 
-			addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTTransfer), tokenID, 0, value, vmInput.CallerAddr, acntDst.AddressBytes())
+			events.AddToVMOutput(vmOutput, &events.DCTTransfer{
+				Sender:   vmInput.CallerAddr,
+				Receiver: acntDst.AddressBytes(),
+				TokenID:  tokenID,
+				Value:    value,
+			})
 			return vmOutput, nil
 		}
 
@@ -165,7 +172,12 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 			vmOutput.GasRemaining = vmInput.GasProvided
 		}
 
-		addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTTransfer), tokenID, 0, value, vmInput.CallerAddr, acntDst.AddressBytes())
+		events.AddToVMOutput(vmOutput, &events.DCTTransfer{
+			Sender:   vmInput.CallerAddr,
+			Receiver: acntDst.AddressBytes(),
+			TokenID:  tokenID,
+			Value:    value,
+		})
 		return vmOutput, nil
 	}
 
@@ -181,7 +193,12 @@ func (e *dctTransfer) ProcessBuiltinFunction(
 			vmOutput)
 	}
 
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTTransfer), tokenID, 0, value, vmInput.CallerAddr, vmInput.RecipientAddr)
+	events.AddToVMOutput(vmOutput, &events.DCTTransfer{
+		Sender:   vmInput.CallerAddr,
+		Receiver: vmInput.RecipientAddr,
+		TokenID:  tokenID,
+		Value:    value,
+	})
 	return vmOutput, nil
 }
 
@@ -367,6 +384,34 @@ func (e *dctTransfer) SetPayableChecker(payableHandler vmcommon.PayableChecker)
 	return nil
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (e *dctTransfer) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost, nil
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctTransfer) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < core.MinLenArgumentsDCTTransfer {
+		return nil, ErrInvalidArguments
+	}
+
+	b := newReadWriteSetBuilder()
+	addTransferAccesses(b, vmInput.CallerAddr, vmInput.RecipientAddr, vmInput.Arguments[0], 0)
+	addFunctionCallAccesses(b, vmInput.RecipientAddr, vmInput.Arguments, core.MinLenArgumentsDCTTransfer)
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctTransfer) IsInterfaceNil() bool {
 	return e == nil
@@ -2,13 +2,13 @@ package builtInFunctions
 
 import (
 	"bytes"
-	"math/big"
 
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
 	"github.com/kalyan3104/k-core/data/dct"
 	"github.com/kalyan3104/k-core/marshal"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 const transfer = "transfer"
@@ -51,7 +51,7 @@ func NewDCTTransferRoleAddressFunc(
 		set:             set,
 	}
 
-	e.baseActiveHandler.activeHandler = enableEpochsHandler.IsSendAlwaysFlagEnabled
+	e.baseActiveHandler.activeHandler = flagActiveHandler(enableEpochsHandler, vmcommon.SendAlwaysFlag)
 
 	return e, nil
 }
@@ -108,8 +108,12 @@ func (e *dctTransferAddress) ProcessBuiltinFunction(
 
 	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
 
-	logData := append([][]byte{systemAcc.AddressBytes()}, vmInput.Arguments[1:]...)
-	addDCTEntryInVMOutput(vmOutput, []byte(vmInput.Function), vmInput.Arguments[0], 0, big.NewInt(0), logData...)
+	events.AddToVMOutput(vmOutput, &events.DCTTransferRoleAddresses{
+		Function:  vmInput.Function,
+		Account:   systemAcc.AddressBytes(),
+		TokenID:   vmInput.Arguments[0],
+		Addresses: vmInput.Arguments[1:],
+	})
 
 	return vmOutput, nil
 }
@@ -149,6 +153,21 @@ func (e *dctTransferAddress) getSystemAccount() (vmcommon.UserAccountHandler, er
 	return userAcc, nil
 }
 
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctTransferAddress) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 1 {
+		return nil, ErrInvalidArguments
+	}
+
+	b := newReadWriteSetBuilder()
+	b.readWrite(vmcommon.SystemAccountAddress, transferAddressesKeyFor(vmInput.Arguments[0]))
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctTransferAddress) IsInterfaceNil() bool {
 	return e == nil
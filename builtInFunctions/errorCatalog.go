@@ -0,0 +1,121 @@
+package builtInFunctions
+
+import (
+	"errors"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+// ErrorCategory groups the built-in function errors by their cause
+type ErrorCategory string
+
+const (
+	// ArgumentErrorCategory is the category of the errors caused by invalid arguments or input
+	ArgumentErrorCategory ErrorCategory = "argument"
+	// PermissionErrorCategory is the category of the errors caused by an action the caller is not allowed to do
+	PermissionErrorCategory ErrorCategory = "permission"
+	// BalanceErrorCategory is the category of the errors caused by insufficient or invalid balances and quantities
+	BalanceErrorCategory ErrorCategory = "balance"
+	// StateErrorCategory is the category of the errors caused by the state of the accounts or of the components
+	StateErrorCategory ErrorCategory = "state"
+	// GasErrorCategory is the category of the errors caused by the gas
+	GasErrorCategory ErrorCategory = "gas"
+)
+
+// ErrorCatalogEntry describes a built-in function error in a way clients can rely on. ID and Code never change, even
+// if the message of the error does, so clients should match on them instead of the message
+type ErrorCatalogEntry struct {
+	Err        error
+	ID         string
+	Code       uint32
+	Category   ErrorCategory
+	ReturnCode vmcommon.ReturnCode
+}
+
+// errorCatalog holds every exported built-in function error. The IDs and the codes are never reused or changed: a
+// new error takes the next free code, while a removed error leaves its ID and code unused
+var errorCatalog = []ErrorCatalogEntry{
+	{Err: ErrNilAccountsAdapter, ID: "nil_accounts_adapter", Code: 1, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrInsufficientFunds, ID: "insufficient_funds", Code: 2, Category: BalanceErrorCategory, ReturnCode: vmcommon.OutOfFunds},
+	{Err: ErrNilValue, ID: "nil_value", Code: 3, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNilMarshalizer, ID: "nil_marshalizer", Code: 4, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrInvalidRcvAddr, ID: "invalid_rcv_addr", Code: 5, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNegativeValue, ID: "negative_value", Code: 6, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNilShardCoordinator, ID: "nil_shard_coordinator", Code: 7, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrWrongTypeAssertion, ID: "wrong_type_assertion", Code: 8, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrNilSCDestAccount, ID: "nil_sc_dest_account", Code: 9, Category: StateErrorCategory, ReturnCode: vmcommon.ContractNotFound},
+	{Err: ErrNotEnoughGas, ID: "not_enough_gas", Code: 10, Category: GasErrorCategory, ReturnCode: vmcommon.OutOfGas},
+	{Err: ErrInvalidArguments, ID: "invalid_arguments", Code: 11, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrOperationNotPermitted, ID: "operation_not_permitted", Code: 12, Category: PermissionErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrInvalidAddressLength, ID: "invalid_address_length", Code: 13, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNilVmInput, ID: "nil_vm_input", Code: 14, Category: ArgumentErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrNilDnsAddresses, ID: "nil_dns_addresses", Code: 15, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrCallerIsNotTheDNSAddress, ID: "caller_is_not_the_dns_address", Code: 16, Category: PermissionErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrUserNameChangeIsDisabled, ID: "user_name_change_is_disabled", Code: 17, Category: PermissionErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrBuiltInFunctionCalledWithValue, ID: "built_in_function_called_with_value", Code: 18, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrAccountNotPayable, ID: "account_not_payable", Code: 19, Category: PermissionErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNilUserAccount, ID: "nil_user_account", Code: 20, Category: StateErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrAddressIsNotDCTSystemSC, ID: "address_is_not_dct_system_sc", Code: 21, Category: PermissionErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrOnlySystemAccountAccepted, ID: "only_system_account_accepted", Code: 22, Category: PermissionErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNilGlobalSettingsHandler, ID: "nil_global_settings_handler", Code: 23, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrNilRolesHandler, ID: "nil_roles_handler", Code: 24, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrDCTTokenIsPaused, ID: "dct_token_is_paused", Code: 25, Category: PermissionErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrDCTIsFrozenForAccount, ID: "dct_is_frozen_for_account", Code: 26, Category: PermissionErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrCannotWipeAccountNotFrozen, ID: "cannot_wipe_account_not_frozen", Code: 27, Category: StateErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNilPayableHandler, ID: "nil_payable_handler", Code: 28, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrActionNotAllowed, ID: "action_not_allowed", Code: 29, Category: PermissionErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrOnlyFungibleTokensHaveBalanceTransfer, ID: "only_fungible_tokens_have_balance_transfer", Code: 30, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNFTTokenDoesNotExist, ID: "nft_token_does_not_exist", Code: 31, Category: StateErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNFTDoesNotHaveMetadata, ID: "nft_does_not_have_metadata", Code: 32, Category: StateErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrInvalidNFTQuantity, ID: "invalid_nft_quantity", Code: 33, Category: BalanceErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNewNFTDataOnSenderAddress, ID: "new_nft_data_on_sender_address", Code: 34, Category: StateErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNilContainerElement, ID: "nil_container_element", Code: 35, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrInvalidContainerKey, ID: "invalid_container_key", Code: 36, Category: ArgumentErrorCategory, ReturnCode: vmcommon.FunctionNotFound},
+	{Err: ErrContainerKeyAlreadyExists, ID: "container_key_already_exists", Code: 37, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrWrongTypeInContainer, ID: "wrong_type_in_container", Code: 38, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrEmptyFunctionName, ID: "empty_function_name", Code: 39, Category: ArgumentErrorCategory, ReturnCode: vmcommon.FunctionNotFound},
+	{Err: ErrInsufficientQuantityDCT, ID: "insufficient_quantity_dct", Code: 40, Category: BalanceErrorCategory, ReturnCode: vmcommon.OutOfFunds},
+	{Err: ErrNilDCTNFTStorageHandler, ID: "nil_dct_nft_storage_handler", Code: 41, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrNilTransactionHandler, ID: "nil_transaction_handler", Code: 42, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrAddressIsNotAllowed, ID: "address_is_not_allowed", Code: 43, Category: PermissionErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrInvalidNumOfArgs, ID: "invalid_num_of_args", Code: 44, Category: ArgumentErrorCategory, ReturnCode: vmcommon.FunctionWrongSignature},
+	{Err: ErrInvalidNonce, ID: "invalid_nonce", Code: 45, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrTokenHasValidMetadata, ID: "token_has_valid_metadata", Code: 46, Category: StateErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrInvalidTokenID, ID: "invalid_token_id", Code: 47, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrNilDCTData, ID: "nil_dct_data", Code: 48, Category: StateErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrInvalidMetadata, ID: "invalid_metadata", Code: 49, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrInvalidLiquidityForDCT, ID: "invalid_liquidity_for_dct", Code: 50, Category: BalanceErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrTooManyTransferAddresses, ID: "too_many_transfer_addresses", Code: 51, Category: ArgumentErrorCategory, ReturnCode: vmcommon.UserError},
+	{Err: ErrInvalidMaxNumAddresses, ID: "invalid_max_num_addresses", Code: 52, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrNilEnableEpochsHandler, ID: "nil_enable_epochs_handler", Code: 53, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrNilActiveHandler, ID: "nil_active_handler", Code: 54, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrNilBuiltInFunctionConstructor, ID: "nil_built_in_function_constructor", Code: 55, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrBuiltInFunctionAlreadyRegistered, ID: "built_in_function_already_registered", Code: 56, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrInvalidArgumentsSchema, ID: "invalid_arguments_schema", Code: 58, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrNilBuiltInFunction, ID: "nil_built_in_function", Code: 59, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrNilStorageUpdatesRecorder, ID: "nil_storage_updates_recorder", Code: 60, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrGasEstimationNotSupported, ID: "gas_estimation_not_supported", Code: 61, Category: GasErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrNilBuiltInFunctionContainer, ID: "nil_built_in_function_container", Code: 62, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrReadWriteSetNotSupported, ID: "read_write_set_not_supported", Code: 63, Category: StateErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+	{Err: ErrGasEstimationOverflow, ID: "gas_estimation_overflow", Code: 64, Category: GasErrorCategory, ReturnCode: vmcommon.ExecutionFailed},
+}
+
+// ErrorCatalog returns the entries of all the built-in function errors, ordered by code
+func ErrorCatalog() []ErrorCatalogEntry {
+	return append(make([]ErrorCatalogEntry, 0, len(errorCatalog)), errorCatalog...)
+}
+
+// LookupError returns the catalog entry of the built-in function error the provided error is, or wraps
+func LookupError(err error) (ErrorCatalogEntry, bool) {
+	if err == nil {
+		return ErrorCatalogEntry{}, false
+	}
+
+	for _, entry := range errorCatalog {
+		if errors.Is(err, entry.Err) {
+			return entry, true
+		}
+	}
+
+	return ErrorCatalogEntry{}, false
+}
@@ -0,0 +1,249 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCatalog_CoversAllExportedErrors(t *testing.T) {
+	t.Parallel()
+
+	exportedErrors := map[string]error{
+		"ErrNilAccountsAdapter":                    ErrNilAccountsAdapter,
+		"ErrInsufficientFunds":                     ErrInsufficientFunds,
+		"ErrNilValue":                              ErrNilValue,
+		"ErrNilMarshalizer":                        ErrNilMarshalizer,
+		"ErrInvalidRcvAddr":                        ErrInvalidRcvAddr,
+		"ErrNegativeValue":                         ErrNegativeValue,
+		"ErrNilShardCoordinator":                   ErrNilShardCoordinator,
+		"ErrWrongTypeAssertion":                    ErrWrongTypeAssertion,
+		"ErrNilSCDestAccount":                      ErrNilSCDestAccount,
+		"ErrNotEnoughGas":                          ErrNotEnoughGas,
+		"ErrInvalidArguments":                      ErrInvalidArguments,
+		"ErrOperationNotPermitted":                 ErrOperationNotPermitted,
+		"ErrInvalidAddressLength":                  ErrInvalidAddressLength,
+		"ErrNilVmInput":                            ErrNilVmInput,
+		"ErrNilDnsAddresses":                       ErrNilDnsAddresses,
+		"ErrCallerIsNotTheDNSAddress":              ErrCallerIsNotTheDNSAddress,
+		"ErrUserNameChangeIsDisabled":              ErrUserNameChangeIsDisabled,
+		"ErrBuiltInFunctionCalledWithValue":        ErrBuiltInFunctionCalledWithValue,
+		"ErrAccountNotPayable":                     ErrAccountNotPayable,
+		"ErrNilUserAccount":                        ErrNilUserAccount,
+		"ErrAddressIsNotDCTSystemSC":               ErrAddressIsNotDCTSystemSC,
+		"ErrOnlySystemAccountAccepted":             ErrOnlySystemAccountAccepted,
+		"ErrNilGlobalSettingsHandler":              ErrNilGlobalSettingsHandler,
+		"ErrNilRolesHandler":                       ErrNilRolesHandler,
+		"ErrDCTTokenIsPaused":                      ErrDCTTokenIsPaused,
+		"ErrDCTIsFrozenForAccount":                 ErrDCTIsFrozenForAccount,
+		"ErrCannotWipeAccountNotFrozen":            ErrCannotWipeAccountNotFrozen,
+		"ErrNilPayableHandler":                     ErrNilPayableHandler,
+		"ErrActionNotAllowed":                      ErrActionNotAllowed,
+		"ErrOnlyFungibleTokensHaveBalanceTransfer": ErrOnlyFungibleTokensHaveBalanceTransfer,
+		"ErrNFTTokenDoesNotExist":                  ErrNFTTokenDoesNotExist,
+		"ErrNFTDoesNotHaveMetadata":                ErrNFTDoesNotHaveMetadata,
+		"ErrInvalidNFTQuantity":                    ErrInvalidNFTQuantity,
+		"ErrNewNFTDataOnSenderAddress":             ErrNewNFTDataOnSenderAddress,
+		"ErrNilContainerElement":                   ErrNilContainerElement,
+		"ErrInvalidContainerKey":                   ErrInvalidContainerKey,
+		"ErrContainerKeyAlreadyExists":             ErrContainerKeyAlreadyExists,
+		"ErrWrongTypeInContainer":                  ErrWrongTypeInContainer,
+		"ErrEmptyFunctionName":                     ErrEmptyFunctionName,
+		"ErrInsufficientQuantityDCT":               ErrInsufficientQuantityDCT,
+		"ErrNilDCTNFTStorageHandler":               ErrNilDCTNFTStorageHandler,
+		"ErrNilTransactionHandler":                 ErrNilTransactionHandler,
+		"ErrAddressIsNotAllowed":                   ErrAddressIsNotAllowed,
+		"ErrInvalidNumOfArgs":                      ErrInvalidNumOfArgs,
+		"ErrInvalidNonce":                          ErrInvalidNonce,
+		"ErrTokenHasValidMetadata":                 ErrTokenHasValidMetadata,
+		"ErrInvalidTokenID":                        ErrInvalidTokenID,
+		"ErrNilDCTData":                            ErrNilDCTData,
+		"ErrInvalidMetadata":                       ErrInvalidMetadata,
+		"ErrInvalidLiquidityForDCT":                ErrInvalidLiquidityForDCT,
+		"ErrTooManyTransferAddresses":              ErrTooManyTransferAddresses,
+		"ErrInvalidMaxNumAddresses":                ErrInvalidMaxNumAddresses,
+		"ErrNilEnableEpochsHandler":                ErrNilEnableEpochsHandler,
+		"ErrNilActiveHandler":                      ErrNilActiveHandler,
+		"ErrNilBuiltInFunctionConstructor":         ErrNilBuiltInFunctionConstructor,
+		"ErrBuiltInFunctionAlreadyRegistered":      ErrBuiltInFunctionAlreadyRegistered,
+		"ErrInvalidArgumentsSchema":                ErrInvalidArgumentsSchema,
+		"ErrNilBuiltInFunction":                    ErrNilBuiltInFunction,
+		"ErrNilStorageUpdatesRecorder":             ErrNilStorageUpdatesRecorder,
+		"ErrGasEstimationNotSupported":             ErrGasEstimationNotSupported,
+		"ErrGasEstimationOverflow":                 ErrGasEstimationOverflow,
+		"ErrNilBuiltInFunctionContainer":           ErrNilBuiltInFunctionContainer,
+		"ErrReadWriteSetNotSupported":              ErrReadWriteSetNotSupported,
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "errors.go", nil, 0)
+	require.Nil(t, err)
+
+	catalogErrors := make(map[error]struct{})
+	for _, entry := range ErrorCatalog() {
+		catalogErrors[entry.Err] = struct{}{}
+	}
+
+	numExportedErrors := 0
+	for name, object := range file.Scope.Objects {
+		if object.Kind != ast.Var || !ast.IsExported(name) {
+			continue
+		}
+		numExportedErrors++
+
+		exportedErr, found := exportedErrors[name]
+		if !assert.True(t, found, "%s should be added to the exported errors of this test", name) {
+			continue
+		}
+		assert.Contains(t, catalogErrors, exportedErr, "%s should be in the catalog", name)
+	}
+	assert.Equal(t, numExportedErrors, len(exportedErrors), "every exported error of this test should be declared in errors.go")
+	assert.Equal(t, numExportedErrors, len(catalogErrors), "the catalog should only hold exported errors")
+}
+
+func TestErrorCatalog_EntriesAreUniqueAndValid(t *testing.T) {
+	t.Parallel()
+
+	idRegex := regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	categories := map[ErrorCategory]struct{}{
+		ArgumentErrorCategory:   {},
+		PermissionErrorCategory: {},
+		BalanceErrorCategory:    {},
+		StateErrorCategory:      {},
+		GasErrorCategory:        {},
+	}
+
+	ids := make(map[string]struct{})
+	codes := make(map[uint32]struct{})
+	lastCode := uint32(0)
+	for _, entry := range ErrorCatalog() {
+		require.NotNil(t, entry.Err)
+		assert.True(t, idRegex.MatchString(entry.ID), entry.ID)
+		assert.Contains(t, categories, entry.Category, entry.ID)
+		assert.Greater(t, entry.Code, lastCode, entry.ID)
+		lastCode = entry.Code
+
+		_, exists := ids[entry.ID]
+		assert.False(t, exists, entry.ID)
+		ids[entry.ID] = struct{}{}
+		_, exists = codes[entry.Code]
+		assert.False(t, exists, entry.ID)
+		codes[entry.Code] = struct{}{}
+	}
+}
+
+func TestErrorCatalog_ReturnsACopy(t *testing.T) {
+	t.Parallel()
+
+	catalog := ErrorCatalog()
+	catalog[0].ID = "changed"
+	assert.Equal(t, "nil_accounts_adapter", ErrorCatalog()[0].ID)
+}
+
+func TestLookupError(t *testing.T) {
+	t.Parallel()
+
+	_, found := LookupError(nil)
+	assert.False(t, found)
+	_, found = LookupError(errors.New("unknown"))
+	assert.False(t, found)
+
+	entry, found := LookupError(fmt.Errorf("%w for address", ErrInsufficientFunds))
+	require.True(t, found)
+	assert.Equal(t, "insufficient_funds", entry.ID)
+	assert.Equal(t, uint32(2), entry.Code)
+	assert.Equal(t, BalanceErrorCategory, entry.Category)
+	assert.True(t, errors.Is(entry.Err, ErrInsufficientFunds))
+
+	entry, found = LookupError(NewVMError("DCTTransfer", ErrNotEnoughGas))
+	require.True(t, found)
+	assert.Equal(t, "not_enough_gas", entry.ID)
+	assert.Equal(t, GasErrorCategory, entry.Category)
+}
+
+func TestErrorCatalog_IDsAndCodesShouldNotChange(t *testing.T) {
+	t.Parallel()
+
+	// the IDs and the codes are part of the API: entries can only be appended, never renamed or renumbered
+	type idAndCode struct {
+		ID   string
+		Code uint32
+	}
+	expected := []idAndCode{
+		{ID: "nil_accounts_adapter", Code: 1},
+		{ID: "insufficient_funds", Code: 2},
+		{ID: "nil_value", Code: 3},
+		{ID: "nil_marshalizer", Code: 4},
+		{ID: "invalid_rcv_addr", Code: 5},
+		{ID: "negative_value", Code: 6},
+		{ID: "nil_shard_coordinator", Code: 7},
+		{ID: "wrong_type_assertion", Code: 8},
+		{ID: "nil_sc_dest_account", Code: 9},
+		{ID: "not_enough_gas", Code: 10},
+		{ID: "invalid_arguments", Code: 11},
+		{ID: "operation_not_permitted", Code: 12},
+		{ID: "invalid_address_length", Code: 13},
+		{ID: "nil_vm_input", Code: 14},
+		{ID: "nil_dns_addresses", Code: 15},
+		{ID: "caller_is_not_the_dns_address", Code: 16},
+		{ID: "user_name_change_is_disabled", Code: 17},
+		{ID: "built_in_function_called_with_value", Code: 18},
+		{ID: "account_not_payable", Code: 19},
+		{ID: "nil_user_account", Code: 20},
+		{ID: "address_is_not_dct_system_sc", Code: 21},
+		{ID: "only_system_account_accepted", Code: 22},
+		{ID: "nil_global_settings_handler", Code: 23},
+		{ID: "nil_roles_handler", Code: 24},
+		{ID: "dct_token_is_paused", Code: 25},
+		{ID: "dct_is_frozen_for_account", Code: 26},
+		{ID: "cannot_wipe_account_not_frozen", Code: 27},
+		{ID: "nil_payable_handler", Code: 28},
+		{ID: "action_not_allowed", Code: 29},
+		{ID: "only_fungible_tokens_have_balance_transfer", Code: 30},
+		{ID: "nft_token_does_not_exist", Code: 31},
+		{ID: "nft_does_not_have_metadata", Code: 32},
+		{ID: "invalid_nft_quantity", Code: 33},
+		{ID: "new_nft_data_on_sender_address", Code: 34},
+		{ID: "nil_container_element", Code: 35},
+		{ID: "invalid_container_key", Code: 36},
+		{ID: "container_key_already_exists", Code: 37},
+		{ID: "wrong_type_in_container", Code: 38},
+		{ID: "empty_function_name", Code: 39},
+		{ID: "insufficient_quantity_dct", Code: 40},
+		{ID: "nil_dct_nft_storage_handler", Code: 41},
+		{ID: "nil_transaction_handler", Code: 42},
+		{ID: "address_is_not_allowed", Code: 43},
+		{ID: "invalid_num_of_args", Code: 44},
+		{ID: "invalid_nonce", Code: 45},
+		{ID: "token_has_valid_metadata", Code: 46},
+		{ID: "invalid_token_id", Code: 47},
+		{ID: "nil_dct_data", Code: 48},
+		{ID: "invalid_metadata", Code: 49},
+		{ID: "invalid_liquidity_for_dct", Code: 50},
+		{ID: "too_many_transfer_addresses", Code: 51},
+		{ID: "invalid_max_num_addresses", Code: 52},
+		{ID: "nil_enable_epochs_handler", Code: 53},
+		{ID: "nil_active_handler", Code: 54},
+		{ID: "nil_built_in_function_constructor", Code: 55},
+		{ID: "built_in_function_already_registered", Code: 56},
+		{ID: "invalid_arguments_schema", Code: 58},
+		{ID: "nil_built_in_function", Code: 59},
+		{ID: "nil_storage_updates_recorder", Code: 60},
+		{ID: "gas_estimation_not_supported", Code: 61},
+		{ID: "nil_built_in_function_container", Code: 62},
+		{ID: "read_write_set_not_supported", Code: 63},
+		{ID: "gas_estimation_overflow", Code: 64},
+	}
+
+	actual := make([]idAndCode, 0, len(ErrorCatalog()))
+	for _, entry := range ErrorCatalog() {
+		actual = append(actual, idAndCode{ID: entry.ID, Code: entry.Code})
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -166,3 +166,30 @@ var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
 
 // ErrNilActiveHandler signals that a nil active handler has been provided
 var ErrNilActiveHandler = errors.New("nil active handler")
+
+// ErrNilBuiltInFunctionConstructor signals that a built-in function descriptor without constructor has been provided
+var ErrNilBuiltInFunctionConstructor = errors.New("nil built-in function constructor")
+
+// ErrBuiltInFunctionAlreadyRegistered signals that a built-in function with the same name is already registered
+var ErrBuiltInFunctionAlreadyRegistered = errors.New("built-in function already registered")
+
+// ErrInvalidArgumentsSchema signals that the provided arguments schema is invalid
+var ErrInvalidArgumentsSchema = errors.New("invalid arguments schema")
+
+// ErrNilBuiltInFunction signals that a nil built-in function has been provided
+var ErrNilBuiltInFunction = errors.New("nil built-in function")
+
+// ErrNilStorageUpdatesRecorder signals that a nil storage updates recorder has been provided
+var ErrNilStorageUpdatesRecorder = errors.New("nil storage updates recorder")
+
+// ErrGasEstimationNotSupported signals that the built-in function does not support gas estimation
+var ErrGasEstimationNotSupported = errors.New("gas estimation not supported")
+
+// ErrGasEstimationOverflow signals that the estimated gas does not fit into an uint64
+var ErrGasEstimationOverflow = errors.New("gas estimation overflowed")
+
+// ErrNilBuiltInFunctionContainer signals that a nil built-in function container has been provided
+var ErrNilBuiltInFunctionContainer = errors.New("nil built-in function container")
+
+// ErrReadWriteSetNotSupported signals that the built-in function does not support deriving its read/write set
+var ErrReadWriteSetNotSupported = errors.New("read/write set not supported")
@@ -0,0 +1,44 @@
+package builtInFunctions
+
+import (
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.BuiltinFunction = (*flagActivatedFunction)(nil)
+
+// flagActivatedFunction wraps a built-in function created from a registry descriptor with an activation flag, so it is
+// active only while the flag is enabled, whatever active handler the wrapped function installed
+type flagActivatedFunction struct {
+	vmcommon.BuiltinFunction
+	activeHandler func() bool
+}
+
+func newFlagActivatedFunction(builtInFunction vmcommon.BuiltinFunction, activeHandler func() bool) (*flagActivatedFunction, error) {
+	if check.IfNil(builtInFunction) {
+		return nil, ErrNilBuiltInFunction
+	}
+	if activeHandler == nil {
+		return nil, ErrNilActiveHandler
+	}
+
+	return &flagActivatedFunction{
+		BuiltinFunction: builtInFunction,
+		activeHandler:   activeHandler,
+	}, nil
+}
+
+// IsActive returns true if the activation flag of the descriptor is enabled
+func (faf *flagActivatedFunction) IsActive() bool {
+	return faf.activeHandler()
+}
+
+// Unwrap returns the wrapped built-in function
+func (faf *flagActivatedFunction) Unwrap() vmcommon.BuiltinFunction {
+	return faf.BuiltinFunction
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (faf *flagActivatedFunction) IsInterfaceNil() bool {
+	return faf == nil || check.IfNil(faf.BuiltinFunction)
+}
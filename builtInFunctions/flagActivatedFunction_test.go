@@ -0,0 +1,49 @@
+package builtInFunctions
+
+import (
+	"testing"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFlagActivatedFunction(t *testing.T) {
+	t.Parallel()
+
+	activatedFunc, err := newFlagActivatedFunction(nil, trueHandler)
+	assert.Nil(t, activatedFunc)
+	assert.Equal(t, ErrNilBuiltInFunction, err)
+
+	activatedFunc, err = newFlagActivatedFunction(&mock.BuiltInFunctionStub{}, nil)
+	assert.Nil(t, activatedFunc)
+	assert.Equal(t, ErrNilActiveHandler, err)
+
+	builtInFunc := &mock.BuiltInFunctionStub{}
+	activatedFunc, err = newFlagActivatedFunction(builtInFunc, trueHandler)
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(activatedFunc))
+	assert.True(t, UnwrapBuiltInFunction(activatedFunc) == builtInFunc)
+}
+
+func TestFlagActivatedFunction_IsActiveShouldFollowTheActiveHandler(t *testing.T) {
+	t.Parallel()
+
+	builtInFunc := &mock.BuiltInFunctionStub{
+		IsActiveCalled: func() bool {
+			return true
+		},
+	}
+	isFlagEnabled := false
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag vmcommon.EnableEpochFlag) bool {
+			return flag == vmcommon.SendAlwaysFlag && isFlagEnabled
+		},
+	}
+	activatedFunc, _ := newFlagActivatedFunction(builtInFunc, flagActiveHandler(enableEpochsHandler, vmcommon.SendAlwaysFlag))
+
+	assert.False(t, activatedFunc.IsActive())
+	isFlagEnabled = true
+	assert.True(t, activatedFunc.IsActive())
+}
@@ -0,0 +1,76 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/kalyan3104/k-core/core"
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+// labels of the charges recorded in the gas trace
+const (
+	gasTraceFunctionCost    = "FunctionCost"
+	gasTraceStorePerByte    = "StorePerByte"
+	gasTracePersistPerByte  = "PersistPerByte"
+	gasTraceDataCopyPerByte = "DataCopyPerByte"
+)
+
+// EstimateBuiltInFunctionGas returns the gas consumed by the built-in function named in the provided input, as
+// computed by the function itself from the current gas costs. The accounts are neither read nor mutated
+func EstimateBuiltInFunctionGas(container vmcommon.BuiltInFunctionContainer, vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if check.IfNil(container) {
+		return 0, ErrNilBuiltInFunctionContainer
+	}
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+
+	builtInFunc, err := container.Get(vmInput.Function)
+	if err != nil {
+		return 0, err
+	}
+
+	gasEstimator, ok := UnwrapBuiltInFunction(builtInFunc).(vmcommon.BuiltInFunctionGasEstimator)
+	if !ok {
+		return 0, fmt.Errorf("%w for function %s", ErrGasEstimationNotSupported, vmInput.Function)
+	}
+
+	return gasEstimator.EstimateGas(vmInput)
+}
+
+// gasEstimate sums up gas costs as a big integer, so an estimate computed from crafted arguments can not wrap around
+type gasEstimate struct {
+	total *big.Int
+}
+
+func newGasEstimate() *gasEstimate {
+	return &gasEstimate{
+		total: big.NewInt(0),
+	}
+}
+
+// add sums up the cost charged for the provided number of units
+func (ge *gasEstimate) add(cost uint64, numUnits uint64) {
+	ge.total.Add(ge.total, core.SafeMul(cost, numUnits))
+}
+
+// value returns the estimated gas or an error if it does not fit into an uint64
+func (ge *gasEstimate) value() (uint64, error) {
+	if !ge.total.IsUint64() {
+		return 0, ErrGasEstimationOverflow
+	}
+
+	return ge.total.Uint64(), nil
+}
+
+// computeArgumentsLength returns the total length of the provided arguments
+func computeArgumentsLength(arguments [][]byte) uint64 {
+	length := uint64(0)
+	for _, arg := range arguments {
+		length += uint64(len(arg))
+	}
+
+	return length
+}
@@ -0,0 +1,170 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createContainerWithMockGasCost(t *testing.T, recordStorageUpdates bool) vmcommon.BuiltInFunctionContainer {
+	args := createMockArguments()
+	args.RecordStorageUpdates = recordStorageUpdates
+	creator, err := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, err)
+	err = creator.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	gasCost := createMockGasCost()
+	container := creator.BuiltInFunctionContainer()
+	for key := range container.Keys() {
+		builtInFunc, _ := container.Get(key)
+		builtInFunc.SetNewGasConfig(&gasCost)
+	}
+
+	return container
+}
+
+func TestEstimateBuiltInFunctionGas_Errors(t *testing.T) {
+	t.Parallel()
+
+	container := createContainerWithMockGasCost(t, false)
+
+	gas, err := EstimateBuiltInFunctionGas(nil, &vmcommon.ContractCallInput{})
+	assert.Zero(t, gas)
+	assert.Equal(t, ErrNilBuiltInFunctionContainer, err)
+
+	gas, err = EstimateBuiltInFunctionGas(container, nil)
+	assert.Zero(t, gas)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	gas, err = EstimateBuiltInFunctionGas(container, &vmcommon.ContractCallInput{Function: "missing"})
+	assert.Zero(t, gas)
+	assert.True(t, errors.Is(err, ErrInvalidContainerKey))
+
+	gas, err = EstimateBuiltInFunctionGas(container, &vmcommon.ContractCallInput{Function: core.BuiltInFunctionDCTPause})
+	assert.Zero(t, gas)
+	assert.True(t, errors.Is(err, ErrGasEstimationNotSupported))
+
+	gas, err = EstimateBuiltInFunctionGas(container, &vmcommon.ContractCallInput{Function: core.BuiltInFunctionDCTNFTCreate})
+	assert.Zero(t, gas)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+
+	gas, err = EstimateBuiltInFunctionGas(container, &vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: [][]byte{[]byte("dst"), big.NewInt(2).Bytes(), []byte("token"), {1}, {1}}},
+		Function: core.BuiltInFunctionMultiDCTNFTTransfer,
+	})
+	assert.Zero(t, gas)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+}
+
+func TestEstimateBuiltInFunctionGas_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	gasCost := createMockGasCost()
+	storePerByte := gasCost.BaseOperationCost.StorePerByte
+	persistPerByte := gasCost.BaseOperationCost.PersistPerByte
+	nftCreateArgs := [][]byte{[]byte("token"), {1}, []byte("name"), {10}, []byte("hash"), []byte("attributes"), []byte("uri")}
+	nftCreateArgsLength := uint64(0)
+	for _, arg := range nftCreateArgs {
+		nftCreateArgsLength += uint64(len(arg))
+	}
+
+	testCases := []struct {
+		function  string
+		arguments [][]byte
+		expected  uint64
+	}{
+		{core.BuiltInFunctionChangeOwnerAddress, [][]byte{[]byte("address")}, gasCost.BuiltInCost.ChangeOwnerAddress},
+		{core.BuiltInFunctionClaimDeveloperRewards, nil, gasCost.BuiltInCost.ClaimDeveloperRewards},
+		{core.BuiltInFunctionSetUserName, [][]byte{[]byte("name")}, gasCost.BuiltInCost.SaveUserName},
+		{core.BuiltInFunctionDCTTransfer, [][]byte{[]byte("token"), {1}}, gasCost.BuiltInCost.DCTTransfer},
+		{core.BuiltInFunctionDCTBurn, [][]byte{[]byte("token"), {1}}, gasCost.BuiltInCost.DCTBurn},
+		{core.BuiltInFunctionDCTLocalMint, [][]byte{[]byte("token"), {1}}, gasCost.BuiltInCost.DCTLocalMint},
+		{core.BuiltInFunctionDCTLocalBurn, [][]byte{[]byte("token"), {1}}, gasCost.BuiltInCost.DCTLocalBurn},
+		{core.BuiltInFunctionDCTNFTAddQuantity, [][]byte{[]byte("token"), {1}, {1}}, gasCost.BuiltInCost.DCTNFTAddQuantity},
+		{core.BuiltInFunctionDCTNFTBurn, [][]byte{[]byte("token"), {1}, {1}}, gasCost.BuiltInCost.DCTNFTBurn},
+		{core.BuiltInFunctionDCTNFTTransfer, [][]byte{[]byte("token"), {1}, {1}, []byte("dst")}, gasCost.BuiltInCost.DCTNFTTransfer},
+		{core.BuiltInFunctionDCTNFTCreate, nftCreateArgs, gasCost.BuiltInCost.DCTNFTCreate + nftCreateArgsLength*storePerByte},
+		{core.BuiltInFunctionDCTNFTUpdateAttributes, [][]byte{[]byte("token"), {1}, []byte("attributes")}, gasCost.BuiltInCost.DCTNFTUpdateAttributes + 10*storePerByte},
+		{core.BuiltInFunctionDCTNFTAddURI, [][]byte{[]byte("token"), {1}, []byte("uri1"), []byte("uri22")}, gasCost.BuiltInCost.DCTNFTAddURI + 9*storePerByte},
+		{
+			core.BuiltInFunctionMultiDCTNFTTransfer,
+			[][]byte{[]byte("dst"), big.NewInt(2).Bytes(), []byte("token"), {1}, {1}, []byte("token"), {2}, {1}},
+			2 * gasCost.BuiltInCost.DCTNFTMultiTransfer,
+		},
+		{
+			core.BuiltInFunctionSaveKeyValue,
+			[][]byte{[]byte("key1"), []byte("value1"), []byte("key2"), []byte("v")},
+			gasCost.BuiltInCost.SaveKeyValue + 15*persistPerByte + 7*storePerByte,
+		},
+	}
+
+	for _, recordStorageUpdates := range []bool{false, true} {
+		container := createContainerWithMockGasCost(t, recordStorageUpdates)
+		for _, tc := range testCases {
+			gas, err := EstimateBuiltInFunctionGas(container, &vmcommon.ContractCallInput{
+				VMInput:  vmcommon.VMInput{Arguments: tc.arguments},
+				Function: tc.function,
+			})
+			assert.Nil(t, err, tc.function)
+			assert.Equal(t, tc.expected, gas, tc.function)
+		}
+	}
+}
+
+func TestFunctionContainer_EstimateGas(t *testing.T) {
+	t.Parallel()
+
+	container := createContainerWithMockGasCost(t, false)
+	functionContainer, ok := container.(*functionContainer)
+	require.True(t, ok)
+
+	gas, err := functionContainer.EstimateGas(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: [][]byte{[]byte("token"), {1}}},
+		Function: core.BuiltInFunctionDCTTransfer,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, createMockGasCost().BuiltInCost.DCTTransfer, gas)
+}
+
+func TestEstimateBuiltInFunctionGas_MultiTransferWithOverflowingNumOfTransfersShouldError(t *testing.T) {
+	t.Parallel()
+
+	container := createContainerWithMockGasCost(t, false)
+
+	// 3 * numOfTransfers + 2 wraps around to 1
+	numOfTransfers := big.NewInt(0).SetUint64(math.MaxUint64 / 3).Bytes()
+	gas, err := EstimateBuiltInFunctionGas(container, &vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: [][]byte{[]byte("dst"), numOfTransfers, []byte("token"), {1}, {1}}},
+		Function: core.BuiltInFunctionMultiDCTNFTTransfer,
+	})
+	assert.Zero(t, gas)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+}
+
+func TestGasEstimate_OverflowShouldError(t *testing.T) {
+	t.Parallel()
+
+	estimate := newGasEstimate()
+	estimate.add(math.MaxUint64, 1)
+	gas, err := estimate.value()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), gas)
+
+	estimate.add(1, 1)
+	gas, err = estimate.value()
+	assert.Zero(t, gas)
+	assert.Equal(t, ErrGasEstimationOverflow, err)
+
+	estimate = newGasEstimate()
+	estimate.add(math.MaxUint64, 2)
+	gas, err = estimate.value()
+	assert.Zero(t, gas)
+	assert.Equal(t, ErrGasEstimationOverflow, err)
+}
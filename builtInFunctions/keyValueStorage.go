@@ -8,16 +8,25 @@ import (
 
 	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type saveKeyValueStorage struct {
 	baseAlwaysActiveHandler
-	gasConfig    vmcommon.BaseOperationCost
-	funcGasCost  uint64
-	mutExecution sync.RWMutex
+	gasConfig           vmcommon.BaseOperationCost
+	funcGasCost         uint64
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+	mutExecution        sync.RWMutex
 }
 
-// NewSaveKeyValueStorageFunc returns the save key-value storage built in function
+// ArgsNewSaveKeyValueStorage defines the argument list for new save key-value storage built in function
+type ArgsNewSaveKeyValueStorage struct {
+	GasConfig           vmcommon.BaseOperationCost
+	FuncGasCost         uint64
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewSaveKeyValueStorageFunc returns the save key-value storage built in function that does not emit logs
 func NewSaveKeyValueStorageFunc(
 	gasConfig vmcommon.BaseOperationCost,
 	funcGasCost uint64,
@@ -30,6 +39,22 @@ func NewSaveKeyValueStorageFunc(
 	return s, nil
 }
 
+// NewSaveKeyValueStorage returns the save key-value storage built in function that emits logs once the built-in
+// functions logs flag is enabled
+func NewSaveKeyValueStorage(args ArgsNewSaveKeyValueStorage) (*saveKeyValueStorage, error) {
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	s, err := NewSaveKeyValueStorageFunc(args.GasConfig, args.FuncGasCost)
+	if err != nil {
+		return nil, err
+	}
+	s.enableEpochsHandler = args.EnableEpochsHandler
+
+	return s, nil
+}
+
 // SetNewGasConfig is called whenever gas cost is changed
 func (k *saveKeyValueStorage) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	if gasCost == nil {
@@ -60,12 +85,15 @@ func (k *saveKeyValueStorage) ProcessBuiltinFunction(
 		GasRefund:    big.NewInt(0),
 	}
 
+	changedPairs := make([]events.KeyValuePair, 0, len(input.Arguments)/2)
 	useGas := k.funcGasCost
+	input.GasTrace.Charge(gasTraceFunctionCost, k.funcGasCost)
 	for i := 0; i < len(input.Arguments); i += 2 {
 		key := input.Arguments[i]
 		value := input.Arguments[i+1]
 		length := uint64(len(value) + len(key))
 		useGas += length * k.gasConfig.PersistPerByte
+		input.GasTrace.Charge(gasTracePersistPerByte, length*k.gasConfig.PersistPerByte)
 
 		if !vmcommon.IsAllowedToSaveUnderKey(key) {
 			return nil, fmt.Errorf("%w it is not allowed to save under key %s", ErrOperationNotPermitted, key)
@@ -84,6 +112,7 @@ func (k *saveKeyValueStorage) ProcessBuiltinFunction(
 		}
 
 		useGas += k.gasConfig.StorePerByte * lengthChange
+		input.GasTrace.Charge(gasTraceStorePerByte, k.gasConfig.StorePerByte*lengthChange)
 		if input.GasProvided < useGas {
 			return nil, ErrNotEnoughGas
 		}
@@ -94,9 +123,16 @@ func (k *saveKeyValueStorage) ProcessBuiltinFunction(
 		if err != nil {
 			return nil, err
 		}
+		changedPairs = append(changedPairs, events.KeyValuePair{Key: key, Value: value})
 	}
 
 	vmOutput.GasRemaining -= useGas
+	if len(changedPairs) > 0 && isBuiltInFunctionsLogsFlagEnabled(k.enableEpochsHandler) {
+		events.AddToVMOutput(vmOutput, &events.SaveKeyValue{
+			Account:       input.RecipientAddr,
+			KeyValuePairs: changedPairs,
+		})
+	}
 
 	return vmOutput, nil
 }
@@ -127,6 +163,48 @@ func checkArgumentsForSaveKeyValue(acntDst vmcommon.UserAccountHandler, input *v
 	return nil
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input, considering that none of the
+// keys is already stored. This is the maximum cost, as storing over an existing value is paid only for the added length
+func (k *saveKeyValueStorage) EstimateGas(input *vmcommon.ContractCallInput) (uint64, error) {
+	if input == nil {
+		return 0, ErrNilVmInput
+	}
+	if len(input.Arguments) < 2 || len(input.Arguments)%2 != 0 {
+		return 0, ErrInvalidArguments
+	}
+
+	k.mutExecution.RLock()
+	defer k.mutExecution.RUnlock()
+
+	estimate := newGasEstimate()
+	estimate.add(k.funcGasCost, 1)
+	for i := 0; i < len(input.Arguments); i += 2 {
+		key := input.Arguments[i]
+		value := input.Arguments[i+1]
+		estimate.add(k.gasConfig.PersistPerByte, uint64(len(value)+len(key)))
+		estimate.add(k.gasConfig.StorePerByte, uint64(len(value)))
+	}
+
+	return estimate.value()
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (k *saveKeyValueStorage) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 2 || len(vmInput.Arguments)%2 != 0 {
+		return nil, ErrInvalidArguments
+	}
+
+	b := newReadWriteSetBuilder()
+	for i := 0; i < len(vmInput.Arguments); i += 2 {
+		b.readWrite(vmInput.RecipientAddr, vmInput.Arguments[i])
+	}
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil return true if underlying object in nil
 func (k *saveKeyValueStorage) IsInterfaceNil() bool {
 	return k == nil
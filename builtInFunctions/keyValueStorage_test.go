@@ -9,6 +9,7 @@ import (
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 	"github.com/kalyan3104/k-vm-common-go/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -28,6 +29,29 @@ func TestNewSaveKeyValueStorageFunc(t *testing.T) {
 	require.Equal(t, gasConfig, kvs.gasConfig)
 }
 
+func TestNewSaveKeyValueStorage(t *testing.T) {
+	t.Parallel()
+
+	funcGasCost := uint64(1)
+	gasConfig := vmcommon.BaseOperationCost{
+		StorePerByte: 1,
+	}
+
+	kvs, err := NewSaveKeyValueStorage(ArgsNewSaveKeyValueStorage{GasConfig: gasConfig, FuncGasCost: funcGasCost})
+	require.Equal(t, ErrNilEnableEpochsHandler, err)
+	require.True(t, check.IfNil(kvs))
+
+	kvs, err = NewSaveKeyValueStorage(ArgsNewSaveKeyValueStorage{
+		GasConfig:           gasConfig,
+		FuncGasCost:         funcGasCost,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+	})
+	require.NoError(t, err)
+	require.False(t, check.IfNil(kvs))
+	require.Equal(t, funcGasCost, kvs.funcGasCost)
+	require.Equal(t, gasConfig, kvs.gasConfig)
+}
+
 func TestSaveKeyValueStorageFunc_SetNewGasConfig(t *testing.T) {
 	t.Parallel()
 
@@ -188,3 +212,111 @@ func TestSaveKeyValue_ProcessBuiltinFunctionMultipleKeys(t *testing.T) {
 	_, err = skv.ProcessBuiltinFunction(acc, acc, vmInput)
 	require.Equal(t, err, ErrNotEnoughGas)
 }
+
+func TestSaveKeyValue_EstimateGasShouldMatchConsumedGasForNewKeys(t *testing.T) {
+	t.Parallel()
+
+	gasConfig := vmcommon.BaseOperationCost{
+		StorePerByte:   3,
+		PersistPerByte: 2,
+	}
+	skv, _ := NewSaveKeyValueStorageFunc(gasConfig, 5)
+
+	gas, err := skv.EstimateGas(nil)
+	require.Equal(t, ErrNilVmInput, err)
+	require.Zero(t, gas)
+
+	addr := []byte("addr")
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  addr,
+			GasProvided: 1000,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte("key")},
+		},
+		RecipientAddr: addr,
+	}
+	gas, err = skv.EstimateGas(vmInput)
+	require.Equal(t, ErrInvalidArguments, err)
+	require.Zero(t, gas)
+
+	vmInput.Arguments = [][]byte{[]byte("key1"), []byte("value1"), []byte("key2"), []byte("value22")}
+	gas, err = skv.EstimateGas(vmInput)
+	require.Nil(t, err)
+
+	acc := mock.NewUserAccount(addr)
+	vmOutput, err := skv.ProcessBuiltinFunction(acc, acc, vmInput)
+	require.Nil(t, err)
+	require.Equal(t, vmInput.GasProvided-vmOutput.GasRemaining, gas)
+}
+
+func TestSaveKeyValue_ProcessBuiltinFunctionShouldRecordGasTrace(t *testing.T) {
+	t.Parallel()
+
+	gasConfig := vmcommon.BaseOperationCost{
+		StorePerByte:   3,
+		PersistPerByte: 2,
+	}
+	skv, _ := NewSaveKeyValueStorageFunc(gasConfig, 5)
+
+	addr := []byte("addr")
+	acc := mock.NewUserAccount(addr)
+	gasTrace := vmcommon.NewGasTrace()
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  addr,
+			GasProvided: 30,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte("key"), []byte("value")},
+			GasTrace:    gasTrace,
+		},
+		RecipientAddr: addr,
+	}
+
+	_, err := skv.ProcessBuiltinFunction(acc, acc, vmInput)
+	require.Equal(t, ErrNotEnoughGas, err)
+	expectedEntries := []vmcommon.GasTraceEntry{
+		{Label: gasTraceFunctionCost, Units: 5, Total: 5},
+		{Label: gasTracePersistPerByte, Units: 16, Total: 21},
+		{Label: gasTraceStorePerByte, Units: 15, Total: 36},
+	}
+	require.Equal(t, expectedEntries, gasTrace.Entries())
+}
+
+func TestSaveKeyValue_ProcessBuiltinFunctionLogs(t *testing.T) {
+	t.Parallel()
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{IsBuiltInFunctionsLogsFlagEnabledField: true}
+	skv, _ := NewSaveKeyValueStorage(ArgsNewSaveKeyValueStorage{FuncGasCost: 1, EnableEpochsHandler: enableEpochsHandler})
+
+	addr := []byte("addr")
+	acc := mock.NewUserAccount(addr)
+	_ = acc.AccountDataHandler().SaveKeyValue([]byte("unchanged"), []byte("value"))
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  addr,
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte("key"), []byte("value"), []byte("unchanged"), []byte("value")},
+		},
+		RecipientAddr: addr,
+	}
+
+	vmOutput, err := skv.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Nil(t, err)
+	expectedLog := (&events.SaveKeyValue{
+		Account:       addr,
+		KeyValuePairs: []events.KeyValuePair{{Key: []byte("key"), Value: []byte("value")}},
+	}).LogEntry()
+	require.Equal(t, []*vmcommon.LogEntry{expectedLog}, vmOutput.Logs)
+
+	vmOutput, err = skv.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Nil(t, err)
+	require.Empty(t, vmOutput.Logs)
+
+	enableEpochsHandler.IsBuiltInFunctionsLogsFlagEnabledField = false
+	vmInput.Arguments = [][]byte{[]byte("key"), []byte("new value")}
+	vmOutput, err = skv.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Nil(t, err)
+	require.Empty(t, vmOutput.Logs)
+}
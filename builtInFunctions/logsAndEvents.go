@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
-	"strconv"
 
+	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
 )
 
@@ -14,35 +14,6 @@ const (
 	dctRandomSequenceLength = 6
 )
 
-func addDCTEntryInVMOutput(vmOutput *vmcommon.VMOutput, identifier []byte, tokenID []byte, nonce uint64, value *big.Int, args ...[]byte) {
-	entry := newEntryForDCT(identifier, tokenID, nonce, value, args...)
-
-	if vmOutput.Logs == nil {
-		vmOutput.Logs = make([]*vmcommon.LogEntry, 0, 1)
-	}
-
-	vmOutput.Logs = append(vmOutput.Logs, entry)
-}
-
-func newEntryForDCT(identifier, tokenID []byte, nonce uint64, value *big.Int, args ...[]byte) *vmcommon.LogEntry {
-	nonceBig := big.NewInt(0).SetUint64(nonce)
-
-	logEntry := &vmcommon.LogEntry{
-		Identifier: identifier,
-		Topics:     [][]byte{tokenID, nonceBig.Bytes(), value.Bytes()},
-	}
-
-	if len(args) > 0 {
-		logEntry.Address = args[0]
-	}
-
-	if len(args) > 1 {
-		logEntry.Topics = append(logEntry.Topics, args[1:]...)
-	}
-
-	return logEntry
-}
-
 func extractTokenIdentifierAndNonceDCTWipe(args []byte) ([]byte, uint64) {
 	argsSplit := bytes.Split(args, []byte(dctIdentifierSeparator))
 	if len(argsSplit) < 2 {
@@ -59,6 +30,6 @@ func extractTokenIdentifierAndNonceDCTWipe(args []byte) ([]byte, uint64) {
 	return identifier, nonce.Uint64()
 }
 
-func boolToSlice(b bool) []byte {
-	return []byte(strconv.FormatBool(b))
+func isBuiltInFunctionsLogsFlagEnabled(enableEpochsHandler vmcommon.EnableEpochsHandler) bool {
+	return !check.IfNil(enableEpochsHandler) && enableEpochsHandler.IsFlagEnabled(vmcommon.BuiltInFunctionsLogsFlag)
 }
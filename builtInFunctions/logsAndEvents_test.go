@@ -7,6 +7,7 @@ import (
 
 	"github.com/kalyan3104/k-core/core"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,7 +15,13 @@ func TestNewEntryForNFT(t *testing.T) {
 	t.Parallel()
 
 	vmOutput := &vmcommon.VMOutput{}
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTCreate), []byte("my-token"), 5, big.NewInt(1), []byte("caller"), []byte("receiver"))
+	events.AddToVMOutput(vmOutput, &events.DCTNFTCreate{
+		Creator:   []byte("caller"),
+		TokenID:   []byte("my-token"),
+		Nonce:     5,
+		Quantity:  big.NewInt(1),
+		TokenData: []byte("receiver"),
+	})
 	require.Equal(t, &vmcommon.LogEntry{
 		Identifier: []byte(core.BuiltInFunctionDCTNFTCreate),
 		Address:    []byte("caller"),
@@ -11,6 +11,7 @@ import (
 	"github.com/kalyan3104/k-core/core/check"
 	"github.com/kalyan3104/k-core/data/dct"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type dctNFTMultiTransfer struct {
@@ -80,7 +81,7 @@ func NewDCTNFTMultiTransferFunc(
 		enableEpochsHandler:   enableEpochsHandler,
 	}
 
-	e.baseActiveHandler.activeHandler = e.enableEpochsHandler.IsDCTNFTImprovementV1FlagEnabled
+	e.baseActiveHandler.activeHandler = flagActiveHandler(e.enableEpochsHandler, vmcommon.DCTNFTImprovementV1Flag)
 
 	return e, nil
 }
@@ -204,7 +205,13 @@ func (e *dctNFTMultiTransfer) ProcessBuiltinFunction(
 			}
 		}
 
-		addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionMultiDCTNFTTransfer), tokenID, nonce, value, vmInput.CallerAddr, acntDst.AddressBytes())
+		events.AddToVMOutput(vmOutput, &events.MultiDCTNFTTransfer{
+			Sender:   vmInput.CallerAddr,
+			Receiver: acntDst.AddressBytes(),
+			TokenID:  tokenID,
+			Nonce:    nonce,
+			Value:    value,
+		})
 	}
 
 	// no need to consume gas on destination - sender already paid for it
@@ -238,7 +245,7 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 	if bytes.Equal(dstAddress, vmInput.CallerAddr) {
 		return nil, fmt.Errorf("%w, can not transfer to self", ErrInvalidArguments)
 	}
-	isTransferToMetaFlagEnabled := e.enableEpochsHandler.IsTransferToMetaFlagEnabled()
+	isTransferToMetaFlagEnabled := e.enableEpochsHandler.IsFlagEnabled(vmcommon.TransferToMetaFlag)
 	isInvalidTransferToMeta := e.shardCoordinator.ComputeId(dstAddress) == core.MetachainShardId && !isTransferToMetaFlagEnabled
 	if isInvalidTransferToMeta {
 		return nil, ErrInvalidRcvAddr
@@ -253,6 +260,7 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 	}
 
 	multiTransferCost := numOfTransfers * e.funcGasCost
+	vmInput.GasTrace.Charge(gasTraceFunctionCost, multiTransferCost)
 	if vmInput.GasProvided < multiTransferCost {
 		return nil, ErrNotEnoughGas
 	}
@@ -301,7 +309,13 @@ func (e *dctNFTMultiTransfer) processDCTNFTMultiTransferOnSenderShard(
 			return nil, fmt.Errorf("%w for token %s", err, string(listTransferData[i].DCTTokenName))
 		}
 
-		addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionMultiDCTNFTTransfer), listTransferData[i].DCTTokenName, listTransferData[i].DCTTokenNonce, listTransferData[i].DCTValue, vmInput.CallerAddr, dstAddress)
+		events.AddToVMOutput(vmOutput, &events.MultiDCTNFTTransfer{
+			Sender:   vmInput.CallerAddr,
+			Receiver: dstAddress,
+			TokenID:  listTransferData[i].DCTTokenName,
+			Nonce:    listTransferData[i].DCTTokenNonce,
+			Value:    listTransferData[i].DCTValue,
+		})
 	}
 
 	if !check.IfNil(acntDst) {
@@ -349,7 +363,7 @@ func (e *dctNFTMultiTransfer) transferOneTokenOnSenderShard(
 	dctData.Value.Set(transferData.DCTValue)
 
 	tokenID := dctTokenKey
-	if e.enableEpochsHandler.IsCheckCorrectTokenIDForTransferRoleFlagEnabled() {
+	if e.enableEpochsHandler.IsFlagEnabled(vmcommon.CheckCorrectTokenIDForTransferRoleFlag) {
 		tokenID = transferData.DCTTokenName
 	}
 
@@ -433,6 +447,7 @@ func (e *dctNFTMultiTransfer) createDCTNFTOutputTransfers(
 				}
 
 				gasForTransfer := uint64(len(marshaledNFTTransfer)) * e.gasConfig.DataCopyPerByte
+				vmInput.GasTrace.Charge(gasTraceDataCopyPerByte, gasForTransfer)
 				if gasForTransfer > vmOutput.GasRemaining {
 					return ErrNotEnoughGas
 				}
@@ -530,6 +545,101 @@ func (e *dctNFTMultiTransfer) addNFTToDestination(
 	return nil
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input. When the destination is in
+// another shard, the copy of each transferred NFT data is charged by the length of its token identifier, nonce and
+// quantity arguments, as the metadata stored by the sender is not read
+func (e *dctNFTMultiTransfer) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 4 {
+		return 0, ErrInvalidArguments
+	}
+	numOfTransfers := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	if numOfTransfers == 0 {
+		return 0, fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+	}
+	startIndex := uint64(2)
+	err := checkNumOfArgumentsForTransfers(numOfTransfers, startIndex, len(vmInput.Arguments))
+	if err != nil {
+		return 0, err
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	estimate := newGasEstimate()
+	estimate.add(e.funcGasCost, numOfTransfers)
+	if e.shardCoordinator.SelfId() == e.shardCoordinator.ComputeId(vmInput.Arguments[0]) {
+		return estimate.value()
+	}
+
+	for i := uint64(0); i < numOfTransfers; i++ {
+		tokenStartIndex := startIndex + i*argumentsPerTransfer
+		transferArguments := vmInput.Arguments[tokenStartIndex : tokenStartIndex+argumentsPerTransfer]
+		if big.NewInt(0).SetBytes(transferArguments[1]).Uint64() == 0 {
+			continue
+		}
+
+		estimate.add(e.gasConfig.DataCopyPerByte, computeArgumentsLength(transferArguments))
+	}
+
+	return estimate.value()
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctNFTMultiTransfer) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 4 {
+		return nil, ErrInvalidArguments
+	}
+
+	sender := vmInput.CallerAddr
+	destination := vmInput.Arguments[0]
+	startIndex := uint64(2)
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		sender = nil
+		destination = vmInput.RecipientAddr
+		startIndex = 1
+	}
+
+	numOfTransfers := big.NewInt(0).SetBytes(vmInput.Arguments[startIndex-1]).Uint64()
+	if numOfTransfers == 0 {
+		return nil, fmt.Errorf("%w, 0 tokens to transfer", ErrInvalidArguments)
+	}
+	err := checkNumOfArgumentsForTransfers(numOfTransfers, startIndex, len(vmInput.Arguments))
+	if err != nil {
+		return nil, err
+	}
+
+	b := newReadWriteSetBuilder()
+	for i := uint64(0); i < numOfTransfers; i++ {
+		tokenStartIndex := startIndex + i*argumentsPerTransfer
+		tokenID := vmInput.Arguments[tokenStartIndex]
+		nonce := big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+1]).Uint64()
+		addTransferAccesses(b, sender, destination, tokenID, nonce)
+	}
+	addFunctionCallAccesses(b, destination, vmInput.Arguments, startIndex+numOfTransfers*argumentsPerTransfer)
+
+	return b.build(), nil
+}
+
+// checkNumOfArgumentsForTransfers checks that the provided number of transfers, starting from startIndex, fits into the
+// arguments. The number of transfers is checked against the number of arguments before it gets multiplied, so it can
+// not overflow
+func checkNumOfArgumentsForTransfers(numOfTransfers uint64, startIndex uint64, numOfArguments int) error {
+	if numOfTransfers > uint64(numOfArguments)/argumentsPerTransfer {
+		return fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+	}
+	if uint64(numOfArguments) < numOfTransfers*argumentsPerTransfer+startIndex {
+		return fmt.Errorf("%w, invalid number of arguments", ErrInvalidArguments)
+	}
+
+	return nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTMultiTransfer) IsInterfaceNil() bool {
 	return e == nil
@@ -1032,3 +1032,35 @@ func TestComputeInsufficientQuantityDCTError(t *testing.T) {
 	require.NotNil(t, resErr)
 	require.Equal(t, errors.New("insufficient quantity for token: my-token-2 nonce 5").Error(), resErr.Error())
 }
+
+func TestDCTNFTMultiTransfer_EstimateGasCrossShardShouldNotReadAccounts(t *testing.T) {
+	t.Parallel()
+
+	multiTransfer := createDCTNFTMultiTransferWithMockArguments(0, 2, &mock.GlobalSettingsHandlerStub{})
+	multiTransfer.accounts = &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			require.Fail(t, "accounts should not be read")
+			return nil, nil
+		},
+	}
+	gasCost := createMockGasCost()
+	multiTransfer.SetNewGasConfig(&gasCost)
+
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:  big.NewInt(0),
+			CallerAddr: bytes.Repeat([]byte{2}, 32),
+			Arguments: [][]byte{
+				bytes.Repeat([]byte{1}, 32), big.NewInt(3).Bytes(),
+				[]byte("token1"), {1}, {1},
+				[]byte("fungible"), {0}, {1, 2},
+				[]byte("token2"), {1}, {1, 2},
+			},
+		},
+	}
+
+	gas, err := multiTransfer.EstimateGas(vmInput)
+	require.Nil(t, err)
+	expectedGas := 3*gasCost.BuiltInCost.DCTNFTMultiTransfer + (8+9)*gasCost.BaseOperationCost.DataCopyPerByte
+	require.Equal(t, expectedGas, gas)
+}
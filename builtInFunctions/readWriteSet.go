@@ -0,0 +1,257 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+// readWriteSetBuilder collects the accessed keys of a built-in function call, keeping the insertion order and
+// dropping the duplicates
+type readWriteSetBuilder struct {
+	readWriteSet *vmcommon.ReadWriteSet
+	reads        map[string]struct{}
+	writes       map[string]struct{}
+}
+
+func newReadWriteSetBuilder() *readWriteSetBuilder {
+	return &readWriteSetBuilder{
+		readWriteSet: &vmcommon.ReadWriteSet{
+			Reads:  make([]vmcommon.AccessedKey, 0),
+			Writes: make([]vmcommon.AccessedKey, 0),
+		},
+		reads:  make(map[string]struct{}),
+		writes: make(map[string]struct{}),
+	}
+}
+
+// read marks the key as read. An empty key stands for the account fields outside the data trie
+func (b *readWriteSetBuilder) read(address []byte, key []byte) *readWriteSetBuilder {
+	b.readWriteSet.Reads = addAccessedKey(b.readWriteSet.Reads, b.reads, address, key, false)
+	return b
+}
+
+// readWrite marks the key as both read and written
+func (b *readWriteSetBuilder) readWrite(address []byte, key []byte) *readWriteSetBuilder {
+	b.readWriteSet.Reads = addAccessedKey(b.readWriteSet.Reads, b.reads, address, key, false)
+	b.readWriteSet.Writes = addAccessedKey(b.readWriteSet.Writes, b.writes, address, key, false)
+	return b
+}
+
+// readWritePrefix marks all the keys starting with the prefix as both read and written
+func (b *readWriteSetBuilder) readWritePrefix(address []byte, prefix []byte) *readWriteSetBuilder {
+	b.readWriteSet.Reads = addAccessedKey(b.readWriteSet.Reads, b.reads, address, prefix, true)
+	b.readWriteSet.Writes = addAccessedKey(b.readWriteSet.Writes, b.writes, address, prefix, true)
+	return b
+}
+
+// readGlobalSettings marks as read the token settings kept in the system account and checked by every transfer
+func (b *readWriteSetBuilder) readGlobalSettings(tokenID []byte, nonce uint64) *readWriteSetBuilder {
+	b.read(vmcommon.SystemAccountAddress, dctTokenKeyFor(tokenID))
+	b.read(vmcommon.SystemAccountAddress, dctNFTTokenKeyFor(tokenID, nonce))
+	b.read(vmcommon.SystemAccountAddress, transferAddressesKeyFor(tokenID))
+	return b
+}
+
+func (b *readWriteSetBuilder) build() *vmcommon.ReadWriteSet {
+	return b.readWriteSet
+}
+
+func addAccessedKey(
+	accessedKeys []vmcommon.AccessedKey,
+	existing map[string]struct{},
+	address []byte,
+	key []byte,
+	isPrefix bool,
+) []vmcommon.AccessedKey {
+	id := fmt.Sprintf("%x:%x:%t", address, key, isPrefix)
+	_, found := existing[id]
+	if found {
+		return accessedKeys
+	}
+	existing[id] = struct{}{}
+
+	return append(accessedKeys, vmcommon.AccessedKey{
+		Address:  cloneBytes(address),
+		Key:      cloneBytes(key),
+		IsPrefix: isPrefix,
+	})
+}
+
+func concatKey(prefix []byte, suffix []byte) []byte {
+	key := make([]byte, 0, len(prefix)+len(suffix))
+	key = append(key, prefix...)
+	return append(key, suffix...)
+}
+
+func dctTokenKeyFor(tokenID []byte) []byte {
+	return concatKey([]byte(baseDCTKeyPrefix), tokenID)
+}
+
+func dctNFTTokenKeyFor(tokenID []byte, nonce uint64) []byte {
+	return computeDCTNFTTokenKey(dctTokenKeyFor(tokenID), nonce)
+}
+
+func roleKeyFor(tokenID []byte) []byte {
+	return concatKey(roleKeyPrefix, tokenID)
+}
+
+func nonceKeyFor(tokenID []byte) []byte {
+	return concatKey(noncePrefix, tokenID)
+}
+
+func transferAddressesKeyFor(tokenID []byte) []byte {
+	return concatKey(transferAddressesKeyPrefix, tokenID)
+}
+
+// GetBuiltInFunctionReadWriteSet returns the account data read and written by the built-in function named in the
+// provided input, as derived by the function itself from the input. The accounts are neither read nor mutated
+func GetBuiltInFunctionReadWriteSet(container vmcommon.BuiltInFunctionContainer, vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if check.IfNil(container) {
+		return nil, ErrNilBuiltInFunctionContainer
+	}
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+
+	builtInFunc, err := container.Get(vmInput.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, ok := UnwrapBuiltInFunction(builtInFunc).(vmcommon.BuiltInFunctionReadWriteSetProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w for function %s", ErrReadWriteSetNotSupported, vmInput.Function)
+	}
+
+	return provider.ReadWriteSet(vmInput)
+}
+
+// GroupIndependentInputs splits a batch of inputs into groups which do not conflict with each other, so that the
+// groups can be processed independently. Each group holds the indexes of its inputs in ascending order, which is the
+// order they have to be processed in, and the groups are sorted by their first index. An input whose read/write set
+// can not be derived (not a built-in function, no read/write set support or invalid arguments) is conservatively
+// considered as conflicting with all the other inputs
+func GroupIndependentInputs(container vmcommon.BuiltInFunctionContainer, inputs []*vmcommon.ContractCallInput) ([][]int, error) {
+	if check.IfNil(container) {
+		return nil, ErrNilBuiltInFunctionContainer
+	}
+
+	readWriteSets := make([]*vmcommon.ReadWriteSet, len(inputs))
+	for i, input := range inputs {
+		if input == nil {
+			return nil, fmt.Errorf("%w at index %d", ErrNilVmInput, i)
+		}
+
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, input)
+		if err != nil {
+			log.Trace("GroupIndependentInputs: unknown read/write set", "index", i, "function", input.Function, "error", err)
+			continue
+		}
+		readWriteSets[i] = readWriteSet
+	}
+
+	parents := make([]int, len(inputs))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	for i := 0; i < len(inputs); i++ {
+		for j := i + 1; j < len(inputs); j++ {
+			if inputsConflict(readWriteSets[i], readWriteSets[j]) {
+				unionGroups(parents, i, j)
+			}
+		}
+	}
+
+	groupsByRoot := make(map[int][]int)
+	for i := range inputs {
+		root := findGroup(parents, i)
+		groupsByRoot[root] = append(groupsByRoot[root], i)
+	}
+
+	groups := make([][]int, 0, len(groupsByRoot))
+	for _, group := range groupsByRoot {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i][0] < groups[j][0]
+	})
+
+	return groups, nil
+}
+
+func inputsConflict(first *vmcommon.ReadWriteSet, second *vmcommon.ReadWriteSet) bool {
+	if first == nil || second == nil {
+		return true
+	}
+
+	return first.ConflictsWith(second)
+}
+
+func findGroup(parents []int, index int) int {
+	for parents[index] != index {
+		parents[index] = parents[parents[index]]
+		index = parents[index]
+	}
+
+	return index
+}
+
+func unionGroups(parents []int, first int, second int) {
+	firstRoot := findGroup(parents, first)
+	secondRoot := findGroup(parents, second)
+	if firstRoot == secondRoot {
+		return
+	}
+
+	if firstRoot < secondRoot {
+		parents[secondRoot] = firstRoot
+		return
+	}
+	parents[firstRoot] = secondRoot
+}
+
+// addTransferAccesses adds the accesses of moving a token between two accounts. An empty sender stands for the
+// destination shard execution of a cross shard transfer
+func addTransferAccesses(b *readWriteSetBuilder, sender []byte, destination []byte, tokenID []byte, nonce uint64) {
+	tokenKey := dctNFTTokenKeyFor(tokenID, nonce)
+	for _, address := range [][]byte{sender, destination} {
+		if len(address) == 0 {
+			continue
+		}
+
+		b.readWrite(address, tokenKey)
+		b.read(address, dctTokenKeyFor(tokenID))
+		b.read(address, roleKeyFor(tokenID))
+	}
+	b.read(destination, nil)
+
+	if nonce > 0 {
+		b.readWrite(vmcommon.SystemAccountAddress, tokenKey)
+	}
+	b.readGlobalSettings(tokenID, nonce)
+}
+
+// addFunctionCallAccesses adds the accesses of the smart contract call following the transfer arguments. The called
+// contract can read and write any data of the destination account, so the whole account is marked as written
+func addFunctionCallAccesses(b *readWriteSetBuilder, destination []byte, arguments [][]byte, numTransferArguments uint64) {
+	if uint64(len(arguments)) <= numTransferArguments {
+		return
+	}
+
+	b.readWrite(destination, nil)
+	b.readWritePrefix(destination, nil)
+}
+
+// addNFTSaveAccesses adds the accesses of saving a token held by the account, together with its metadata and
+// liquidity kept in the system account
+func addNFTSaveAccesses(b *readWriteSetBuilder, address []byte, tokenID []byte, nonce uint64) {
+	tokenKey := dctNFTTokenKeyFor(tokenID, nonce)
+	b.readWrite(address, tokenKey)
+	b.read(address, dctTokenKeyFor(tokenID))
+	b.readWrite(vmcommon.SystemAccountAddress, tokenKey)
+	b.readGlobalSettings(tokenID, nonce)
+}
@@ -0,0 +1,284 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createContainerForReadWriteSet(t *testing.T, recordStorageUpdates bool) vmcommon.BuiltInFunctionContainer {
+	args := createMockArguments()
+	args.RecordStorageUpdates = recordStorageUpdates
+	creator, err := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, err)
+	err = creator.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	return creator.BuiltInFunctionContainer()
+}
+
+func createReadWriteSetInput(function string, caller []byte, recipient []byte, arguments ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: caller,
+			CallValue:  big.NewInt(0),
+			Arguments:  arguments,
+		},
+		RecipientAddr: recipient,
+		Function:      function,
+	}
+}
+
+func TestGetBuiltInFunctionReadWriteSet_Errors(t *testing.T) {
+	t.Parallel()
+
+	container := createContainerForReadWriteSet(t, false)
+
+	readWriteSet, err := GetBuiltInFunctionReadWriteSet(nil, &vmcommon.ContractCallInput{})
+	assert.Nil(t, readWriteSet)
+	assert.Equal(t, ErrNilBuiltInFunctionContainer, err)
+
+	readWriteSet, err = GetBuiltInFunctionReadWriteSet(container, nil)
+	assert.Nil(t, readWriteSet)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	readWriteSet, err = GetBuiltInFunctionReadWriteSet(container, &vmcommon.ContractCallInput{Function: "missing"})
+	assert.Nil(t, readWriteSet)
+	assert.True(t, errors.Is(err, ErrInvalidContainerKey))
+
+	readWriteSet, err = GetBuiltInFunctionReadWriteSet(container, &vmcommon.ContractCallInput{Function: core.BuiltInFunctionDCTTransfer})
+	assert.Nil(t, readWriteSet)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+
+	readWriteSet, err = GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+		core.BuiltInFunctionMultiDCTNFTTransfer, []byte("snd"), []byte("snd"),
+		[]byte("dst"), big.NewInt(2).Bytes(), []byte("token"), []byte{1}, []byte{1},
+	))
+	assert.Nil(t, readWriteSet)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+
+	readWriteSet, err = GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+		vmcommon.DCTDeleteMetadata, core.DCTSCAddress, core.DCTSCAddress,
+		[]byte("token"), big.NewInt(5).Bytes(), []byte{1}, []byte{2},
+	))
+	assert.Nil(t, readWriteSet)
+	assert.Equal(t, ErrInvalidNumOfArgs, err)
+}
+
+func TestGetBuiltInFunctionReadWriteSet_AllFunctionsShouldBeSupported(t *testing.T) {
+	t.Parallel()
+
+	for _, recordStorageUpdates := range []bool{false, true} {
+		container := createContainerForReadWriteSet(t, recordStorageUpdates)
+		for name := range container.Keys() {
+			builtInFunc, _ := container.Get(name)
+			_, ok := UnwrapBuiltInFunction(builtInFunc).(vmcommon.BuiltInFunctionReadWriteSetProvider)
+			assert.True(t, ok, name)
+		}
+	}
+}
+
+func TestGetBuiltInFunctionReadWriteSet_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	container := createContainerForReadWriteSet(t, true)
+	sender := []byte("sender")
+	receiver := []byte("receiver")
+	tokenID := []byte("TKN-abcdef")
+	tokenKey := []byte(baseDCTKeyPrefix + string(tokenID))
+	nftKey := computeDCTNFTTokenKey([]byte(baseDCTKeyPrefix+string(tokenID)), 5)
+	roleKey := []byte(core.ProtectedKeyPrefix + core.DCTRoleIdentifier + core.DCTKeyIdentifier + string(tokenID))
+	nonceKey := []byte(core.ProtectedKeyPrefix + core.DCTNFTLatestNonceIdentifier + string(tokenID))
+	transferKey := []byte(core.ProtectedKeyPrefix + transfer + core.DCTKeyIdentifier + string(tokenID))
+	system := vmcommon.SystemAccountAddress
+
+	t.Run("transfer", func(t *testing.T) {
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+			core.BuiltInFunctionDCTTransfer, sender, receiver, tokenID, big.NewInt(10).Bytes()))
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.AccessedKey{
+			{Address: sender, Key: tokenKey},
+			{Address: receiver, Key: tokenKey},
+		}, readWriteSet.Writes)
+		assert.Equal(t, []vmcommon.AccessedKey{
+			{Address: sender, Key: tokenKey},
+			{Address: sender, Key: roleKey},
+			{Address: receiver, Key: tokenKey},
+			{Address: receiver, Key: roleKey},
+			{Address: receiver},
+			{Address: system, Key: tokenKey},
+			{Address: system, Key: transferKey},
+		}, readWriteSet.Reads)
+	})
+	t.Run("transfer with function call", func(t *testing.T) {
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+			core.BuiltInFunctionDCTTransfer, sender, receiver, tokenID, big.NewInt(10).Bytes(), []byte("function")))
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.AccessedKey{
+			{Address: sender, Key: tokenKey},
+			{Address: receiver, Key: tokenKey},
+			{Address: receiver},
+			{Address: receiver, IsPrefix: true},
+		}, readWriteSet.Writes)
+	})
+	t.Run("nft transfer on sender shard", func(t *testing.T) {
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+			core.BuiltInFunctionDCTNFTTransfer, sender, sender, tokenID, big.NewInt(5).Bytes(), big.NewInt(1).Bytes(), receiver))
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.AccessedKey{
+			{Address: sender, Key: nftKey},
+			{Address: receiver, Key: nftKey},
+			{Address: system, Key: nftKey},
+		}, readWriteSet.Writes)
+	})
+	t.Run("multi transfer on destination shard", func(t *testing.T) {
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+			core.BuiltInFunctionMultiDCTNFTTransfer, sender, receiver,
+			big.NewInt(2).Bytes(),
+			tokenID, big.NewInt(5).Bytes(), big.NewInt(1).Bytes(),
+			tokenID, nil, big.NewInt(1).Bytes(),
+		))
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.AccessedKey{
+			{Address: receiver, Key: nftKey},
+			{Address: system, Key: nftKey},
+			{Address: receiver, Key: tokenKey},
+		}, readWriteSet.Writes)
+	})
+	t.Run("pause", func(t *testing.T) {
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+			core.BuiltInFunctionDCTPause, core.DCTSCAddress, system, tokenID))
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.AccessedKey{{Address: system, Key: tokenKey}}, readWriteSet.Writes)
+		assert.Equal(t, readWriteSet.Writes, readWriteSet.Reads)
+	})
+	t.Run("set role with create multi shard", func(t *testing.T) {
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+			core.BuiltInFunctionSetDCTRole, core.DCTSCAddress, receiver, tokenID, []byte(core.DCTRoleNFTCreateMultiShard)))
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.AccessedKey{
+			{Address: receiver, Key: roleKey},
+			{Address: receiver, Key: nonceKey},
+		}, readWriteSet.Writes)
+	})
+	t.Run("nft create", func(t *testing.T) {
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+			core.BuiltInFunctionDCTNFTCreate, sender, sender,
+			tokenID, []byte{1}, []byte("name"), []byte{10}, []byte("hash"), []byte("attributes"), []byte("uri")))
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.AccessedKey{
+			{Address: sender, Key: nonceKey},
+			{Address: sender, Key: tokenKey, IsPrefix: true},
+			{Address: system, Key: tokenKey, IsPrefix: true},
+		}, readWriteSet.Writes)
+	})
+	t.Run("wipe", func(t *testing.T) {
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+			core.BuiltInFunctionDCTWipe, core.DCTSCAddress, receiver, tokenID))
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.AccessedKey{
+			{Address: receiver, Key: tokenKey},
+			{Address: system, Key: tokenKey},
+		}, readWriteSet.Writes)
+	})
+	t.Run("transfer role add address", func(t *testing.T) {
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+			vmcommon.BuiltInFunctionDCTTransferRoleAddAddress, core.DCTSCAddress, system, tokenID, sender))
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.AccessedKey{{Address: system, Key: transferKey}}, readWriteSet.Writes)
+	})
+	t.Run("save key value", func(t *testing.T) {
+		readWriteSet, err := GetBuiltInFunctionReadWriteSet(container, createReadWriteSetInput(
+			core.BuiltInFunctionSaveKeyValue, sender, sender, []byte("key1"), []byte("value"), []byte("key2"), nil))
+		require.Nil(t, err)
+		assert.Equal(t, []vmcommon.AccessedKey{
+			{Address: sender, Key: []byte("key1")},
+			{Address: sender, Key: []byte("key2")},
+		}, readWriteSet.Writes)
+	})
+}
+
+func TestGroupIndependentInputs(t *testing.T) {
+	t.Parallel()
+
+	container := createContainerForReadWriteSet(t, false)
+	transfer := func(sender string, receiver string, token string) *vmcommon.ContractCallInput {
+		return createReadWriteSetInput(core.BuiltInFunctionDCTTransfer, []byte(sender), []byte(receiver), []byte(token), big.NewInt(1).Bytes())
+	}
+	pause := func(token string) *vmcommon.ContractCallInput {
+		return createReadWriteSetInput(core.BuiltInFunctionDCTPause, core.DCTSCAddress, vmcommon.SystemAccountAddress, []byte(token))
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		groups, err := GroupIndependentInputs(nil, nil)
+		assert.Nil(t, groups)
+		assert.Equal(t, ErrNilBuiltInFunctionContainer, err)
+
+		groups, err = GroupIndependentInputs(container, []*vmcommon.ContractCallInput{transfer("a", "b", "TKN"), nil})
+		assert.Nil(t, groups)
+		assert.True(t, errors.Is(err, ErrNilVmInput))
+	})
+	t.Run("empty batch", func(t *testing.T) {
+		groups, err := GroupIndependentInputs(container, nil)
+		require.Nil(t, err)
+		assert.Empty(t, groups)
+	})
+	t.Run("independent and conflicting inputs", func(t *testing.T) {
+		groups, err := GroupIndependentInputs(container, []*vmcommon.ContractCallInput{
+			transfer("a", "b", "TKN"),
+			transfer("c", "d", "TKN"),
+			transfer("b", "e", "TKN"),
+			pause("OTHER"),
+			transfer("f", "g", "OTHER"),
+			transfer("e", "h", "TKN"),
+		})
+		require.Nil(t, err)
+		assert.Equal(t, [][]int{{0, 2, 5}, {1}, {3, 4}}, groups)
+	})
+	t.Run("transfers with function call to the same contract conflict", func(t *testing.T) {
+		transferAndCall := func(sender string, receiver string, token string) *vmcommon.ContractCallInput {
+			input := transfer(sender, receiver, token)
+			input.Arguments = append(input.Arguments, []byte("function"), []byte("argument"))
+			return input
+		}
+		nftTransferAndCall := func(sender string, receiver string, token string) *vmcommon.ContractCallInput {
+			return createReadWriteSetInput(core.BuiltInFunctionDCTNFTTransfer, []byte(sender), []byte(sender),
+				[]byte(token), big.NewInt(1).Bytes(), big.NewInt(1).Bytes(), []byte(receiver), []byte("function"))
+		}
+		multiTransferAndCall := func(sender string, receiver string, token string) *vmcommon.ContractCallInput {
+			return createReadWriteSetInput(core.BuiltInFunctionMultiDCTNFTTransfer, []byte(sender), []byte(sender),
+				[]byte(receiver), big.NewInt(1).Bytes(), []byte(token), big.NewInt(1).Bytes(), big.NewInt(1).Bytes(), []byte("function"))
+		}
+
+		groups, err := GroupIndependentInputs(container, []*vmcommon.ContractCallInput{
+			transferAndCall("a", "sc", "TKN"),
+			transferAndCall("b", "sc", "OTHER"),
+			nftTransferAndCall("c", "sc", "NFT"),
+			multiTransferAndCall("d", "sc", "SFT"),
+			transfer("e", "sc", "THIRD"),
+		})
+		require.Nil(t, err)
+		assert.Equal(t, [][]int{{0, 1, 2, 3, 4}}, groups)
+
+		groups, err = GroupIndependentInputs(container, []*vmcommon.ContractCallInput{
+			transfer("a", "sc", "TKN"),
+			transfer("b", "sc", "OTHER"),
+		})
+		require.Nil(t, err)
+		assert.Equal(t, [][]int{{0}, {1}}, groups)
+	})
+	t.Run("unknown read write set conflicts with all", func(t *testing.T) {
+		groups, err := GroupIndependentInputs(container, []*vmcommon.ContractCallInput{
+			transfer("a", "b", "TKN"),
+			transfer("c", "d", "TKN"),
+			{Function: "missing"},
+		})
+		require.Nil(t, err)
+		assert.Equal(t, [][]int{{0, 1, 2}}, groups)
+	})
+}
@@ -0,0 +1,354 @@
+package builtInFunctions
+
+import (
+	"github.com/kalyan3104/k-core/core"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var tokenIdentifierArg = ArgumentDescriptor{Name: "tokenIdentifier", Type: ArgTypeTokenIdentifier}
+var nonceArg = ArgumentDescriptor{Name: "nonce", Type: ArgTypeNonce}
+var valueArg = ArgumentDescriptor{Name: "value", Type: ArgTypeBigUint}
+var quantityArg = ArgumentDescriptor{Name: "quantity", Type: ArgTypeBigUint}
+var functionArg = ArgumentDescriptor{Name: "function", Type: ArgTypeBytes, Optional: true}
+var functionArgumentsArg = ArgumentDescriptor{Name: "arguments", Type: ArgTypeBytes, Variadic: true}
+
+// protocolBuiltInFunctions returns the descriptors of all the protocol built-in functions. The DCTPause and
+// SetDCTRole functions are shared with the other built-in functions, so the creator builds them beforehand
+func protocolBuiltInFunctions() []BuiltInFunctionDescriptor {
+	return []BuiltInFunctionDescriptor{
+		{
+			Name: core.BuiltInFunctionClaimDeveloperRewards,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewClaimDeveloperRewards(ArgsNewClaimDeveloperRewards{
+					GasCost:             args.FuncGasCost,
+					EnableEpochsHandler: args.EnableEpochsHandler,
+				})
+			},
+			GasCost: func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.ClaimDeveloperRewards },
+		},
+		{
+			Name: core.BuiltInFunctionChangeOwnerAddress,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewChangeOwnerAddress(ArgsNewChangeOwnerAddress{
+					GasCost:             args.FuncGasCost,
+					EnableEpochsHandler: args.EnableEpochsHandler,
+				})
+			},
+			GasCost:   func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.ChangeOwnerAddress },
+			Arguments: []ArgumentDescriptor{{Name: "newOwner", Type: ArgTypeAddress}},
+		},
+		{
+			Name: core.BuiltInFunctionSetUserName,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewSaveUserName(ArgsNewSaveUserName{
+					GasCost:             args.FuncGasCost,
+					MapDnsAddresses:     args.MapDNSAddresses,
+					EnableChange:        args.EnableUserNameChange,
+					EnableEpochsHandler: args.EnableEpochsHandler,
+				})
+			},
+			GasCost:   func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.SaveUserName },
+			Arguments: []ArgumentDescriptor{{Name: "userName", Type: ArgTypeBytes}},
+		},
+		{
+			Name: core.BuiltInFunctionSaveKeyValue,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewSaveKeyValueStorage(ArgsNewSaveKeyValueStorage{
+					GasConfig:           args.GasConfig.BaseOperationCost,
+					FuncGasCost:         args.FuncGasCost,
+					EnableEpochsHandler: args.EnableEpochsHandler,
+				})
+			},
+			GasCost:   func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.SaveKeyValue },
+			Arguments: []ArgumentDescriptor{{Name: "keyValuePairs", Type: ArgTypeBytes, Variadic: true}},
+		},
+		{
+			Name: core.BuiltInFunctionDCTPause,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return sharedBuiltInFunction(args.GlobalSettingsHandler)
+			},
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg},
+		},
+		{
+			Name: core.BuiltInFunctionSetDCTRole,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return sharedBuiltInFunction(args.RolesHandler)
+			},
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg, {Name: "roles", Type: ArgTypeRole, Variadic: true}},
+		},
+		{
+			Name: core.BuiltInFunctionDCTTransfer,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTTransferFunc(args.FuncGasCost, args.Marshalizer, args.GlobalSettingsHandler, args.ShardCoordinator, args.RolesHandler, args.EnableEpochsHandler)
+			},
+			GasCost:   func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTTransfer },
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg, valueArg, functionArg, functionArgumentsArg},
+		},
+		{
+			Name: core.BuiltInFunctionDCTBurn,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTBurnFunc(args.FuncGasCost, args.Marshalizer, args.GlobalSettingsHandler, args.EnableEpochsHandler)
+			},
+			GasCost:        func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTBurn },
+			ActivationFlag: vmcommon.GlobalMintBurnFlag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg, valueArg},
+		},
+		{
+			Name: core.BuiltInFunctionDCTUnPause,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTGlobalSettings(ArgsNewDCTGlobalSettings{
+					Accounts:            args.Accounts,
+					Marshaller:          args.Marshalizer,
+					Set:                 false,
+					Function:            core.BuiltInFunctionDCTUnPause,
+					ActiveHandler:       args.ActiveHandler,
+					EnableEpochsHandler: args.EnableEpochsHandler,
+				})
+			},
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg},
+		},
+		{
+			Name: core.BuiltInFunctionUnSetDCTRole,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTRolesFunc(args.Marshalizer, false)
+			},
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg, {Name: "roles", Type: ArgTypeRole, Variadic: true}},
+		},
+		{
+			Name: core.BuiltInFunctionDCTLocalBurn,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTLocalBurnFunc(args.FuncGasCost, args.Marshalizer, args.GlobalSettingsHandler, args.RolesHandler)
+			},
+			GasCost:   func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTLocalBurn },
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg, valueArg},
+		},
+		{
+			Name: core.BuiltInFunctionDCTLocalMint,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTLocalMintFunc(args.FuncGasCost, args.Marshalizer, args.GlobalSettingsHandler, args.RolesHandler)
+			},
+			GasCost:   func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTLocalMint },
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg, valueArg},
+		},
+		{
+			Name: core.BuiltInFunctionDCTNFTAddQuantity,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTAddQuantityFunc(args.FuncGasCost, args.DCTStorageHandler, args.GlobalSettingsHandler, args.RolesHandler, args.EnableEpochsHandler)
+			},
+			GasCost:   func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTNFTAddQuantity },
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg, nonceArg, quantityArg},
+		},
+		{
+			Name: core.BuiltInFunctionDCTNFTBurn,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTBurnFunc(args.FuncGasCost, args.DCTStorageHandler, args.GlobalSettingsHandler, args.RolesHandler)
+			},
+			GasCost:   func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTNFTBurn },
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg, nonceArg, quantityArg},
+		},
+		{
+			Name: core.BuiltInFunctionDCTNFTCreate,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTCreateFunc(args.FuncGasCost, args.GasConfig.BaseOperationCost, args.Marshalizer, args.GlobalSettingsHandler, args.RolesHandler, args.DCTStorageHandler, args.Accounts, args.EnableEpochsHandler)
+			},
+			GasCost: func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTNFTCreate },
+			Arguments: []ArgumentDescriptor{
+				tokenIdentifierArg,
+				quantityArg,
+				{Name: "name", Type: ArgTypeBytes},
+				{Name: "royalties", Type: ArgTypeBigUint},
+				{Name: "hash", Type: ArgTypeBytes},
+				{Name: "attributes", Type: ArgTypeBytes},
+				{Name: "uris", Type: ArgTypeBytes, Variadic: true},
+			},
+		},
+		{
+			Name: core.BuiltInFunctionDCTFreeze,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTFreezeWipeFunc(args.DCTStorageHandler, args.EnableEpochsHandler, args.Marshalizer, true, false)
+			},
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg},
+		},
+		{
+			Name: core.BuiltInFunctionDCTUnFreeze,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTFreezeWipeFunc(args.DCTStorageHandler, args.EnableEpochsHandler, args.Marshalizer, false, false)
+			},
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg},
+		},
+		{
+			Name: core.BuiltInFunctionDCTWipe,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTFreezeWipeFunc(args.DCTStorageHandler, args.EnableEpochsHandler, args.Marshalizer, false, true)
+			},
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg},
+		},
+		{
+			Name: core.BuiltInFunctionDCTNFTTransfer,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTTransferFunc(args.FuncGasCost, args.Marshalizer, args.GlobalSettingsHandler, args.Accounts, args.ShardCoordinator, args.GasConfig.BaseOperationCost, args.RolesHandler, args.DCTStorageHandler, args.EnableEpochsHandler)
+			},
+			GasCost: func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTNFTTransfer },
+			Arguments: []ArgumentDescriptor{
+				tokenIdentifierArg,
+				nonceArg,
+				quantityArg,
+				{Name: "destination", Type: ArgTypeAddress},
+				functionArg,
+				functionArgumentsArg,
+			},
+		},
+		{
+			Name: core.BuiltInFunctionDCTNFTCreateRoleTransfer,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTCreateRoleTransfer(args.Marshalizer, args.Accounts, args.ShardCoordinator)
+			},
+			Arguments: []ArgumentDescriptor{tokenIdentifierArg, {Name: "arguments", Type: ArgTypeBytes, Variadic: true}},
+		},
+		{
+			Name: core.BuiltInFunctionDCTNFTUpdateAttributes,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTUpdateAttributesFunc(args.FuncGasCost, args.GasConfig.BaseOperationCost, args.DCTStorageHandler, args.GlobalSettingsHandler, args.RolesHandler, args.EnableEpochsHandler)
+			},
+			GasCost:        func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTNFTUpdateAttributes },
+			ActivationFlag: vmcommon.DCTNFTImprovementV1Flag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg, nonceArg, {Name: "attributes", Type: ArgTypeBytes}},
+		},
+		{
+			Name: core.BuiltInFunctionDCTNFTAddURI,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTAddUriFunc(args.FuncGasCost, args.GasConfig.BaseOperationCost, args.DCTStorageHandler, args.GlobalSettingsHandler, args.RolesHandler, args.EnableEpochsHandler)
+			},
+			GasCost:        func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTNFTAddURI },
+			ActivationFlag: vmcommon.DCTNFTImprovementV1Flag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg, nonceArg, {Name: "uris", Type: ArgTypeBytes, Variadic: true}},
+		},
+		{
+			Name: core.BuiltInFunctionMultiDCTNFTTransfer,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTNFTMultiTransferFunc(args.FuncGasCost, args.Marshalizer, args.GlobalSettingsHandler, args.Accounts, args.ShardCoordinator, args.GasConfig.BaseOperationCost, args.EnableEpochsHandler, args.RolesHandler, args.DCTStorageHandler)
+			},
+			GasCost:        func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTNFTMultiTransfer },
+			ActivationFlag: vmcommon.DCTNFTImprovementV1Flag,
+			Arguments: []ArgumentDescriptor{
+				{Name: "destination", Type: ArgTypeAddress},
+				{Name: "numOfTransfers", Type: ArgTypeBigUint},
+				{Name: "transfers", Type: ArgTypeBytes, Variadic: true},
+			},
+		},
+		{
+			Name: core.BuiltInFunctionDCTSetLimitedTransfer,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTGlobalSettings(ArgsNewDCTGlobalSettings{
+					Accounts:            args.Accounts,
+					Marshaller:          args.Marshalizer,
+					Set:                 true,
+					Function:            core.BuiltInFunctionDCTSetLimitedTransfer,
+					ActiveHandler:       args.ActiveHandler,
+					EnableEpochsHandler: args.EnableEpochsHandler,
+				})
+			},
+			ActivationFlag: vmcommon.DCTTransferRoleFlag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg},
+		},
+		{
+			Name: core.BuiltInFunctionDCTUnSetLimitedTransfer,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTGlobalSettings(ArgsNewDCTGlobalSettings{
+					Accounts:            args.Accounts,
+					Marshaller:          args.Marshalizer,
+					Set:                 false,
+					Function:            core.BuiltInFunctionDCTUnSetLimitedTransfer,
+					ActiveHandler:       args.ActiveHandler,
+					EnableEpochsHandler: args.EnableEpochsHandler,
+				})
+			},
+			ActivationFlag: vmcommon.DCTTransferRoleFlag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg},
+		},
+		{
+			Name: vmcommon.DCTDeleteMetadata,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTDeleteMetadataFunc(createArgsNewDCTDeleteMetadata(args, true))
+			},
+			GasCost:        func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTNFTBurn },
+			ActivationFlag: vmcommon.SendAlwaysFlag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg, {Name: "intervals", Type: ArgTypeBytes, Variadic: true}},
+		},
+		{
+			Name: vmcommon.DCTAddMetadata,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTDeleteMetadataFunc(createArgsNewDCTDeleteMetadata(args, false))
+			},
+			GasCost:        func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTNFTBurn },
+			ActivationFlag: vmcommon.SendAlwaysFlag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg, {Name: "intervals", Type: ArgTypeBytes, Variadic: true}},
+		},
+		{
+			Name: vmcommon.BuiltInFunctionDCTSetBurnRoleForAll,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTGlobalSettings(ArgsNewDCTGlobalSettings{
+					Accounts:            args.Accounts,
+					Marshaller:          args.Marshalizer,
+					Set:                 true,
+					Function:            vmcommon.BuiltInFunctionDCTSetBurnRoleForAll,
+					ActiveHandler:       args.ActiveHandler,
+					EnableEpochsHandler: args.EnableEpochsHandler,
+				})
+			},
+			ActivationFlag: vmcommon.SendAlwaysFlag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg},
+		},
+		{
+			Name: vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTGlobalSettings(ArgsNewDCTGlobalSettings{
+					Accounts:            args.Accounts,
+					Marshaller:          args.Marshalizer,
+					Set:                 false,
+					Function:            vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll,
+					ActiveHandler:       args.ActiveHandler,
+					EnableEpochsHandler: args.EnableEpochsHandler,
+				})
+			},
+			ActivationFlag: vmcommon.SendAlwaysFlag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg},
+		},
+		{
+			Name: vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTTransferRoleAddressFunc(args.Accounts, args.Marshalizer, args.MaxNumOfAddressesForTransferRole, false, args.EnableEpochsHandler)
+			},
+			ActivationFlag: vmcommon.SendAlwaysFlag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg, {Name: "addresses", Type: ArgTypeAddress, Variadic: true}},
+		},
+		{
+			Name: vmcommon.BuiltInFunctionDCTTransferRoleAddAddress,
+			Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+				return NewDCTTransferRoleAddressFunc(args.Accounts, args.Marshalizer, args.MaxNumOfAddressesForTransferRole, true, args.EnableEpochsHandler)
+			},
+			ActivationFlag: vmcommon.SendAlwaysFlag,
+			Arguments:      []ArgumentDescriptor{tokenIdentifierArg, {Name: "addresses", Type: ArgTypeAddress, Variadic: true}},
+		},
+	}
+}
+
+func createArgsNewDCTDeleteMetadata(args ArgsNewBuiltInFunction, deleteMetadata bool) ArgsNewDCTDeleteMetadata {
+	return ArgsNewDCTDeleteMetadata{
+		FuncGasCost:         args.FuncGasCost,
+		Marshalizer:         args.Marshalizer,
+		Accounts:            args.Accounts,
+		AllowedAddress:      args.ConfigAddress,
+		Delete:              deleteMetadata,
+		EnableEpochsHandler: args.EnableEpochsHandler,
+	}
+}
+
+func sharedBuiltInFunction(handler interface{}) (vmcommon.BuiltinFunction, error) {
+	builtInFunc, ok := handler.(vmcommon.BuiltinFunction)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return builtInFunc, nil
+}
@@ -0,0 +1,221 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"sync"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ BuiltInFunctionsRegistryHandler = (*builtInFunctionsRegistry)(nil)
+
+// ArgumentType defines the type of a built-in function argument
+type ArgumentType string
+
+const (
+	// ArgTypeTokenIdentifier defines a token identifier argument
+	ArgTypeTokenIdentifier ArgumentType = "tokenIdentifier"
+	// ArgTypeNonce defines a token nonce argument
+	ArgTypeNonce ArgumentType = "nonce"
+	// ArgTypeBigUint defines an unsigned big integer argument, as a value or a quantity
+	ArgTypeBigUint ArgumentType = "bigUint"
+	// ArgTypeAddress defines an address argument
+	ArgTypeAddress ArgumentType = "address"
+	// ArgTypeRole defines a DCT role argument
+	ArgTypeRole ArgumentType = "role"
+	// ArgTypeBytes defines an opaque bytes argument
+	ArgTypeBytes ArgumentType = "bytes"
+)
+
+// ArgumentDescriptor describes one argument of a built-in function. An optional argument can be omitted
+// and a variadic argument can be repeated until the end of the arguments list, so both can only be
+// followed by other optional or variadic arguments
+type ArgumentDescriptor struct {
+	Name     string
+	Type     ArgumentType
+	Optional bool
+	Variadic bool
+}
+
+// ArgsNewBuiltInFunction holds the components a registered built-in function can be created from.
+// FuncGasCost and ActiveHandler are resolved from the gas cost and the activation flag of the descriptor
+type ArgsNewBuiltInFunction struct {
+	FuncGasCost                      uint64
+	ActiveHandler                    func() bool
+	GasConfig                        vmcommon.GasCost
+	Marshalizer                      vmcommon.Marshalizer
+	Accounts                         vmcommon.AccountsAdapter
+	ShardCoordinator                 vmcommon.Coordinator
+	EnableEpochsHandler              vmcommon.EnableEpochsHandler
+	GlobalSettingsHandler            vmcommon.ExtendedDCTGlobalSettingsHandler
+	RolesHandler                     vmcommon.DCTRoleHandler
+	DCTStorageHandler                vmcommon.DCTNFTStorageHandler
+	MapDNSAddresses                  map[string]struct{}
+	EnableUserNameChange             bool
+	MaxNumOfAddressesForTransferRole uint32
+	ConfigAddress                    []byte
+}
+
+// BuiltInFunctionDescriptor declares a built-in function: its name, how it is created, how its cost is read from
+// vmcommon.BuiltInCost (nil if it has none), the flag that activates it (empty if always active) and the schema of
+// its arguments. The created built-in function is active only while the activation flag is enabled
+type BuiltInFunctionDescriptor struct {
+	Name           string
+	Constructor    func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error)
+	GasCost        func(builtInCost vmcommon.BuiltInCost) uint64
+	ActivationFlag vmcommon.EnableEpochFlag
+	Arguments      []ArgumentDescriptor
+}
+
+// MinNumArguments returns the number of arguments that can not be omitted
+func (descriptor BuiltInFunctionDescriptor) MinNumArguments() int {
+	numArgs := 0
+	for _, arg := range descriptor.Arguments {
+		if arg.Optional || arg.Variadic {
+			break
+		}
+		numArgs++
+	}
+
+	return numArgs
+}
+
+// BuiltInFunctionsRegistryHandler defines the registry the built-in functions container is created from
+type BuiltInFunctionsRegistryHandler interface {
+	Register(descriptor BuiltInFunctionDescriptor) error
+	Get(name string) (BuiltInFunctionDescriptor, bool)
+	Descriptors() []BuiltInFunctionDescriptor
+	Names() []string
+	IsInterfaceNil() bool
+}
+
+type builtInFunctionsRegistry struct {
+	mutDescriptors sync.RWMutex
+	descriptors    []BuiltInFunctionDescriptor
+	indexes        map[string]int
+}
+
+// NewBuiltInFunctionsRegistry creates an empty built-in functions registry
+func NewBuiltInFunctionsRegistry() *builtInFunctionsRegistry {
+	return &builtInFunctionsRegistry{
+		descriptors: make([]BuiltInFunctionDescriptor, 0),
+		indexes:     make(map[string]int),
+	}
+}
+
+// NewDefaultBuiltInFunctionsRegistry creates a registry holding all the protocol built-in functions.
+// Additional built-in functions can be registered on the returned registry
+func NewDefaultBuiltInFunctionsRegistry() (*builtInFunctionsRegistry, error) {
+	registry := NewBuiltInFunctionsRegistry()
+	for _, descriptor := range protocolBuiltInFunctions() {
+		err := registry.Register(descriptor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// Register adds a new built-in function descriptor. The name must be unique
+func (registry *builtInFunctionsRegistry) Register(descriptor BuiltInFunctionDescriptor) error {
+	err := checkDescriptor(descriptor)
+	if err != nil {
+		return err
+	}
+
+	registry.mutDescriptors.Lock()
+	defer registry.mutDescriptors.Unlock()
+
+	_, exists := registry.indexes[descriptor.Name]
+	if exists {
+		return fmt.Errorf("%w: %s", ErrBuiltInFunctionAlreadyRegistered, descriptor.Name)
+	}
+
+	registry.indexes[descriptor.Name] = len(registry.descriptors)
+	registry.descriptors = append(registry.descriptors, copyDescriptor(descriptor))
+
+	return nil
+}
+
+func checkDescriptor(descriptor BuiltInFunctionDescriptor) error {
+	if len(descriptor.Name) == 0 {
+		return ErrEmptyFunctionName
+	}
+	if descriptor.Constructor == nil {
+		return fmt.Errorf("%w for %s", ErrNilBuiltInFunctionConstructor, descriptor.Name)
+	}
+
+	canBeOmitted := false
+	for index, arg := range descriptor.Arguments {
+		if arg.Variadic && index != len(descriptor.Arguments)-1 {
+			return fmt.Errorf("%w for %s: variadic argument %s is not the last one", ErrInvalidArgumentsSchema, descriptor.Name, arg.Name)
+		}
+		if canBeOmitted && !arg.Optional && !arg.Variadic {
+			return fmt.Errorf("%w for %s: mandatory argument %s follows an optional one", ErrInvalidArgumentsSchema, descriptor.Name, arg.Name)
+		}
+		canBeOmitted = canBeOmitted || arg.Optional || arg.Variadic
+	}
+
+	return nil
+}
+
+func copyDescriptor(descriptor BuiltInFunctionDescriptor) BuiltInFunctionDescriptor {
+	descriptorCopy := descriptor
+	descriptorCopy.Arguments = make([]ArgumentDescriptor, len(descriptor.Arguments))
+	copy(descriptorCopy.Arguments, descriptor.Arguments)
+
+	return descriptorCopy
+}
+
+// Get returns the descriptor registered for the provided name
+func (registry *builtInFunctionsRegistry) Get(name string) (BuiltInFunctionDescriptor, bool) {
+	registry.mutDescriptors.RLock()
+	defer registry.mutDescriptors.RUnlock()
+
+	index, found := registry.indexes[name]
+	if !found {
+		return BuiltInFunctionDescriptor{}, false
+	}
+
+	return copyDescriptor(registry.descriptors[index]), true
+}
+
+// Descriptors returns all the registered descriptors, in registration order
+func (registry *builtInFunctionsRegistry) Descriptors() []BuiltInFunctionDescriptor {
+	registry.mutDescriptors.RLock()
+	defer registry.mutDescriptors.RUnlock()
+
+	descriptors := make([]BuiltInFunctionDescriptor, 0, len(registry.descriptors))
+	for _, descriptor := range registry.descriptors {
+		descriptors = append(descriptors, copyDescriptor(descriptor))
+	}
+
+	return descriptors
+}
+
+// Names returns the names of all the registered built-in functions, in registration order
+func (registry *builtInFunctionsRegistry) Names() []string {
+	registry.mutDescriptors.RLock()
+	defer registry.mutDescriptors.RUnlock()
+
+	names := make([]string, 0, len(registry.descriptors))
+	for _, descriptor := range registry.descriptors {
+		names = append(names, descriptor.Name)
+	}
+
+	return names
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (registry *builtInFunctionsRegistry) IsInterfaceNil() bool {
+	return registry == nil
+}
+
+func activeHandlerFromFlag(enableEpochsHandler vmcommon.EnableEpochsHandler, flag vmcommon.EnableEpochFlag) func() bool {
+	if len(flag) == 0 {
+		return trueHandler
+	}
+
+	return flagActiveHandler(enableEpochsHandler, flag)
+}
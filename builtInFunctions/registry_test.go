@@ -0,0 +1,109 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDescriptor(name string) BuiltInFunctionDescriptor {
+	return BuiltInFunctionDescriptor{
+		Name: name,
+		Constructor: func(args ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+			return &mock.BuiltInFunctionStub{}, nil
+		},
+	}
+}
+
+func TestBuiltInFunctionsRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	registry := NewBuiltInFunctionsRegistry()
+	assert.False(t, check.IfNil(registry))
+
+	descriptor := createDescriptor("")
+	assert.Equal(t, ErrEmptyFunctionName, registry.Register(descriptor))
+
+	descriptor = createDescriptor("function")
+	descriptor.Constructor = nil
+	assert.True(t, errors.Is(registry.Register(descriptor), ErrNilBuiltInFunctionConstructor))
+
+	descriptor = createDescriptor("function")
+	descriptor.Arguments = []ArgumentDescriptor{{Name: "args", Variadic: true}, {Name: "last"}}
+	assert.True(t, errors.Is(registry.Register(descriptor), ErrInvalidArgumentsSchema))
+
+	descriptor = createDescriptor("function")
+	descriptor.Arguments = []ArgumentDescriptor{{Name: "optional", Optional: true}, {Name: "mandatory"}}
+	assert.True(t, errors.Is(registry.Register(descriptor), ErrInvalidArgumentsSchema))
+
+	descriptor = createDescriptor("function")
+	descriptor.GasCost = func(builtInCost vmcommon.BuiltInCost) uint64 { return builtInCost.DCTTransfer }
+	descriptor.Arguments = []ArgumentDescriptor{{Name: "first"}, {Name: "optional", Optional: true}, {Name: "args", Variadic: true}}
+	assert.Nil(t, registry.Register(descriptor))
+	assert.True(t, errors.Is(registry.Register(descriptor), ErrBuiltInFunctionAlreadyRegistered))
+
+	assert.Nil(t, registry.Register(createDescriptor("second")))
+	assert.Equal(t, []string{"function", "second"}, registry.Names())
+	assert.Len(t, registry.Descriptors(), 2)
+
+	storedDescriptor, found := registry.Get("function")
+	assert.True(t, found)
+	assert.Equal(t, uint64(3), storedDescriptor.GasCost(vmcommon.BuiltInCost{DCTTransfer: 3}))
+	assert.Equal(t, 1, storedDescriptor.MinNumArguments())
+
+	storedDescriptor.Arguments[0].Name = "changed"
+	storedDescriptor, _ = registry.Get("function")
+	assert.Equal(t, "first", storedDescriptor.Arguments[0].Name)
+
+	_, found = registry.Get("missing")
+	assert.False(t, found)
+}
+
+func TestNewDefaultBuiltInFunctionsRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry, err := NewDefaultBuiltInFunctionsRegistry()
+	require.Nil(t, err)
+
+	args := createMockArguments()
+	creator, _ := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+
+	container := creator.BuiltInFunctionContainer()
+	assert.Equal(t, container.Len(), len(registry.Names()))
+	for _, name := range registry.Names() {
+		_, errGet := container.Get(name)
+		assert.Nil(t, errGet, name)
+	}
+}
+
+func TestNewDefaultBuiltInFunctionsRegistry_ActivationFlagsShouldMatchBuiltInFunctions(t *testing.T) {
+	t.Parallel()
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{}
+	args := createMockArguments()
+	args.EnableEpochsHandler = enableEpochsHandler
+	creator, _ := NewBuiltInFunctionsCreator(args)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+
+	activeFlag := vmcommon.EnableEpochFlag("")
+	enableEpochsHandler.IsFlagEnabledCalled = func(flag vmcommon.EnableEpochFlag) bool {
+		return flag == activeFlag
+	}
+
+	for _, descriptor := range creator.BuiltInFunctionsRegistry().Descriptors() {
+		builtInFunc, err := creator.BuiltInFunctionContainer().Get(descriptor.Name)
+		require.Nil(t, err)
+
+		activeFlag = ""
+		assert.Equal(t, len(descriptor.ActivationFlag) == 0, builtInFunc.IsActive(), descriptor.Name)
+
+		activeFlag = descriptor.ActivationFlag
+		assert.True(t, builtInFunc.IsActive(), descriptor.Name)
+	}
+}
@@ -9,17 +9,27 @@ import (
 	"github.com/kalyan3104/k-core/core/check"
 	"github.com/kalyan3104/k-core/data/vm"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type saveUserName struct {
 	baseAlwaysActiveHandler
-	gasCost         uint64
-	mapDnsAddresses map[string]struct{}
-	enableChange    bool
-	mutExecution    sync.RWMutex
+	gasCost             uint64
+	mapDnsAddresses     map[string]struct{}
+	enableChange        bool
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+	mutExecution        sync.RWMutex
 }
 
-// NewSaveUserNameFunc returns a username built in function implementation
+// ArgsNewSaveUserName defines the argument list for new username built in function
+type ArgsNewSaveUserName struct {
+	GasCost             uint64
+	MapDnsAddresses     map[string]struct{}
+	EnableChange        bool
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewSaveUserNameFunc returns a username built in function implementation that does not emit logs
 func NewSaveUserNameFunc(
 	gasCost uint64,
 	mapDnsAddresses map[string]struct{},
@@ -41,6 +51,22 @@ func NewSaveUserNameFunc(
 	return s, nil
 }
 
+// NewSaveUserName returns a username built in function implementation that emits logs once the built-in functions
+// logs flag is enabled
+func NewSaveUserName(args ArgsNewSaveUserName) (*saveUserName, error) {
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	s, err := NewSaveUserNameFunc(args.GasCost, args.MapDnsAddresses, args.EnableChange)
+	if err != nil {
+		return nil, err
+	}
+	s.enableEpochsHandler = args.EnableEpochsHandler
+
+	return s, nil
+}
+
 // SetNewGasConfig is called whenever gas cost is changed
 func (s *saveUserName) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 	if gasCost == nil {
@@ -66,6 +92,7 @@ func (s *saveUserName) ProcessBuiltinFunction(
 	if vmInput.CallValue.Cmp(zero) != 0 {
 		return nil, ErrBuiltInFunctionCalledWithValue
 	}
+	vmInput.GasTrace.Charge(gasTraceFunctionCost, s.gasCost)
 	if vmInput.GasProvided < s.gasCost {
 		return nil, ErrNotEnoughGas
 	}
@@ -104,7 +131,39 @@ func (s *saveUserName) ProcessBuiltinFunction(
 
 	acntDst.SetUserName(vmInput.Arguments[0])
 
-	return &vmcommon.VMOutput{GasRemaining: vmInput.GasProvided - s.gasCost, ReturnCode: vmcommon.Ok}, nil
+	vmOutput := &vmcommon.VMOutput{GasRemaining: vmInput.GasProvided - s.gasCost, ReturnCode: vmcommon.Ok}
+	if isBuiltInFunctionsLogsFlagEnabled(s.enableEpochsHandler) {
+		events.AddToVMOutput(vmOutput, &events.SetUserName{
+			Account:  vmInput.RecipientAddr,
+			UserName: vmInput.Arguments[0],
+		})
+	}
+
+	return vmOutput, nil
+}
+
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (s *saveUserName) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+
+	s.mutExecution.RLock()
+	defer s.mutExecution.RUnlock()
+
+	return s.gasCost, nil
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (s *saveUserName) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+
+	b := newReadWriteSetBuilder()
+	b.readWrite(vmInput.RecipientAddr, nil)
+
+	return b.build(), nil
 }
 
 // IsInterfaceNil returns true if underlying object in nil
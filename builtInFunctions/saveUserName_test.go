@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 	"github.com/kalyan3104/k-vm-common-go/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -51,3 +52,44 @@ func TestSaveUserName_ProcessBuiltinFunction(t *testing.T) {
 	_, err = coa.ProcessBuiltinFunction(nil, acc, vmInput)
 	require.Equal(t, ErrUserNameChangeIsDisabled, err)
 }
+
+func TestSaveUserName_ProcessBuiltinFunctionLogs(t *testing.T) {
+	t.Parallel()
+
+	dnsAddr := []byte("DNS")
+	mapDnsAddresses := map[string]struct{}{string(dnsAddr): {}}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{IsBuiltInFunctionsLogsFlagEnabledField: true}
+	sun, _ := NewSaveUserName(ArgsNewSaveUserName{
+		GasCost:             1,
+		MapDnsAddresses:     mapDnsAddresses,
+		EnableChange:        true,
+		EnableEpochsHandler: enableEpochsHandler,
+	})
+
+	addr := []byte("addr")
+	userName := []byte("afafafafafafafafafafafafafafafaf")
+	acc := mock.NewUserAccount(addr)
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  dnsAddr,
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{userName},
+		},
+		RecipientAddr: addr,
+	}
+
+	vmOutput, err := sun.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Nil(t, err)
+	expectedLog := (&events.SetUserName{Account: addr, UserName: userName}).LogEntry()
+	require.Equal(t, []*vmcommon.LogEntry{expectedLog}, vmOutput.Logs)
+
+	vmOutput, err = sun.ProcessBuiltinFunction(nil, nil, vmInput)
+	require.Nil(t, err)
+	require.Empty(t, vmOutput.Logs)
+
+	enableEpochsHandler.IsBuiltInFunctionsLogsFlagEnabledField = false
+	vmOutput, err = sun.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Nil(t, err)
+	require.Empty(t, vmOutput.Logs)
+}
@@ -0,0 +1,89 @@
+package builtInFunctions
+
+import (
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.BuiltinFunction = (*storageRecordingFunction)(nil)
+
+// storageRecordingFunction wraps a built-in function and adds all the storage updates it made, on any account,
+// to the returned VMOutput
+type storageRecordingFunction struct {
+	vmcommon.BuiltinFunction
+	recorder *storageUpdatesRecorder
+}
+
+// NewStorageRecordingFunction creates a built-in function which records the storage updates of the wrapped one.
+// The accounts adapter of the wrapped function must be obtained through the WrapAccounts method of the same recorder,
+// so the updates made on the loaded accounts (e.g. the system account) are recorded as well
+func NewStorageRecordingFunction(
+	builtInFunction vmcommon.BuiltinFunction,
+	recorder *storageUpdatesRecorder,
+) (*storageRecordingFunction, error) {
+	if check.IfNil(builtInFunction) {
+		return nil, ErrNilBuiltInFunction
+	}
+	if check.IfNil(recorder) {
+		return nil, ErrNilStorageUpdatesRecorder
+	}
+
+	return &storageRecordingFunction{
+		BuiltinFunction: builtInFunction,
+		recorder:        recorder,
+	}, nil
+}
+
+// ProcessBuiltinFunction processes the wrapped built-in function and adds the recorded storage updates to its output.
+// The calls sharing the recorder are serialized, except the ones nested in a running call, which receive accounts
+// recording in its session
+func (srf *storageRecordingFunction) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	endExecution := srf.recorder.startExecution(acntSnd, acntDst)
+	defer endExecution()
+
+	session := srf.recorder.StartRecording()
+
+	vmOutput, err := srf.BuiltinFunction.ProcessBuiltinFunction(
+		srf.recorder.WrapAccount(acntSnd, session),
+		srf.recorder.WrapAccount(acntDst, session),
+		vmInput,
+	)
+	if err != nil {
+		srf.recorder.StopRecording(session, nil)
+		return nil, err
+	}
+
+	srf.recorder.StopRecording(session, vmOutput)
+
+	return vmOutput, nil
+}
+
+// Unwrap returns the wrapped built-in function
+func (srf *storageRecordingFunction) Unwrap() vmcommon.BuiltinFunction {
+	return srf.BuiltinFunction
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (srf *storageRecordingFunction) IsInterfaceNil() bool {
+	return srf == nil || check.IfNil(srf.BuiltinFunction)
+}
+
+// BuiltInFunctionWrapper defines a built-in function which decorates another one
+type BuiltInFunctionWrapper interface {
+	Unwrap() vmcommon.BuiltinFunction
+}
+
+// UnwrapBuiltInFunction returns the innermost built-in function, so its optional interfaces, as the gas estimation or
+// the read/write set, can be type asserted
+func UnwrapBuiltInFunction(builtInFunction vmcommon.BuiltinFunction) vmcommon.BuiltinFunction {
+	for {
+		wrapper, ok := builtInFunction.(BuiltInFunctionWrapper)
+		if !ok {
+			return builtInFunction
+		}
+		builtInFunction = wrapper.Unwrap()
+	}
+}
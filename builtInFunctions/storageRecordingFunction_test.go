@@ -0,0 +1,178 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStorageRecordingFunction(t *testing.T) {
+	t.Parallel()
+
+	recordingFunc, err := NewStorageRecordingFunction(nil, NewStorageUpdatesRecorder())
+	assert.Nil(t, recordingFunc)
+	assert.Equal(t, ErrNilBuiltInFunction, err)
+
+	recordingFunc, err = NewStorageRecordingFunction(&mock.BuiltInFunctionStub{}, nil)
+	assert.Nil(t, recordingFunc)
+	assert.Equal(t, ErrNilStorageUpdatesRecorder, err)
+
+	builtInFunc := &mock.BuiltInFunctionStub{}
+	recordingFunc, err = NewStorageRecordingFunction(builtInFunc, NewStorageUpdatesRecorder())
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(recordingFunc))
+	assert.True(t, recordingFunc.Unwrap() == builtInFunc)
+	assert.True(t, UnwrapBuiltInFunction(recordingFunc) == builtInFunc)
+	assert.True(t, UnwrapBuiltInFunction(builtInFunc) == builtInFunc)
+}
+
+func TestStorageRecordingFunction_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	shouldFail := true
+	builtInFunc := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			if !check.IfNil(acntSnd) {
+				_ = acntSnd.AccountDataHandler().SaveKeyValue([]byte("sender key"), []byte("value"))
+			}
+			_ = acntDst.AccountDataHandler().SaveKeyValue([]byte("destination key"), []byte("value"))
+			if shouldFail {
+				return nil, expectedErr
+			}
+
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		},
+	}
+	recordingFunc, _ := NewStorageRecordingFunction(builtInFunc, NewStorageUpdatesRecorder())
+
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+	vmOutput, err := recordingFunc.ProcessBuiltinFunction(accSnd, accDst, &vmcommon.ContractCallInput{})
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, expectedErr, err)
+
+	shouldFail = false
+	vmOutput, err = recordingFunc.ProcessBuiltinFunction(accSnd, accDst, &vmcommon.ContractCallInput{})
+	require.Nil(t, err)
+	require.Len(t, vmOutput.OutputAccounts, 2)
+	assert.Contains(t, vmOutput.OutputAccounts["snd"].StorageUpdates, "sender key")
+	assert.Contains(t, vmOutput.OutputAccounts["dst"].StorageUpdates, "destination key")
+	assert.Zero(t, vmOutput.OutputAccounts["snd"].BytesAddedToStorage)
+	assert.Zero(t, vmOutput.OutputAccounts["dst"].BytesAddedToStorage)
+
+	vmOutput, err = recordingFunc.ProcessBuiltinFunction(nil, accDst, &vmcommon.ContractCallInput{})
+	require.Nil(t, err)
+	require.Len(t, vmOutput.OutputAccounts, 1)
+	assert.Contains(t, vmOutput.OutputAccounts["dst"].StorageUpdates, "destination key")
+}
+
+func TestStorageRecordingFunction_ReentrantCallShouldNotDeadlock(t *testing.T) {
+	t.Parallel()
+
+	var recordingFunc *storageRecordingFunction
+	builtInFunc := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			_ = acntDst.AccountDataHandler().SaveKeyValue([]byte(vmInput.Function), []byte("value"))
+			if vmInput.Function == "inner" {
+				return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+			}
+
+			innerInput := &vmcommon.ContractCallInput{Function: "inner"}
+			innerOutput, err := recordingFunc.ProcessBuiltinFunction(nil, acntDst, innerInput)
+			require.Nil(t, err)
+			require.Len(t, innerOutput.OutputAccounts, 1)
+			assert.Len(t, innerOutput.OutputAccounts["dst"].StorageUpdates, 1)
+
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		},
+	}
+	recordingFunc, _ = NewStorageRecordingFunction(builtInFunc, NewStorageUpdatesRecorder())
+
+	vmOutput, err := recordingFunc.ProcessBuiltinFunction(nil, mock.NewUserAccount([]byte("dst")), &vmcommon.ContractCallInput{Function: "outer"})
+	require.Nil(t, err)
+	require.Len(t, vmOutput.OutputAccounts, 1)
+	assert.Contains(t, vmOutput.OutputAccounts["dst"].StorageUpdates, "outer")
+	assert.Contains(t, vmOutput.OutputAccounts["dst"].StorageUpdates, "inner")
+}
+
+func TestStorageRecordingFunction_ConcurrentCallsShouldNotRecordEachOtherUpdates(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewStorageUpdatesRecorder()
+	accounts := recorder.WrapAccounts(&mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return mock.NewUserAccount(address), nil
+		},
+	})
+	builtInFunc := &mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			loaded, err := accounts.LoadAccount(vmInput.RecipientAddr)
+			require.Nil(t, err)
+			time.Sleep(time.Millisecond)
+			_ = loaded.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		},
+	}
+	recordingFunc, _ := NewStorageRecordingFunction(builtInFunc, recorder)
+
+	numCalls := 10
+	outputs := make([]*vmcommon.VMOutput, numCalls)
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func(index int) {
+			defer wg.Done()
+
+			vmInput := &vmcommon.ContractCallInput{RecipientAddr: []byte{byte(index)}}
+			outputs[index], _ = recordingFunc.ProcessBuiltinFunction(nil, nil, vmInput)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, vmOutput := range outputs {
+		require.Len(t, vmOutput.OutputAccounts, 1)
+		assert.NotNil(t, vmOutput.OutputAccounts[string([]byte{byte(i)})])
+	}
+}
+
+func TestStorageRecordingFunction_CallsOnDifferentRecordersShouldNotBeSerialized(t *testing.T) {
+	t.Parallel()
+
+	callStarted := make(chan struct{})
+	otherCallDone := make(chan struct{})
+	blockingFunc, _ := NewStorageRecordingFunction(&mock.BuiltInFunctionStub{
+		ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			close(callStarted)
+			select {
+			case <-otherCallDone:
+			case <-time.After(time.Second):
+				assert.Fail(t, "the call on the other recorder should not wait for this one")
+			}
+
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		},
+	}, NewStorageUpdatesRecorder())
+	otherFunc, _ := NewStorageRecordingFunction(&mock.BuiltInFunctionStub{}, NewStorageUpdatesRecorder())
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = blockingFunc.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	}()
+
+	<-callStarted
+	_, err := otherFunc.ProcessBuiltinFunction(nil, nil, &vmcommon.ContractCallInput{})
+	require.Nil(t, err)
+	close(otherCallDone)
+	wg.Wait()
+}
@@ -0,0 +1,369 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.UserAccountHandler = (*recordingUserAccount)(nil)
+var _ vmcommon.AccountDataHandler = (*recordingDataHandler)(nil)
+var _ vmcommon.AccountsAdapter = (*recordingAccountsAdapter)(nil)
+
+type recordedValue struct {
+	oldValue []byte
+	newValue []byte
+}
+
+type recordedAccount struct {
+	keys   []string
+	values map[string]*recordedValue
+}
+
+// recordingSession holds the keys written during one recorded built-in function call
+type recordingSession struct {
+	mutAccounts sync.Mutex
+	addresses   []string
+	accounts    map[string]*recordedAccount
+}
+
+func newRecordingSession() *recordingSession {
+	return &recordingSession{
+		addresses: make([]string, 0),
+		accounts:  make(map[string]*recordedAccount),
+	}
+}
+
+// storageUpdatesRecorder records the keys written in the accounts data by the built-in function calls. Every call has
+// its own recording session, started by StartRecording and ended by StopRecording. The accounts loaded through the
+// wrapped adapter can not be related to a call, so the recorded executions sharing the recorder are serialized, while
+// the nested recorded calls run inside the execution they are nested in. A key written back to its previous value is
+// reported as read only
+type storageUpdatesRecorder struct {
+	mutExecution   sync.Mutex
+	mutSessions    sync.RWMutex
+	activeSessions []*recordingSession
+}
+
+// NewStorageUpdatesRecorder creates a new storage updates recorder
+func NewStorageUpdatesRecorder() *storageUpdatesRecorder {
+	return &storageUpdatesRecorder{
+		activeSessions: make([]*recordingSession, 0),
+	}
+}
+
+// StartRecording starts and returns a new recording session, which stays active until StopRecording is called on it
+func (sur *storageUpdatesRecorder) StartRecording() *recordingSession {
+	session := newRecordingSession()
+
+	sur.mutSessions.Lock()
+	sur.activeSessions = append(sur.activeSessions, session)
+	sur.mutSessions.Unlock()
+
+	return session
+}
+
+// StopRecording ends the provided recording session and adds its recorded storage updates to the provided VMOutput,
+// if not nil
+func (sur *storageUpdatesRecorder) StopRecording(session *recordingSession, vmOutput *vmcommon.VMOutput) {
+	if session == nil {
+		return
+	}
+
+	sur.mutSessions.Lock()
+	for i, activeSession := range sur.activeSessions {
+		if activeSession == session {
+			sur.activeSessions = append(sur.activeSessions[:i:i], sur.activeSessions[i+1:]...)
+			break
+		}
+	}
+	sur.mutSessions.Unlock()
+
+	if vmOutput != nil {
+		session.addToVMOutput(vmOutput)
+	}
+}
+
+// startExecution waits for the running recorded execution to end, unless the call is nested in it, and returns the
+// function ending the execution. A call is nested if it received an account recording in one of the active sessions
+func (sur *storageUpdatesRecorder) startExecution(accounts ...vmcommon.UserAccountHandler) func() {
+	if sur.isNestedExecution(accounts) {
+		return func() {}
+	}
+
+	sur.mutExecution.Lock()
+	return sur.mutExecution.Unlock
+}
+
+func (sur *storageUpdatesRecorder) isNestedExecution(accounts []vmcommon.UserAccountHandler) bool {
+	sur.mutSessions.RLock()
+	defer sur.mutSessions.RUnlock()
+
+	for _, account := range accounts {
+		recordingAccount, ok := account.(*recordingUserAccount)
+		if !ok || recordingAccount == nil {
+			continue
+		}
+
+		for _, session := range recordingAccount.sessions {
+			if containsSession(sur.activeSessions, session) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (sur *storageUpdatesRecorder) getActiveSessions() []*recordingSession {
+	sur.mutSessions.RLock()
+	defer sur.mutSessions.RUnlock()
+
+	return append(make([]*recordingSession, 0, len(sur.activeSessions)), sur.activeSessions...)
+}
+
+func (rs *recordingSession) addToVMOutput(vmOutput *vmcommon.VMOutput) {
+	rs.mutAccounts.Lock()
+	defer rs.mutAccounts.Unlock()
+
+	if len(rs.addresses) > 0 && vmOutput.OutputAccounts == nil {
+		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
+	}
+
+	for _, address := range rs.addresses {
+		outputAccount, found := vmOutput.OutputAccounts[address]
+		if !found {
+			outputAccount = &vmcommon.OutputAccount{Address: []byte(address)}
+			vmOutput.OutputAccounts[address] = outputAccount
+		}
+		if outputAccount.StorageUpdates == nil {
+			outputAccount.StorageUpdates = make(map[string]*vmcommon.StorageUpdate)
+		}
+
+		account := rs.accounts[address]
+		for _, key := range account.keys {
+			value := account.values[key]
+			if bytes.Equal(value.oldValue, value.newValue) {
+				addReadStorageUpdate(outputAccount, key, value.newValue)
+				continue
+			}
+
+			outputAccount.StorageUpdates[key] = &vmcommon.StorageUpdate{
+				Offset:  []byte(key),
+				Data:    value.newValue,
+				Written: true,
+			}
+
+			lenOld, lenNew := uint64(len(value.oldValue)), uint64(len(value.newValue))
+			if lenNew > lenOld {
+				outputAccount.BytesAddedToStorage += lenNew - lenOld
+			} else {
+				outputAccount.BytesDeletedFromStorage += lenOld - lenNew
+			}
+		}
+	}
+}
+
+// addReadStorageUpdate reports a key written back to its value as read only, unless the key is already reported
+func addReadStorageUpdate(outputAccount *vmcommon.OutputAccount, key string, value []byte) {
+	_, found := outputAccount.StorageUpdates[key]
+	if found {
+		return
+	}
+
+	outputAccount.StorageUpdates[key] = &vmcommon.StorageUpdate{
+		Offset: []byte(key),
+		Data:   value,
+	}
+}
+
+func (rs *recordingSession) record(address []byte, key []byte, value []byte, dataHandler vmcommon.AccountDataHandler) {
+	rs.mutAccounts.Lock()
+	defer rs.mutAccounts.Unlock()
+
+	account, found := rs.accounts[string(address)]
+	if !found {
+		account = &recordedAccount{
+			keys:   make([]string, 0),
+			values: make(map[string]*recordedValue),
+		}
+		rs.accounts[string(address)] = account
+		rs.addresses = append(rs.addresses, string(address))
+	}
+
+	recorded, found := account.values[string(key)]
+	if !found {
+		oldValue, _, err := dataHandler.RetrieveValue(key)
+		if err != nil {
+			oldValue = nil
+		}
+
+		recorded = &recordedValue{oldValue: cloneBytes(oldValue)}
+		account.values[string(key)] = recorded
+		account.keys = append(account.keys, string(key))
+	}
+	recorded.newValue = cloneBytes(value)
+}
+
+// WrapAccount returns a view of the provided account which records all the written keys in the provided session.
+// An account which is already recording keeps recording in its sessions as well. A nil account is returned as it is
+func (sur *storageUpdatesRecorder) WrapAccount(account vmcommon.UserAccountHandler, session *recordingSession) vmcommon.UserAccountHandler {
+	if check.IfNil(account) || session == nil {
+		return account
+	}
+
+	return wrapAccountInSessions(account, []*recordingSession{session})
+}
+
+func wrapAccountInSessions(account vmcommon.UserAccountHandler, sessions []*recordingSession) vmcommon.UserAccountHandler {
+	recordingAccount, isRecording := account.(*recordingUserAccount)
+	if !isRecording {
+		return &recordingUserAccount{
+			UserAccountHandler: account,
+			sessions:           sessions,
+		}
+	}
+
+	allSessions := append(make([]*recordingSession, 0, len(recordingAccount.sessions)+len(sessions)), recordingAccount.sessions...)
+	for _, session := range sessions {
+		if !containsSession(allSessions, session) {
+			allSessions = append(allSessions, session)
+		}
+	}
+
+	return &recordingUserAccount{
+		UserAccountHandler: recordingAccount.UserAccountHandler,
+		sessions:           allSessions,
+	}
+}
+
+func containsSession(sessions []*recordingSession, session *recordingSession) bool {
+	for _, existing := range sessions {
+		if existing == session {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WrapAccounts returns a view of the provided accounts adapter which loads accounts recording in all the sessions
+// active at load time. The active sessions all belong to the running recorded execution and to the calls nested in it,
+// as the recorded executions are serialized
+func (sur *storageUpdatesRecorder) WrapAccounts(accounts vmcommon.AccountsAdapter) vmcommon.AccountsAdapter {
+	return &recordingAccountsAdapter{
+		AccountsAdapter: accounts,
+		recorder:        sur,
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sur *storageUpdatesRecorder) IsInterfaceNil() bool {
+	return sur == nil
+}
+
+type recordingUserAccount struct {
+	vmcommon.UserAccountHandler
+	sessions []*recordingSession
+}
+
+// AccountDataHandler returns the recording handler of the account data
+func (rua *recordingUserAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return &recordingDataHandler{
+		AccountDataHandler: rua.UserAccountHandler.AccountDataHandler(),
+		address:            rua.AddressBytes(),
+		sessions:           rua.sessions,
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rua *recordingUserAccount) IsInterfaceNil() bool {
+	return rua == nil || check.IfNil(rua.UserAccountHandler)
+}
+
+type recordingDataHandler struct {
+	vmcommon.AccountDataHandler
+	address  []byte
+	sessions []*recordingSession
+}
+
+// SaveKeyValue records the written key in the sessions of the account and saves it in the wrapped account data
+func (rdh *recordingDataHandler) SaveKeyValue(key []byte, value []byte) error {
+	for _, session := range rdh.sessions {
+		session.record(rdh.address, key, value, rdh.AccountDataHandler)
+	}
+	return rdh.AccountDataHandler.SaveKeyValue(key, value)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rdh *recordingDataHandler) IsInterfaceNil() bool {
+	return rdh == nil || check.IfNil(rdh.AccountDataHandler)
+}
+
+type recordingAccountsAdapter struct {
+	vmcommon.AccountsAdapter
+	recorder *storageUpdatesRecorder
+}
+
+// GetExistingAccount returns the existing account, wrapped if recording sessions are active
+func (raa *recordingAccountsAdapter) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	account, err := raa.AccountsAdapter.GetExistingAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return raa.wrapIfRecording(account), nil
+}
+
+// LoadAccount loads the account, wrapped if recording sessions are active
+func (raa *recordingAccountsAdapter) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	account, err := raa.AccountsAdapter.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return raa.wrapIfRecording(account), nil
+}
+
+func (raa *recordingAccountsAdapter) wrapIfRecording(account vmcommon.AccountHandler) vmcommon.AccountHandler {
+	sessions := raa.recorder.getActiveSessions()
+	if len(sessions) == 0 {
+		return account
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok || check.IfNil(userAccount) {
+		return account
+	}
+
+	return wrapAccountInSessions(userAccount, sessions)
+}
+
+// SaveAccount saves the provided account, unwrapping it first, as the wrapped adapter expects its own accounts
+func (raa *recordingAccountsAdapter) SaveAccount(account vmcommon.AccountHandler) error {
+	return raa.AccountsAdapter.SaveAccount(unwrapAccount(account))
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (raa *recordingAccountsAdapter) IsInterfaceNil() bool {
+	return raa == nil || check.IfNil(raa.AccountsAdapter)
+}
+
+func unwrapAccount(account vmcommon.AccountHandler) vmcommon.AccountHandler {
+	recordingAccount, ok := account.(*recordingUserAccount)
+	if !ok || recordingAccount == nil {
+		return account
+	}
+
+	return recordingAccount.UserAccountHandler
+}
+
+func cloneBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(data)), data...)
+}
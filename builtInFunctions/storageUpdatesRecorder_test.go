@@ -0,0 +1,214 @@
+package builtInFunctions
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageUpdatesRecorder_WrapAccount(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewStorageUpdatesRecorder()
+	assert.False(t, check.IfNil(recorder))
+	session := recorder.StartRecording()
+
+	assert.True(t, check.IfNil(recorder.WrapAccount(nil, session)))
+
+	account := mock.NewUserAccount([]byte("address"))
+	assert.True(t, recorder.WrapAccount(account, nil) == account)
+
+	wrapped := recorder.WrapAccount(account, session)
+	assert.False(t, check.IfNil(wrapped))
+	assert.Equal(t, account.AddressBytes(), wrapped.AddressBytes())
+	assert.True(t, unwrapAccount(wrapped) == account)
+
+	rewrapped := recorder.WrapAccount(wrapped, session)
+	assert.True(t, unwrapAccount(rewrapped) == account)
+	assert.Len(t, rewrapped.(*recordingUserAccount).sessions, 1)
+}
+
+func TestStorageUpdatesRecorder_ShouldRecordWrittenKeys(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewStorageUpdatesRecorder()
+	account := mock.NewUserAccount([]byte("address"))
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("existing"), []byte("value"))
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("removed"), []byte("removed value"))
+	_ = account.AccountDataHandler().SaveKeyValue([]byte("unchanged"), []byte("same"))
+
+	session := recorder.StartRecording()
+	wrapped := recorder.WrapAccount(account, session)
+	_ = wrapped.AccountDataHandler().SaveKeyValue([]byte("new"), []byte("v"))
+	_ = wrapped.AccountDataHandler().SaveKeyValue([]byte("new"), []byte("new value"))
+	_ = wrapped.AccountDataHandler().SaveKeyValue([]byte("existing"), []byte("val"))
+	_ = wrapped.AccountDataHandler().SaveKeyValue([]byte("removed"), nil)
+	_ = wrapped.AccountDataHandler().SaveKeyValue([]byte("unchanged"), []byte("other"))
+	_ = wrapped.AccountDataHandler().SaveKeyValue([]byte("unchanged"), []byte("same"))
+
+	vmOutput := &vmcommon.VMOutput{}
+	recorder.StopRecording(session, vmOutput)
+
+	value, _, _ := account.AccountDataHandler().RetrieveValue([]byte("new"))
+	assert.Equal(t, []byte("new value"), value)
+
+	require.Len(t, vmOutput.OutputAccounts, 1)
+	outputAccount := vmOutput.OutputAccounts["address"]
+	require.NotNil(t, outputAccount)
+	assert.Equal(t, []byte("address"), outputAccount.Address)
+	expectedUpdates := map[string]*vmcommon.StorageUpdate{
+		"new":       {Offset: []byte("new"), Data: []byte("new value"), Written: true},
+		"existing":  {Offset: []byte("existing"), Data: []byte("val"), Written: true},
+		"removed":   {Offset: []byte("removed"), Data: nil, Written: true},
+		"unchanged": {Offset: []byte("unchanged"), Data: []byte("same")},
+	}
+	assert.Equal(t, expectedUpdates, outputAccount.StorageUpdates)
+	assert.Equal(t, uint64(len("new value")), outputAccount.BytesAddedToStorage)
+	assert.Equal(t, uint64(len("value")-len("val")+len("removed value")), outputAccount.BytesDeletedFromStorage)
+
+	otherSession := recorder.StartRecording()
+	vmOutput = &vmcommon.VMOutput{}
+	recorder.StopRecording(otherSession, vmOutput)
+	assert.Empty(t, vmOutput.OutputAccounts)
+	recorder.StopRecording(nil, vmOutput)
+}
+
+func TestStorageUpdatesRecorder_NestedSessions(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewStorageUpdatesRecorder()
+	accounts := recorder.WrapAccounts(&mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return mock.NewUserAccount(address), nil
+		},
+	})
+
+	outerSession := recorder.StartRecording()
+	outerAccount := recorder.WrapAccount(mock.NewUserAccount([]byte("outer")), outerSession)
+	_ = outerAccount.AccountDataHandler().SaveKeyValue([]byte("outer key"), []byte("value"))
+
+	innerSession := recorder.StartRecording()
+	innerAccount := recorder.WrapAccount(outerAccount, innerSession)
+	_ = innerAccount.AccountDataHandler().SaveKeyValue([]byte("inner key"), []byte("value"))
+	loaded, _ := accounts.LoadAccount([]byte("loaded"))
+	_ = loaded.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue([]byte("loaded key"), []byte("value"))
+
+	innerOutput := &vmcommon.VMOutput{}
+	recorder.StopRecording(innerSession, innerOutput)
+	outerOutput := &vmcommon.VMOutput{}
+	recorder.StopRecording(outerSession, outerOutput)
+
+	require.Len(t, innerOutput.OutputAccounts, 2)
+	assert.Len(t, innerOutput.OutputAccounts["outer"].StorageUpdates, 1)
+	assert.Contains(t, innerOutput.OutputAccounts["outer"].StorageUpdates, "inner key")
+	assert.Contains(t, innerOutput.OutputAccounts["loaded"].StorageUpdates, "loaded key")
+
+	require.Len(t, outerOutput.OutputAccounts, 2)
+	assert.Len(t, outerOutput.OutputAccounts["outer"].StorageUpdates, 2)
+	assert.Contains(t, outerOutput.OutputAccounts["loaded"].StorageUpdates, "loaded key")
+
+	loaded, _ = accounts.LoadAccount([]byte("loaded"))
+	_, isRecording := loaded.(*recordingUserAccount)
+	assert.False(t, isRecording)
+}
+
+func TestStorageUpdatesRecorder_ConcurrentSessionsShouldNotBlock(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewStorageUpdatesRecorder()
+	numSessions := 10
+	outputs := make([]*vmcommon.VMOutput, numSessions)
+	wg := sync.WaitGroup{}
+	wg.Add(numSessions)
+	for i := 0; i < numSessions; i++ {
+		go func(index int) {
+			defer wg.Done()
+
+			session := recorder.StartRecording()
+			account := recorder.WrapAccount(mock.NewUserAccount([]byte{byte(index)}), session)
+			_ = account.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+
+			outputs[index] = &vmcommon.VMOutput{}
+			recorder.StopRecording(session, outputs[index])
+		}(i)
+	}
+	wg.Wait()
+
+	for i, vmOutput := range outputs {
+		require.Len(t, vmOutput.OutputAccounts, 1)
+		assert.NotNil(t, vmOutput.OutputAccounts[string([]byte{byte(i)})])
+	}
+}
+
+func TestStorageUpdatesRecorder_ShouldMergeIntoExistingOutputAccounts(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewStorageUpdatesRecorder()
+	session := recorder.StartRecording()
+	wrapped := recorder.WrapAccount(mock.NewUserAccount([]byte("address")), session)
+
+	_ = wrapped.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+
+	outputTransfers := []vmcommon.OutputTransfer{{Data: []byte("data")}}
+	vmOutput := &vmcommon.VMOutput{
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"address": {
+				Address:         []byte("address"),
+				OutputTransfers: outputTransfers,
+			},
+		},
+	}
+	recorder.StopRecording(session, vmOutput)
+
+	outputAccount := vmOutput.OutputAccounts["address"]
+	assert.Equal(t, outputTransfers, outputAccount.OutputTransfers)
+	assert.Len(t, outputAccount.StorageUpdates, 1)
+	assert.Equal(t, uint64(len("value")), outputAccount.BytesAddedToStorage)
+}
+
+func TestStorageUpdatesRecorder_WrapAccounts(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewStorageUpdatesRecorder()
+	account := mock.NewUserAccount([]byte("address"))
+	var savedAccount vmcommon.AccountHandler
+	accounts := recorder.WrapAccounts(&mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return account, nil
+		},
+		GetExistingAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return account, nil
+		},
+		SaveAccountCalled: func(account vmcommon.AccountHandler) error {
+			savedAccount = account
+			return nil
+		},
+	})
+	assert.False(t, check.IfNil(accounts))
+
+	loaded, err := accounts.LoadAccount([]byte("address"))
+	assert.Nil(t, err)
+	assert.True(t, loaded == account)
+
+	session := recorder.StartRecording()
+	loaded, err = accounts.LoadAccount([]byte("address"))
+	assert.Nil(t, err)
+	assert.False(t, loaded == account)
+	existing, err := accounts.GetExistingAccount([]byte("address"))
+	assert.Nil(t, err)
+	assert.False(t, existing == account)
+
+	_ = loaded.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+	err = accounts.SaveAccount(loaded)
+	assert.Nil(t, err)
+	assert.True(t, savedAccount == account)
+
+	vmOutput := &vmcommon.VMOutput{}
+	recorder.StopRecording(session, vmOutput)
+	assert.Len(t, vmOutput.OutputAccounts["address"].StorageUpdates, 1)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/kalyan3104/k-core/core"
 	"github.com/kalyan3104/k-core/core/check"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/events"
 )
 
 type dctNFTupdate struct {
@@ -52,7 +53,7 @@ func NewDCTNFTUpdateAttributesFunc(
 		rolesHandler:          rolesHandler,
 	}
 
-	e.baseActiveHandler.activeHandler = enableEpochsHandler.IsDCTNFTImprovementV1FlagEnabled
+	e.baseActiveHandler.activeHandler = flagActiveHandler(enableEpochsHandler, vmcommon.DCTNFTImprovementV1Flag)
 
 	return e, nil
 }
@@ -94,7 +95,8 @@ func (e *dctNFTupdate) ProcessBuiltinFunction(
 		return nil, err
 	}
 
-	gasCostForStore := uint64(len(vmInput.Arguments[2])) * e.gasConfig.StorePerByte
+	gasCostForStore := e.getGasCostForAttributesStore(vmInput)
+	vmInput.GasTrace.Charge(gasTraceStorePerByte, gasCostForStore)
 	if vmInput.GasProvided < e.funcGasCost+gasCostForStore {
 		return nil, ErrNotEnoughGas
 	}
@@ -121,11 +123,53 @@ func (e *dctNFTupdate) ProcessBuiltinFunction(
 		GasRemaining: vmInput.GasProvided - e.funcGasCost - gasCostForStore,
 	}
 
-	addDCTEntryInVMOutput(vmOutput, []byte(core.BuiltInFunctionDCTNFTUpdateAttributes), vmInput.Arguments[0], nonce, big.NewInt(0), vmInput.CallerAddr, vmInput.Arguments[2])
+	events.AddToVMOutput(vmOutput, &events.DCTNFTUpdateAttributes{
+		Account:    vmInput.CallerAddr,
+		TokenID:    vmInput.Arguments[0],
+		Nonce:      nonce,
+		Attributes: vmInput.Arguments[2],
+	})
 
 	return vmOutput, nil
 }
 
+// EstimateGas returns the gas consumed by the built-in function for the provided input
+func (e *dctNFTupdate) EstimateGas(vmInput *vmcommon.ContractCallInput) (uint64, error) {
+	if vmInput == nil {
+		return 0, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) != 3 {
+		return 0, ErrInvalidArguments
+	}
+
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	return e.funcGasCost + e.getGasCostForAttributesStore(vmInput), nil
+}
+
+func (e *dctNFTupdate) getGasCostForAttributesStore(vmInput *vmcommon.ContractCallInput) uint64 {
+	return uint64(len(vmInput.Arguments[2])) * e.gasConfig.StorePerByte
+}
+
+// ReadWriteSet returns the account data read and written by the built-in function for the provided input
+func (e *dctNFTupdate) ReadWriteSet(vmInput *vmcommon.ContractCallInput) (*vmcommon.ReadWriteSet, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < 3 {
+		return nil, ErrInvalidArguments
+	}
+
+	tokenID := vmInput.Arguments[0]
+	nonce := big.NewInt(0).SetBytes(vmInput.Arguments[1]).Uint64()
+	b := newReadWriteSetBuilder()
+	b.read(vmInput.CallerAddr, roleKeyFor(tokenID))
+	addNFTSaveAccesses(b, vmInput.CallerAddr, tokenID, nonce)
+
+	return b.build(), nil
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *dctNFTupdate) IsInterfaceNil() bool {
 	return e == nil
@@ -0,0 +1,40 @@
+package builtInFunctions
+
+import (
+	"errors"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+// NewVMError wraps the error returned by the built-in function into a VMError carrying the stable code of the error
+// and the return code it maps to, as listed in the error catalog. An error which is not a built-in function error
+// gets the code 0 and UserError
+func NewVMError(function string, err error) *vmcommon.VMError {
+	if err == nil {
+		return nil
+	}
+
+	entry, found := LookupError(err)
+	if !found {
+		return vmcommon.NewVMError(vmcommon.UserError, 0, function, err)
+	}
+
+	return vmcommon.NewVMError(entry.ReturnCode, entry.Code, function, err)
+}
+
+// ReturnCodeFromError returns the code a VM should end the execution with for the provided error. A VMError provides
+// its own code, a built-in function error, even if not wrapped into a VMError, gets the code listed in the error
+// catalog, while any other error is mapped by vmcommon.ReturnCodeFromError
+func ReturnCodeFromError(err error) vmcommon.ReturnCode {
+	var vmErr *vmcommon.VMError
+	if errors.As(err, &vmErr) {
+		return vmErr.ReturnCode
+	}
+
+	entry, found := LookupError(err)
+	if found {
+		return entry.ReturnCode
+	}
+
+	return vmcommon.ReturnCodeFromError(err)
+}
@@ -0,0 +1,42 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVMError(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, NewVMError("function", nil))
+
+	vmErr := NewVMError("DCTTransfer", fmt.Errorf("%w for token", ErrNotEnoughGas))
+	assert.Equal(t, vmcommon.OutOfGas, vmErr.ReturnCode)
+	assert.Equal(t, uint32(10), vmErr.ErrorCode)
+	assert.Equal(t, "DCTTransfer", vmErr.Function)
+	assert.True(t, errors.Is(vmErr, ErrNotEnoughGas))
+	assert.Equal(t, vmcommon.OutOfGas, vmcommon.ReturnCodeFromError(vmErr))
+
+	assert.Equal(t, vmcommon.OutOfFunds, NewVMError("", ErrInsufficientFunds).ReturnCode)
+	assert.Equal(t, vmcommon.FunctionWrongSignature, NewVMError("", ErrInvalidNumOfArgs).ReturnCode)
+
+	vmErr = NewVMError("", errors.New("unknown"))
+	assert.Equal(t, vmcommon.UserError, vmErr.ReturnCode)
+	assert.Equal(t, uint32(0), vmErr.ErrorCode)
+}
+
+func TestReturnCodeFromError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, vmcommon.Ok, ReturnCodeFromError(nil))
+	assert.Equal(t, vmcommon.OutOfGas, ReturnCodeFromError(ErrNotEnoughGas))
+	assert.Equal(t, vmcommon.OutOfGas, ReturnCodeFromError(fmt.Errorf("%w for token", ErrNotEnoughGas)))
+	assert.Equal(t, vmcommon.OutOfFunds, ReturnCodeFromError(ErrInsufficientFunds))
+	assert.Equal(t, vmcommon.UserError, ReturnCodeFromError(NewVMError("", errors.New("unknown"))))
+	assert.Equal(t, vmcommon.ContractInvalid, ReturnCodeFromError(vmcommon.NewVMError(vmcommon.ContractInvalid, 0, "", ErrNotEnoughGas)))
+	assert.Equal(t, vmcommon.ExecutionFailed, ReturnCodeFromError(errors.New("unknown")))
+}
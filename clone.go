@@ -0,0 +1,213 @@
+package vmcommon
+
+// Clone returns a deep copy of the VMInput, sharing no byte slice, big integer or transfer with the original. The
+// gas trace, if any, is cloned as well, so the charges made on the copy are not recorded in the original trace
+func (vmInput *VMInput) Clone() *VMInput {
+	if vmInput == nil {
+		return nil
+	}
+
+	clone := vmInput.cloneValue()
+	return &clone
+}
+
+func (vmInput *VMInput) cloneValue() VMInput {
+	clone := *vmInput
+	clone.CallerAddr = cloneBytes(vmInput.CallerAddr)
+	clone.Arguments = cloneBytesList(vmInput.Arguments)
+	clone.CallValue = copyBigInt(vmInput.CallValue)
+	clone.OriginalTxHash = cloneBytes(vmInput.OriginalTxHash)
+	clone.CurrentTxHash = cloneBytes(vmInput.CurrentTxHash)
+	clone.PrevTxHash = cloneBytes(vmInput.PrevTxHash)
+	clone.DCTTransfers = cloneDCTTransfers(vmInput.DCTTransfers)
+	clone.GasTrace = vmInput.GasTrace.Clone()
+
+	return clone
+}
+
+// Clone returns a deep copy of the ContractCallInput
+func (input *ContractCallInput) Clone() *ContractCallInput {
+	if input == nil {
+		return nil
+	}
+
+	clone := *input
+	clone.VMInput = input.VMInput.cloneValue()
+	clone.RecipientAddr = cloneBytes(input.RecipientAddr)
+
+	return &clone
+}
+
+// Clone returns a deep copy of the ContractCreateInput
+func (input *ContractCreateInput) Clone() *ContractCreateInput {
+	if input == nil {
+		return nil
+	}
+
+	clone := *input
+	clone.VMInput = input.VMInput.cloneValue()
+	clone.ContractCode = cloneBytes(input.ContractCode)
+	clone.ContractCodeMetadata = cloneBytes(input.ContractCodeMetadata)
+
+	return &clone
+}
+
+// Clone returns a deep copy of the DCTTransfer
+func (transfer *DCTTransfer) Clone() *DCTTransfer {
+	if transfer == nil {
+		return nil
+	}
+
+	clone := *transfer
+	clone.DCTValue = copyBigInt(transfer.DCTValue)
+	clone.DCTTokenName = cloneBytes(transfer.DCTTokenName)
+
+	return &clone
+}
+
+// Clone returns a deep copy of the ParsedDCTTransfers
+func (parsed *ParsedDCTTransfers) Clone() *ParsedDCTTransfers {
+	if parsed == nil {
+		return nil
+	}
+
+	clone := *parsed
+	clone.DCTTransfers = cloneDCTTransfers(parsed.DCTTransfers)
+	clone.RcvAddr = cloneBytes(parsed.RcvAddr)
+	clone.CallArgs = cloneBytesList(parsed.CallArgs)
+
+	return &clone
+}
+
+// Clone returns a deep copy of the VMOutput, including the output accounts and their storage updates. The gas trace,
+// if any, is cloned as well
+func (vmOutput *VMOutput) Clone() *VMOutput {
+	if vmOutput == nil {
+		return nil
+	}
+
+	clone := *vmOutput
+	clone.ReturnData = cloneBytesList(vmOutput.ReturnData)
+	clone.GasRefund = copyBigInt(vmOutput.GasRefund)
+	clone.DeletedAccounts = cloneBytesList(vmOutput.DeletedAccounts)
+	clone.TouchedAccounts = cloneBytesList(vmOutput.TouchedAccounts)
+	clone.GasTrace = vmOutput.GasTrace.Clone()
+
+	if vmOutput.OutputAccounts != nil {
+		clone.OutputAccounts = make(map[string]*OutputAccount, len(vmOutput.OutputAccounts))
+		for key, account := range vmOutput.OutputAccounts {
+			clone.OutputAccounts[key] = account.Clone()
+		}
+	}
+
+	if vmOutput.Logs != nil {
+		clone.Logs = make([]*LogEntry, len(vmOutput.Logs))
+		for i, logEntry := range vmOutput.Logs {
+			clone.Logs[i] = logEntry.Clone()
+		}
+	}
+
+	return &clone
+}
+
+// Clone returns a deep copy of the OutputAccount
+func (o *OutputAccount) Clone() *OutputAccount {
+	if o == nil {
+		return nil
+	}
+
+	clone := *o
+	clone.Address = cloneBytes(o.Address)
+	clone.Balance = copyBigInt(o.Balance)
+	clone.Code = cloneBytes(o.Code)
+	clone.CodeMetadata = cloneBytes(o.CodeMetadata)
+	clone.CodeDeployerAddress = cloneBytes(o.CodeDeployerAddress)
+	clone.BalanceDelta = copyBigInt(o.BalanceDelta)
+
+	if o.StorageUpdates != nil {
+		clone.StorageUpdates = make(map[string]*StorageUpdate, len(o.StorageUpdates))
+		for key, update := range o.StorageUpdates {
+			if update == nil {
+				clone.StorageUpdates[key] = nil
+				continue
+			}
+			clone.StorageUpdates[key] = &StorageUpdate{
+				Offset:  cloneBytes(update.Offset),
+				Data:    cloneBytes(update.Data),
+				Written: update.Written,
+			}
+		}
+	}
+
+	if o.OutputTransfers != nil {
+		clone.OutputTransfers = make([]OutputTransfer, len(o.OutputTransfers))
+		for i := range o.OutputTransfers {
+			clone.OutputTransfers[i] = *o.OutputTransfers[i].Clone()
+		}
+	}
+
+	return &clone
+}
+
+// Clone returns a deep copy of the OutputTransfer
+func (ot *OutputTransfer) Clone() *OutputTransfer {
+	if ot == nil {
+		return nil
+	}
+
+	clone := *ot
+	clone.Value = copyBigInt(ot.Value)
+	clone.Data = cloneBytes(ot.Data)
+	clone.SenderAddress = cloneBytes(ot.SenderAddress)
+
+	return &clone
+}
+
+// Clone returns a deep copy of the LogEntry
+func (le *LogEntry) Clone() *LogEntry {
+	if le == nil {
+		return nil
+	}
+
+	return &LogEntry{
+		Identifier: cloneBytes(le.Identifier),
+		Address:    cloneBytes(le.Address),
+		Topics:     cloneBytesList(le.Topics),
+		Data:       cloneBytes(le.Data),
+	}
+}
+
+func cloneDCTTransfers(transfers []*DCTTransfer) []*DCTTransfer {
+	if transfers == nil {
+		return nil
+	}
+
+	clone := make([]*DCTTransfer, len(transfers))
+	for i, transfer := range transfers {
+		clone[i] = transfer.Clone()
+	}
+
+	return clone
+}
+
+// cloneBytes copies the slice, keeping nil and empty slices apart
+func cloneBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(data)), data...)
+}
+
+func cloneBytesList(list [][]byte) [][]byte {
+	if list == nil {
+		return nil
+	}
+
+	clone := make([][]byte, len(list))
+	for i, data := range list {
+		clone[i] = cloneBytes(data)
+	}
+
+	return clone
+}
@@ -0,0 +1,244 @@
+package vmcommon
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/kalyan3104/k-core/data/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mutateInPlace changes, in place, every byte slice and big integer reachable from the value, so that any memory
+// shared between a clone and its original shows up as a difference
+func mutateInPlace(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		value, isBigInt := v.Interface().(*big.Int)
+		if isBigInt {
+			value.Add(value, big.NewInt(1))
+			return
+		}
+		mutateInPlace(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				mutateInPlace(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			for i := 0; i < v.Len(); i++ {
+				v.Index(i).SetUint(^v.Index(i).Uint() & 0xff)
+			}
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			mutateInPlace(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			mutateInPlace(iter.Value())
+		}
+	}
+}
+
+// fuzzChunks splits the data in numChunks consecutive pieces, the last one holding the remainder
+func fuzzChunks(data []byte, numChunks int) [][]byte {
+	chunks := make([][]byte, numChunks)
+	chunkLen := len(data) / numChunks
+	for i := 0; i < numChunks-1; i++ {
+		chunks[i] = data[i*chunkLen : (i+1)*chunkLen]
+	}
+	chunks[numChunks-1] = data[(numChunks-1)*chunkLen:]
+
+	return chunks
+}
+
+func createFuzzVMInput(data []byte, value int64, nonce uint64) VMInput {
+	chunks := fuzzChunks(data, 6)
+	return VMInput{
+		CallerAddr:     append([]byte{}, chunks[0]...),
+		Arguments:      [][]byte{append([]byte{}, chunks[1]...), append([]byte{}, chunks[2]...), nil},
+		CallValue:      big.NewInt(value),
+		CallType:       vm.CallType(nonce % 5),
+		GasPrice:       nonce,
+		GasProvided:    nonce + 1,
+		OriginalTxHash: append([]byte{}, chunks[3]...),
+		CurrentTxHash:  append([]byte{}, chunks[4]...),
+		PrevTxHash:     nil,
+		DCTTransfers: []*DCTTransfer{
+			{DCTValue: big.NewInt(value), DCTTokenName: append([]byte{}, chunks[5]...), DCTTokenNonce: nonce},
+			nil,
+		},
+	}
+}
+
+func createFuzzVMOutput(data []byte, value int64, nonce uint64) *VMOutput {
+	chunks := fuzzChunks(data, 4)
+	return &VMOutput{
+		ReturnData:   [][]byte{append([]byte{}, chunks[0]...)},
+		ReturnCode:   ReturnCode(nonce % 12),
+		GasRemaining: nonce,
+		GasRefund:    big.NewInt(value),
+		OutputAccounts: map[string]*OutputAccount{
+			string(chunks[1]): {
+				Address:      append([]byte{}, chunks[1]...),
+				Nonce:        nonce,
+				Balance:      big.NewInt(value),
+				BalanceDelta: big.NewInt(-value),
+				StorageUpdates: map[string]*StorageUpdate{
+					string(chunks[2]): {Offset: append([]byte{}, chunks[2]...), Data: append([]byte{}, chunks[3]...), Written: true},
+					"nil":             nil,
+				},
+				Code: append([]byte{}, chunks[3]...),
+				OutputTransfers: []OutputTransfer{
+					{Value: big.NewInt(value), Data: append([]byte{}, chunks[0]...), SenderAddress: append([]byte{}, chunks[1]...)},
+				},
+			},
+			"nil": nil,
+		},
+		DeletedAccounts: [][]byte{append([]byte{}, chunks[1]...)},
+		TouchedAccounts: [][]byte{append([]byte{}, chunks[2]...)},
+		Logs: []*LogEntry{
+			{Identifier: append([]byte{}, chunks[0]...), Address: append([]byte{}, chunks[1]...), Topics: [][]byte{append([]byte{}, chunks[2]...)}, Data: append([]byte{}, chunks[3]...)},
+			nil,
+		},
+	}
+}
+
+func addCloneFuzzSeeds(f *testing.F) {
+	f.Add([]byte("caller-arguments-hashes-token-name"), int64(10), uint64(1))
+	f.Add([]byte{}, int64(0), uint64(0))
+	f.Add([]byte{0xff, 0x00, 0x01}, int64(-1), uint64(1<<63))
+}
+
+func FuzzContractCallInput_Clone(f *testing.F) {
+	addCloneFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte, value int64, nonce uint64) {
+		createInput := func() *ContractCallInput {
+			return &ContractCallInput{
+				VMInput:       createFuzzVMInput(data, value, nonce),
+				RecipientAddr: append([]byte{}, data...),
+				Function:      string(data),
+			}
+		}
+
+		original := createInput()
+		clone := original.Clone()
+		require.Equal(t, original, clone)
+
+		mutateInPlace(reflect.ValueOf(clone))
+		require.Equal(t, createInput(), original)
+	})
+}
+
+func FuzzContractCreateInput_Clone(f *testing.F) {
+	addCloneFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte, value int64, nonce uint64) {
+		createInput := func() *ContractCreateInput {
+			return &ContractCreateInput{
+				VMInput:              createFuzzVMInput(data, value, nonce),
+				ContractCode:         append([]byte{}, data...),
+				ContractCodeMetadata: []byte{},
+			}
+		}
+
+		original := createInput()
+		clone := original.Clone()
+		require.Equal(t, original, clone)
+
+		mutateInPlace(reflect.ValueOf(clone))
+		require.Equal(t, createInput(), original)
+	})
+}
+
+func FuzzParsedDCTTransfers_Clone(f *testing.F) {
+	addCloneFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte, value int64, nonce uint64) {
+		createParsed := func() *ParsedDCTTransfers {
+			input := createFuzzVMInput(data, value, nonce)
+			return &ParsedDCTTransfers{
+				DCTTransfers: input.DCTTransfers,
+				RcvAddr:      input.CallerAddr,
+				CallFunction: string(data),
+				CallArgs:     input.Arguments,
+			}
+		}
+
+		original := createParsed()
+		clone := original.Clone()
+		require.Equal(t, original, clone)
+
+		mutateInPlace(reflect.ValueOf(clone))
+		require.Equal(t, createParsed(), original)
+	})
+}
+
+func FuzzVMOutput_Clone(f *testing.F) {
+	addCloneFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte, value int64, nonce uint64) {
+		original := createFuzzVMOutput(data, value, nonce)
+		clone := original.Clone()
+		require.Equal(t, original, clone)
+
+		mutateInPlace(reflect.ValueOf(clone))
+		require.Equal(t, createFuzzVMOutput(data, value, nonce), original)
+	})
+}
+
+func TestClone_NilReceivers(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, (*VMInput)(nil).Clone())
+	assert.Nil(t, (*ContractCallInput)(nil).Clone())
+	assert.Nil(t, (*ContractCreateInput)(nil).Clone())
+	assert.Nil(t, (*DCTTransfer)(nil).Clone())
+	assert.Nil(t, (*ParsedDCTTransfers)(nil).Clone())
+	assert.Nil(t, (*VMOutput)(nil).Clone())
+	assert.Nil(t, (*OutputAccount)(nil).Clone())
+	assert.Nil(t, (*OutputTransfer)(nil).Clone())
+	assert.Nil(t, (*LogEntry)(nil).Clone())
+	assert.Nil(t, (*GasTrace)(nil).Clone())
+}
+
+func TestClone_KeepsNilAndEmptyApart(t *testing.T) {
+	t.Parallel()
+
+	input := &VMInput{Arguments: [][]byte{}, CallerAddr: []byte{}}
+	clone := input.Clone()
+	assert.NotNil(t, clone.Arguments)
+	assert.NotNil(t, clone.CallerAddr)
+	assert.Nil(t, clone.CallValue)
+	assert.Nil(t, clone.DCTTransfers)
+
+	output := &VMOutput{OutputAccounts: map[string]*OutputAccount{}}
+	outputClone := output.Clone()
+	assert.NotNil(t, outputClone.OutputAccounts)
+	assert.Nil(t, outputClone.Logs)
+}
+
+func TestClone_GasTraceIsIndependent(t *testing.T) {
+	t.Parallel()
+
+	input := &ContractCallInput{VMInput: VMInput{GasTrace: NewGasTrace()}}
+	input.GasTrace.Charge("original", 5)
+
+	clone := input.Clone()
+	require.NotNil(t, clone.GasTrace)
+	assert.Equal(t, input.GasTrace.Entries(), clone.GasTrace.Entries())
+
+	clone.GasTrace.Charge("clone", 3)
+	assert.Equal(t, uint64(5), input.GasTrace.Total())
+	assert.Equal(t, uint64(8), clone.GasTrace.Total())
+
+	output := &VMOutput{GasTrace: input.GasTrace}
+	outputClone := output.Clone()
+	outputClone.GasTrace.Charge("clone", 1)
+	assert.Equal(t, 1, len(output.GasTrace.Entries()))
+}
@@ -0,0 +1,144 @@
+package vmcommon
+
+// EnableEpochFlag defines a flag handled by the enable epochs handler
+type EnableEpochFlag string
+
+const (
+	// GlobalMintBurnFlag defines the flag for the global mint burn feature. The flag is enabled before its epoch is reached
+	GlobalMintBurnFlag EnableEpochFlag = "GlobalMintBurnFlag"
+
+	// DCTTransferRoleFlag defines the flag for the DCT transfer role feature
+	DCTTransferRoleFlag EnableEpochFlag = "DCTTransferRoleFlag"
+
+	// BuiltInFunctionsFlag defines the flag for the built-in functions feature
+	BuiltInFunctionsFlag EnableEpochFlag = "BuiltInFunctionsFlag"
+
+	// CheckCorrectTokenIDForTransferRoleFlag defines the flag for the check correct token ID for transfer role feature
+	CheckCorrectTokenIDForTransferRoleFlag EnableEpochFlag = "CheckCorrectTokenIDForTransferRoleFlag"
+
+	// MultiDCTTransferFixOnCallBackFlag defines the flag for the multi DCT transfer fix on callback feature
+	MultiDCTTransferFixOnCallBackFlag EnableEpochFlag = "MultiDCTTransferFixOnCallBackFlag"
+
+	// FixOOGReturnCodeFlag defines the flag for the fix out of gas return code feature
+	FixOOGReturnCodeFlag EnableEpochFlag = "FixOOGReturnCodeFlag"
+
+	// RemoveNonUpdatedStorageFlag defines the flag for the remove non updated storage feature
+	RemoveNonUpdatedStorageFlag EnableEpochFlag = "RemoveNonUpdatedStorageFlag"
+
+	// CreateNFTThroughExecByCallerFlag defines the flag for the create NFT through exec by caller feature
+	CreateNFTThroughExecByCallerFlag EnableEpochFlag = "CreateNFTThroughExecByCallerFlag"
+
+	// StorageAPICostOptimizationFlag defines the flag for the storage API cost optimization feature
+	StorageAPICostOptimizationFlag EnableEpochFlag = "StorageAPICostOptimizationFlag"
+
+	// FailExecutionOnEveryAPIErrorFlag defines the flag for the fail execution on every API error feature
+	FailExecutionOnEveryAPIErrorFlag EnableEpochFlag = "FailExecutionOnEveryAPIErrorFlag"
+
+	// ManagedCryptoAPIsFlag defines the flag for the managed crypto APIs feature
+	ManagedCryptoAPIsFlag EnableEpochFlag = "ManagedCryptoAPIsFlag"
+
+	// SCDeployFlag defines the flag for the smart contract deploy feature
+	SCDeployFlag EnableEpochFlag = "SCDeployFlag"
+
+	// AheadOfTimeGasUsageFlag defines the flag for the ahead of time gas usage feature
+	AheadOfTimeGasUsageFlag EnableEpochFlag = "AheadOfTimeGasUsageFlag"
+
+	// RepairCallbackFlag defines the flag for the repair callback feature
+	RepairCallbackFlag EnableEpochFlag = "RepairCallbackFlag"
+
+	// DisableExecByCallerFlag defines the flag for the disable exec by caller feature
+	DisableExecByCallerFlag EnableEpochFlag = "DisableExecByCallerFlag"
+
+	// RefactorContextFlag defines the flag for the refactor context feature
+	RefactorContextFlag EnableEpochFlag = "RefactorContextFlag"
+
+	// CheckFunctionArgumentFlag defines the flag for the check function argument feature
+	CheckFunctionArgumentFlag EnableEpochFlag = "CheckFunctionArgumentFlag"
+
+	// CheckExecuteOnReadOnlyFlag defines the flag for the check execute on read only feature
+	CheckExecuteOnReadOnlyFlag EnableEpochFlag = "CheckExecuteOnReadOnlyFlag"
+
+	// FixAsyncCallbackCheckFlag defines the flag for the fix async callback check feature
+	FixAsyncCallbackCheckFlag EnableEpochFlag = "FixAsyncCallbackCheckFlag"
+
+	// SaveToSystemAccountFlag defines the flag for the save to system account feature
+	SaveToSystemAccountFlag EnableEpochFlag = "SaveToSystemAccountFlag"
+
+	// CheckFrozenCollectionFlag defines the flag for the check frozen collection feature
+	CheckFrozenCollectionFlag EnableEpochFlag = "CheckFrozenCollectionFlag"
+
+	// SendAlwaysFlag defines the flag for the send always feature
+	SendAlwaysFlag EnableEpochFlag = "SendAlwaysFlag"
+
+	// ValueLengthCheckFlag defines the flag for the value length check feature
+	ValueLengthCheckFlag EnableEpochFlag = "ValueLengthCheckFlag"
+
+	// CheckTransferFlag defines the flag for the check transfer feature
+	CheckTransferFlag EnableEpochFlag = "CheckTransferFlag"
+
+	// TransferToMetaFlag defines the flag for the transfer to meta feature
+	TransferToMetaFlag EnableEpochFlag = "TransferToMetaFlag"
+
+	// DCTNFTImprovementV1Flag defines the flag for the DCT NFT improvement V1 feature
+	DCTNFTImprovementV1Flag EnableEpochFlag = "DCTNFTImprovementV1Flag"
+
+	// FixOldTokenLiquidityFlag defines the flag for the fix old token liquidity feature
+	FixOldTokenLiquidityFlag EnableEpochFlag = "FixOldTokenLiquidityFlag"
+
+	// RuntimeMemStoreLimitFlag defines the flag for the runtime mem store limit feature
+	RuntimeMemStoreLimitFlag EnableEpochFlag = "RuntimeMemStoreLimitFlag"
+
+	// MaxBlockchainHookCountersFlag defines the flag for the max blockchain hook counters feature
+	MaxBlockchainHookCountersFlag EnableEpochFlag = "MaxBlockchainHookCountersFlag"
+
+	// WipeSingleNFTLiquidityDecreaseFlag defines the flag for the wipe single NFT liquidity decrease feature
+	WipeSingleNFTLiquidityDecreaseFlag EnableEpochFlag = "WipeSingleNFTLiquidityDecreaseFlag"
+
+	// AlwaysSaveTokenMetaDataFlag defines the flag for the always save token metadata feature
+	AlwaysSaveTokenMetaDataFlag EnableEpochFlag = "AlwaysSaveTokenMetaDataFlag"
+
+	// RuntimeCodeSizeFixFlag defines the flag for the runtime code size fix feature
+	RuntimeCodeSizeFixFlag EnableEpochFlag = "RuntimeCodeSizeFixFlag"
+
+	// BuiltInFunctionsLogsFlag defines the flag for the built-in functions logs feature
+	BuiltInFunctionsLogsFlag EnableEpochFlag = "BuiltInFunctionsLogsFlag"
+)
+
+// AllEnableEpochFlags returns all the flags known by this package, in definition order
+func AllEnableEpochFlags() []EnableEpochFlag {
+	return []EnableEpochFlag{
+		GlobalMintBurnFlag,
+		DCTTransferRoleFlag,
+		BuiltInFunctionsFlag,
+		CheckCorrectTokenIDForTransferRoleFlag,
+		MultiDCTTransferFixOnCallBackFlag,
+		FixOOGReturnCodeFlag,
+		RemoveNonUpdatedStorageFlag,
+		CreateNFTThroughExecByCallerFlag,
+		StorageAPICostOptimizationFlag,
+		FailExecutionOnEveryAPIErrorFlag,
+		ManagedCryptoAPIsFlag,
+		SCDeployFlag,
+		AheadOfTimeGasUsageFlag,
+		RepairCallbackFlag,
+		DisableExecByCallerFlag,
+		RefactorContextFlag,
+		CheckFunctionArgumentFlag,
+		CheckExecuteOnReadOnlyFlag,
+		FixAsyncCallbackCheckFlag,
+		SaveToSystemAccountFlag,
+		CheckFrozenCollectionFlag,
+		SendAlwaysFlag,
+		ValueLengthCheckFlag,
+		CheckTransferFlag,
+		TransferToMetaFlag,
+		DCTNFTImprovementV1Flag,
+		FixOldTokenLiquidityFlag,
+		RuntimeMemStoreLimitFlag,
+		MaxBlockchainHookCountersFlag,
+		WipeSingleNFTLiquidityDecreaseFlag,
+		AlwaysSaveTokenMetaDataFlag,
+		RuntimeCodeSizeFixFlag,
+		BuiltInFunctionsLogsFlag,
+	}
+}
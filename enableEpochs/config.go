@@ -0,0 +1,40 @@
+package enableEpochs
+
+// EnableEpochs holds the activation epoch of every flag exposed by the enable epochs handler.
+// A flag is enabled starting with its activation epoch, except for the ones named DisableEpoch,
+// which are enabled only before the configured epoch.
+type EnableEpochs struct {
+	GlobalMintBurnDisableEpoch                    uint32
+	DCTTransferRoleEnableEpoch                    uint32
+	BuiltInFunctionsEnableEpoch                   uint32
+	CheckCorrectTokenIDForTransferRoleEnableEpoch uint32
+	MultiDCTTransferFixOnCallBackOnEnableEpoch    uint32
+	FixOOGReturnCodeEnableEpoch                   uint32
+	RemoveNonUpdatedStorageEnableEpoch            uint32
+	CreateNFTThroughExecByCallerEnableEpoch       uint32
+	StorageAPICostOptimizationEnableEpoch         uint32
+	FailExecutionOnEveryAPIErrorEnableEpoch       uint32
+	ManagedCryptoAPIsEnableEpoch                  uint32
+	SCDeployEnableEpoch                           uint32
+	AheadOfTimeGasUsageEnableEpoch                uint32
+	RepairCallbackEnableEpoch                     uint32
+	DisableExecByCallerEnableEpoch                uint32
+	RefactorContextEnableEpoch                    uint32
+	CheckFunctionArgumentEnableEpoch              uint32
+	CheckExecuteOnReadOnlyEnableEpoch             uint32
+	FixAsyncCallbackCheckEnableEpoch              uint32
+	SaveToSystemAccountEnableEpoch                uint32
+	CheckFrozenCollectionEnableEpoch              uint32
+	SendAlwaysEnableEpoch                         uint32
+	ValueLengthCheckEnableEpoch                   uint32
+	CheckTransferEnableEpoch                      uint32
+	TransferToMetaEnableEpoch                     uint32
+	DCTNFTImprovementV1EnableEpoch                uint32
+	FixOldTokenLiquidityEnableEpoch               uint32
+	RuntimeMemStoreLimitEnableEpoch               uint32
+	MaxBlockchainHookCountersEnableEpoch          uint32
+	WipeSingleNFTLiquidityDecreaseEnableEpoch     uint32
+	AlwaysSaveTokenMetaDataEnableEpoch            uint32
+	RuntimeCodeSizeFixEnableEpoch                 uint32
+	BuiltInFunctionsLogsEnableEpoch               uint32
+}
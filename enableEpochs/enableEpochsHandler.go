@@ -0,0 +1,348 @@
+package enableEpochs
+
+import (
+	logger "github.com/kalyan3104/k-core-logger-go"
+	"github.com/kalyan3104/k-core/core/atomic"
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var log = logger.GetOrCreate("enableEpochs")
+
+var _ vmcommon.EnableEpochsHandler = (*enableEpochsHandler)(nil)
+var _ vmcommon.EpochSubscriberHandler = (*enableEpochsHandler)(nil)
+
+type flagHandler struct {
+	isActiveInEpoch func(epoch uint32) bool
+	activationEpoch uint32
+}
+
+// enableEpochsHandler computes all the flags from the confirmed epoch. As the only mutable state is the epoch,
+// which is stored atomically, all the flags change at once when a new epoch is confirmed
+type enableEpochsHandler struct {
+	flagsHandlers map[vmcommon.EnableEpochFlag]flagHandler
+	currentEpoch  atomic.Uint32
+}
+
+// NewEnableEpochsHandler creates a new instance of enableEpochsHandler and registers it to the epoch notifier
+func NewEnableEpochsHandler(enableEpochsConfig EnableEpochs, epochNotifier vmcommon.EpochNotifier) (*enableEpochsHandler, error) {
+	if check.IfNil(epochNotifier) {
+		return nil, ErrNilEpochNotifier
+	}
+
+	handler := &enableEpochsHandler{
+		flagsHandlers: createFlagsHandlers(enableEpochsConfig),
+	}
+
+	epochNotifier.RegisterNotifyHandler(handler)
+
+	return handler, nil
+}
+
+func createFlagsHandlers(cfg EnableEpochs) map[vmcommon.EnableEpochFlag]flagHandler {
+	return map[vmcommon.EnableEpochFlag]flagHandler{
+		vmcommon.GlobalMintBurnFlag:                     enabledBefore(cfg.GlobalMintBurnDisableEpoch),
+		vmcommon.DCTTransferRoleFlag:                    enabledSince(cfg.DCTTransferRoleEnableEpoch),
+		vmcommon.BuiltInFunctionsFlag:                   enabledSince(cfg.BuiltInFunctionsEnableEpoch),
+		vmcommon.CheckCorrectTokenIDForTransferRoleFlag: enabledSince(cfg.CheckCorrectTokenIDForTransferRoleEnableEpoch),
+		vmcommon.MultiDCTTransferFixOnCallBackFlag:      enabledSince(cfg.MultiDCTTransferFixOnCallBackOnEnableEpoch),
+		vmcommon.FixOOGReturnCodeFlag:                   enabledSince(cfg.FixOOGReturnCodeEnableEpoch),
+		vmcommon.RemoveNonUpdatedStorageFlag:            enabledSince(cfg.RemoveNonUpdatedStorageEnableEpoch),
+		vmcommon.CreateNFTThroughExecByCallerFlag:       enabledSince(cfg.CreateNFTThroughExecByCallerEnableEpoch),
+		vmcommon.StorageAPICostOptimizationFlag:         enabledSince(cfg.StorageAPICostOptimizationEnableEpoch),
+		vmcommon.FailExecutionOnEveryAPIErrorFlag:       enabledSince(cfg.FailExecutionOnEveryAPIErrorEnableEpoch),
+		vmcommon.ManagedCryptoAPIsFlag:                  enabledSince(cfg.ManagedCryptoAPIsEnableEpoch),
+		vmcommon.SCDeployFlag:                           enabledSince(cfg.SCDeployEnableEpoch),
+		vmcommon.AheadOfTimeGasUsageFlag:                enabledSince(cfg.AheadOfTimeGasUsageEnableEpoch),
+		vmcommon.RepairCallbackFlag:                     enabledSince(cfg.RepairCallbackEnableEpoch),
+		vmcommon.DisableExecByCallerFlag:                enabledSince(cfg.DisableExecByCallerEnableEpoch),
+		vmcommon.RefactorContextFlag:                    enabledSince(cfg.RefactorContextEnableEpoch),
+		vmcommon.CheckFunctionArgumentFlag:              enabledSince(cfg.CheckFunctionArgumentEnableEpoch),
+		vmcommon.CheckExecuteOnReadOnlyFlag:             enabledSince(cfg.CheckExecuteOnReadOnlyEnableEpoch),
+		vmcommon.FixAsyncCallbackCheckFlag:              enabledSince(cfg.FixAsyncCallbackCheckEnableEpoch),
+		vmcommon.SaveToSystemAccountFlag:                enabledSince(cfg.SaveToSystemAccountEnableEpoch),
+		vmcommon.CheckFrozenCollectionFlag:              enabledSince(cfg.CheckFrozenCollectionEnableEpoch),
+		vmcommon.SendAlwaysFlag:                         enabledSince(cfg.SendAlwaysEnableEpoch),
+		vmcommon.ValueLengthCheckFlag:                   enabledSince(cfg.ValueLengthCheckEnableEpoch),
+		vmcommon.CheckTransferFlag:                      enabledSince(cfg.CheckTransferEnableEpoch),
+		vmcommon.TransferToMetaFlag:                     enabledSince(cfg.TransferToMetaEnableEpoch),
+		vmcommon.DCTNFTImprovementV1Flag:                enabledSince(cfg.DCTNFTImprovementV1EnableEpoch),
+		vmcommon.FixOldTokenLiquidityFlag:               enabledSince(cfg.FixOldTokenLiquidityEnableEpoch),
+		vmcommon.RuntimeMemStoreLimitFlag:               enabledSince(cfg.RuntimeMemStoreLimitEnableEpoch),
+		vmcommon.MaxBlockchainHookCountersFlag:          enabledSince(cfg.MaxBlockchainHookCountersEnableEpoch),
+		vmcommon.WipeSingleNFTLiquidityDecreaseFlag:     enabledSince(cfg.WipeSingleNFTLiquidityDecreaseEnableEpoch),
+		vmcommon.AlwaysSaveTokenMetaDataFlag:            enabledSince(cfg.AlwaysSaveTokenMetaDataEnableEpoch),
+		vmcommon.RuntimeCodeSizeFixFlag:                 enabledSince(cfg.RuntimeCodeSizeFixEnableEpoch),
+		vmcommon.BuiltInFunctionsLogsFlag:               enabledSince(cfg.BuiltInFunctionsLogsEnableEpoch),
+	}
+}
+
+func enabledSince(activationEpoch uint32) flagHandler {
+	return flagHandler{
+		isActiveInEpoch: func(epoch uint32) bool {
+			return epoch >= activationEpoch
+		},
+		activationEpoch: activationEpoch,
+	}
+}
+
+// enabledBefore creates the handler of a flag which is active from the genesis up to the deactivation epoch, so
+// its activation epoch is 0
+func enabledBefore(deactivationEpoch uint32) flagHandler {
+	return flagHandler{
+		isActiveInEpoch: func(epoch uint32) bool {
+			return epoch < deactivationEpoch
+		},
+		activationEpoch: 0,
+	}
+}
+
+// EpochConfirmed is called whenever a new epoch is confirmed
+func (handler *enableEpochsHandler) EpochConfirmed(epoch uint32, _ uint64) {
+	handler.currentEpoch.Set(epoch)
+	log.Debug("enableEpochsHandler: epoch confirmed", "epoch", epoch)
+}
+
+// IsFlagDefined returns true if the provided flag is known by the handler
+func (handler *enableEpochsHandler) IsFlagDefined(flag vmcommon.EnableEpochFlag) bool {
+	_, found := handler.flagsHandlers[flag]
+	return found
+}
+
+// IsFlagEnabled returns true if the provided flag is enabled in the current epoch. An undefined flag is never enabled
+func (handler *enableEpochsHandler) IsFlagEnabled(flag vmcommon.EnableEpochFlag) bool {
+	fh, found := handler.flagsHandlers[flag]
+	if !found {
+		log.Error("IsFlagEnabled: programming error, flag is not defined", "flag", flag)
+		return false
+	}
+
+	return fh.isActiveInEpoch(handler.currentEpoch.Get())
+}
+
+// GetActivationEpoch returns the first epoch the provided flag is enabled in. The flags that are enabled only before
+// their configured epoch, as GlobalMintBurnFlag, are enabled since the genesis, so 0 is returned for them
+func (handler *enableEpochsHandler) GetActivationEpoch(flag vmcommon.EnableEpochFlag) uint32 {
+	fh, found := handler.flagsHandlers[flag]
+	if !found {
+		log.Error("GetActivationEpoch: programming error, flag is not defined", "flag", flag)
+		return 0
+	}
+
+	return fh.activationEpoch
+}
+
+// IsGlobalMintBurnFlagEnabled returns true if global mint burn flag is enabled
+func (handler *enableEpochsHandler) IsGlobalMintBurnFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.GlobalMintBurnFlag)
+}
+
+// IsDCTTransferRoleFlagEnabled returns true if DCT transfer role flag is enabled
+func (handler *enableEpochsHandler) IsDCTTransferRoleFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.DCTTransferRoleFlag)
+}
+
+// IsBuiltInFunctionsFlagEnabled returns true if built-in functions flag is enabled
+func (handler *enableEpochsHandler) IsBuiltInFunctionsFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.BuiltInFunctionsFlag)
+}
+
+// IsCheckCorrectTokenIDForTransferRoleFlagEnabled returns true if check correct token ID for transfer role flag is enabled
+func (handler *enableEpochsHandler) IsCheckCorrectTokenIDForTransferRoleFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.CheckCorrectTokenIDForTransferRoleFlag)
+}
+
+// IsMultiDCTTransferFixOnCallBackFlagEnabled returns true if multi DCT transfer fix on callback flag is enabled
+func (handler *enableEpochsHandler) IsMultiDCTTransferFixOnCallBackFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.MultiDCTTransferFixOnCallBackFlag)
+}
+
+// IsFixOOGReturnCodeFlagEnabled returns true if fix out of gas return code flag is enabled
+func (handler *enableEpochsHandler) IsFixOOGReturnCodeFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.FixOOGReturnCodeFlag)
+}
+
+// IsRemoveNonUpdatedStorageFlagEnabled returns true if remove non updated storage flag is enabled
+func (handler *enableEpochsHandler) IsRemoveNonUpdatedStorageFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.RemoveNonUpdatedStorageFlag)
+}
+
+// IsCreateNFTThroughExecByCallerFlagEnabled returns true if create NFT through exec by caller flag is enabled
+func (handler *enableEpochsHandler) IsCreateNFTThroughExecByCallerFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.CreateNFTThroughExecByCallerFlag)
+}
+
+// IsStorageAPICostOptimizationFlagEnabled returns true if storage API cost optimization flag is enabled
+func (handler *enableEpochsHandler) IsStorageAPICostOptimizationFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.StorageAPICostOptimizationFlag)
+}
+
+// IsFailExecutionOnEveryAPIErrorFlagEnabled returns true if fail execution on every API error flag is enabled
+func (handler *enableEpochsHandler) IsFailExecutionOnEveryAPIErrorFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.FailExecutionOnEveryAPIErrorFlag)
+}
+
+// IsManagedCryptoAPIsFlagEnabled returns true if managed crypto APIs flag is enabled
+func (handler *enableEpochsHandler) IsManagedCryptoAPIsFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.ManagedCryptoAPIsFlag)
+}
+
+// IsSCDeployFlagEnabled returns true if SC deploy flag is enabled
+func (handler *enableEpochsHandler) IsSCDeployFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.SCDeployFlag)
+}
+
+// IsAheadOfTimeGasUsageFlagEnabled returns true if ahead of time gas usage flag is enabled
+func (handler *enableEpochsHandler) IsAheadOfTimeGasUsageFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.AheadOfTimeGasUsageFlag)
+}
+
+// IsRepairCallbackFlagEnabled returns true if repair callback flag is enabled
+func (handler *enableEpochsHandler) IsRepairCallbackFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.RepairCallbackFlag)
+}
+
+// IsDisableExecByCallerFlagEnabled returns true if disable exec by caller flag is enabled
+func (handler *enableEpochsHandler) IsDisableExecByCallerFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.DisableExecByCallerFlag)
+}
+
+// IsRefactorContextFlagEnabled returns true if refactor context flag is enabled
+func (handler *enableEpochsHandler) IsRefactorContextFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.RefactorContextFlag)
+}
+
+// IsCheckFunctionArgumentFlagEnabled returns true if check function argument flag is enabled
+func (handler *enableEpochsHandler) IsCheckFunctionArgumentFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.CheckFunctionArgumentFlag)
+}
+
+// IsCheckExecuteOnReadOnlyFlagEnabled returns true if check execute on read only flag is enabled
+func (handler *enableEpochsHandler) IsCheckExecuteOnReadOnlyFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.CheckExecuteOnReadOnlyFlag)
+}
+
+// IsFixAsyncCallbackCheckFlagEnabled returns true if fix async callback check flag is enabled
+func (handler *enableEpochsHandler) IsFixAsyncCallbackCheckFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.FixAsyncCallbackCheckFlag)
+}
+
+// IsSaveToSystemAccountFlagEnabled returns true if save to system account flag is enabled
+func (handler *enableEpochsHandler) IsSaveToSystemAccountFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.SaveToSystemAccountFlag)
+}
+
+// IsCheckFrozenCollectionFlagEnabled returns true if check frozen collection flag is enabled
+func (handler *enableEpochsHandler) IsCheckFrozenCollectionFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.CheckFrozenCollectionFlag)
+}
+
+// IsSendAlwaysFlagEnabled returns true if send always flag is enabled
+func (handler *enableEpochsHandler) IsSendAlwaysFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.SendAlwaysFlag)
+}
+
+// IsValueLengthCheckFlagEnabled returns true if value length check flag is enabled
+func (handler *enableEpochsHandler) IsValueLengthCheckFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.ValueLengthCheckFlag)
+}
+
+// IsCheckTransferFlagEnabled returns true if check transfer flag is enabled
+func (handler *enableEpochsHandler) IsCheckTransferFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.CheckTransferFlag)
+}
+
+// IsTransferToMetaFlagEnabled returns true if transfer to meta flag is enabled
+func (handler *enableEpochsHandler) IsTransferToMetaFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.TransferToMetaFlag)
+}
+
+// IsDCTNFTImprovementV1FlagEnabled returns true if DCT NFT improvement V1 flag is enabled
+func (handler *enableEpochsHandler) IsDCTNFTImprovementV1FlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.DCTNFTImprovementV1Flag)
+}
+
+// IsFixOldTokenLiquidityEnabled returns true if fix old token liquidity flag is enabled
+func (handler *enableEpochsHandler) IsFixOldTokenLiquidityEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.FixOldTokenLiquidityFlag)
+}
+
+// IsRuntimeMemStoreLimitEnabled returns true if runtime mem store limit flag is enabled
+func (handler *enableEpochsHandler) IsRuntimeMemStoreLimitEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.RuntimeMemStoreLimitFlag)
+}
+
+// IsMaxBlockchainHookCountersFlagEnabled returns true if max blockchain hook counters flag is enabled
+func (handler *enableEpochsHandler) IsMaxBlockchainHookCountersFlagEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.MaxBlockchainHookCountersFlag)
+}
+
+// IsWipeSingleNFTLiquidityDecreaseEnabled returns true if wipe single NFT liquidity decrease flag is enabled
+func (handler *enableEpochsHandler) IsWipeSingleNFTLiquidityDecreaseEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.WipeSingleNFTLiquidityDecreaseFlag)
+}
+
+// IsAlwaysSaveTokenMetaDataEnabled returns true if always save token metadata flag is enabled
+func (handler *enableEpochsHandler) IsAlwaysSaveTokenMetaDataEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.AlwaysSaveTokenMetaDataFlag)
+}
+
+// IsRuntimeCodeSizeFixEnabled returns true if runtime code size fix flag is enabled
+func (handler *enableEpochsHandler) IsRuntimeCodeSizeFixEnabled() bool {
+	return handler.IsFlagEnabled(vmcommon.RuntimeCodeSizeFixFlag)
+}
+
+// MultiDCTTransferAsyncCallBackEnableEpoch returns the epoch when multi DCT transfer async callback becomes active
+func (handler *enableEpochsHandler) MultiDCTTransferAsyncCallBackEnableEpoch() uint32 {
+	return handler.GetActivationEpoch(vmcommon.MultiDCTTransferFixOnCallBackFlag)
+}
+
+// FixOOGReturnCodeEnableEpoch returns the epoch when fix out of gas return code becomes active
+func (handler *enableEpochsHandler) FixOOGReturnCodeEnableEpoch() uint32 {
+	return handler.GetActivationEpoch(vmcommon.FixOOGReturnCodeFlag)
+}
+
+// RemoveNonUpdatedStorageEnableEpoch returns the epoch when remove non updated storage becomes active
+func (handler *enableEpochsHandler) RemoveNonUpdatedStorageEnableEpoch() uint32 {
+	return handler.GetActivationEpoch(vmcommon.RemoveNonUpdatedStorageFlag)
+}
+
+// CreateNFTThroughExecByCallerEnableEpoch returns the epoch when create NFT through exec by caller becomes active
+func (handler *enableEpochsHandler) CreateNFTThroughExecByCallerEnableEpoch() uint32 {
+	return handler.GetActivationEpoch(vmcommon.CreateNFTThroughExecByCallerFlag)
+}
+
+// FixFailExecutionOnErrorEnableEpoch returns the epoch when fix fail execution on error becomes active
+func (handler *enableEpochsHandler) FixFailExecutionOnErrorEnableEpoch() uint32 {
+	return handler.GetActivationEpoch(vmcommon.FailExecutionOnEveryAPIErrorFlag)
+}
+
+// ManagedCryptoAPIEnableEpoch returns the epoch when managed crypto API becomes active
+func (handler *enableEpochsHandler) ManagedCryptoAPIEnableEpoch() uint32 {
+	return handler.GetActivationEpoch(vmcommon.ManagedCryptoAPIsFlag)
+}
+
+// DisableExecByCallerEnableEpoch returns the epoch when disable exec by caller becomes active
+func (handler *enableEpochsHandler) DisableExecByCallerEnableEpoch() uint32 {
+	return handler.GetActivationEpoch(vmcommon.DisableExecByCallerFlag)
+}
+
+// RefactorContextEnableEpoch returns the epoch when refactor context becomes active
+func (handler *enableEpochsHandler) RefactorContextEnableEpoch() uint32 {
+	return handler.GetActivationEpoch(vmcommon.RefactorContextFlag)
+}
+
+// CheckExecuteReadOnlyEnableEpoch returns the epoch when check execute read only becomes active
+func (handler *enableEpochsHandler) CheckExecuteReadOnlyEnableEpoch() uint32 {
+	return handler.GetActivationEpoch(vmcommon.CheckExecuteOnReadOnlyFlag)
+}
+
+// StorageAPICostOptimizationEnableEpoch returns the epoch when storage API cost optimization becomes active
+func (handler *enableEpochsHandler) StorageAPICostOptimizationEnableEpoch() uint32 {
+	return handler.GetActivationEpoch(vmcommon.StorageAPICostOptimizationFlag)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *enableEpochsHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
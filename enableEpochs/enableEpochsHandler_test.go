@@ -0,0 +1,160 @@
+package enableEpochs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createEnableEpochsConfig(epoch uint32) EnableEpochs {
+	cfg := EnableEpochs{}
+	cfgValue := reflect.ValueOf(&cfg).Elem()
+	for i := 0; i < cfgValue.NumField(); i++ {
+		cfgValue.Field(i).SetUint(uint64(epoch))
+	}
+
+	return cfg
+}
+
+func checkAllFlags(t *testing.T, handler *enableEpochsHandler, expectedEnabled bool) {
+	for _, flag := range vmcommon.AllEnableEpochFlags() {
+		expected := expectedEnabled
+		if flag == vmcommon.GlobalMintBurnFlag {
+			expected = !expectedEnabled
+		}
+
+		assert.True(t, handler.IsFlagDefined(flag), flag)
+		assert.Equal(t, expected, handler.IsFlagEnabled(flag), flag)
+	}
+
+	handlerValue := reflect.ValueOf(handler)
+	handlerType := handlerValue.Type()
+	numLegacyFlags := 0
+	for i := 0; i < handlerType.NumMethod(); i++ {
+		method := handlerType.Method(i)
+		isLegacyFlagMethod := strings.HasPrefix(method.Name, "Is") && method.Type.NumIn() == 1 && method.Name != "IsInterfaceNil"
+		if !isLegacyFlagMethod {
+			continue
+		}
+
+		numLegacyFlags++
+		expected := expectedEnabled
+		if method.Name == "IsGlobalMintBurnFlagEnabled" {
+			expected = !expectedEnabled
+		}
+
+		result := handlerValue.Method(i).Call(nil)[0].Bool()
+		assert.Equal(t, expected, result, method.Name)
+	}
+
+	// flags added after the legacy methods were deprecated are only queried through IsFlagEnabled
+	flagsWithoutLegacyMethod := []vmcommon.EnableEpochFlag{vmcommon.BuiltInFunctionsLogsFlag}
+	require.Equal(t, len(vmcommon.AllEnableEpochFlags())-len(flagsWithoutLegacyMethod), numLegacyFlags)
+}
+
+func TestNewEnableEpochsHandler(t *testing.T) {
+	t.Parallel()
+
+	handler, err := NewEnableEpochsHandler(EnableEpochs{}, nil)
+	assert.Equal(t, ErrNilEpochNotifier, err)
+	assert.True(t, check.IfNil(handler))
+
+	notifier := NewEpochNotifier()
+	handler, err = NewEnableEpochsHandler(EnableEpochs{}, notifier)
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(handler))
+	assert.Equal(t, 1, notifier.NumHandlers())
+}
+
+func TestEnableEpochsHandler_EpochConfirmedShouldFlipFlags(t *testing.T) {
+	t.Parallel()
+
+	notifier := NewEpochNotifier()
+	handler, _ := NewEnableEpochsHandler(createEnableEpochsConfig(5), notifier)
+
+	notifier.CheckEpoch(4, 0)
+	checkAllFlags(t, handler, false)
+
+	notifier.CheckEpoch(5, 0)
+	checkAllFlags(t, handler, true)
+
+	notifier.CheckEpoch(6, 0)
+	checkAllFlags(t, handler, true)
+
+	notifier.CheckEpoch(3, 0)
+	checkAllFlags(t, handler, false)
+}
+
+func TestEnableEpochsHandler_EpochGetters(t *testing.T) {
+	t.Parallel()
+
+	cfg := EnableEpochs{
+		MultiDCTTransferFixOnCallBackOnEnableEpoch: 1,
+		FixOOGReturnCodeEnableEpoch:                2,
+		RemoveNonUpdatedStorageEnableEpoch:         3,
+		CreateNFTThroughExecByCallerEnableEpoch:    4,
+		FailExecutionOnEveryAPIErrorEnableEpoch:    5,
+		ManagedCryptoAPIsEnableEpoch:               6,
+		DisableExecByCallerEnableEpoch:             7,
+		RefactorContextEnableEpoch:                 8,
+		CheckExecuteOnReadOnlyEnableEpoch:          9,
+		StorageAPICostOptimizationEnableEpoch:      10,
+	}
+	handler, _ := NewEnableEpochsHandler(cfg, NewEpochNotifier())
+
+	assert.Equal(t, uint32(1), handler.MultiDCTTransferAsyncCallBackEnableEpoch())
+	assert.Equal(t, uint32(2), handler.FixOOGReturnCodeEnableEpoch())
+	assert.Equal(t, uint32(3), handler.RemoveNonUpdatedStorageEnableEpoch())
+	assert.Equal(t, uint32(4), handler.CreateNFTThroughExecByCallerEnableEpoch())
+	assert.Equal(t, uint32(5), handler.FixFailExecutionOnErrorEnableEpoch())
+	assert.Equal(t, uint32(6), handler.ManagedCryptoAPIEnableEpoch())
+	assert.Equal(t, uint32(7), handler.DisableExecByCallerEnableEpoch())
+	assert.Equal(t, uint32(8), handler.RefactorContextEnableEpoch())
+	assert.Equal(t, uint32(9), handler.CheckExecuteReadOnlyEnableEpoch())
+	assert.Equal(t, uint32(10), handler.StorageAPICostOptimizationEnableEpoch())
+}
+
+func TestEnableEpochsHandler_GenericFlagAPI(t *testing.T) {
+	t.Parallel()
+
+	handler, _ := NewEnableEpochsHandler(EnableEpochs{
+		GlobalMintBurnDisableEpoch:     3,
+		SaveToSystemAccountEnableEpoch: 5,
+	}, NewEpochNotifier())
+
+	undefinedFlag := vmcommon.EnableEpochFlag("undefined flag")
+	assert.False(t, handler.IsFlagDefined(undefinedFlag))
+	assert.False(t, handler.IsFlagEnabled(undefinedFlag))
+	assert.Equal(t, uint32(0), handler.GetActivationEpoch(undefinedFlag))
+
+	assert.Equal(t, uint32(0), handler.GetActivationEpoch(vmcommon.GlobalMintBurnFlag))
+	assert.Equal(t, uint32(5), handler.GetActivationEpoch(vmcommon.SaveToSystemAccountFlag))
+
+	handler.EpochConfirmed(4, 0)
+	assert.False(t, handler.IsFlagEnabled(vmcommon.GlobalMintBurnFlag))
+	assert.False(t, handler.IsFlagEnabled(vmcommon.SaveToSystemAccountFlag))
+	assert.False(t, handler.IsSaveToSystemAccountFlagEnabled())
+
+	handler.EpochConfirmed(5, 0)
+	assert.True(t, handler.IsFlagEnabled(vmcommon.SaveToSystemAccountFlag))
+	assert.True(t, handler.IsSaveToSystemAccountFlagEnabled())
+}
+
+func TestEnableEpochsHandler_LegacyMethodsThroughInterface(t *testing.T) {
+	t.Parallel()
+
+	var handler vmcommon.EnableEpochsHandler
+	handler, _ = NewEnableEpochsHandler(EnableEpochs{
+		GlobalMintBurnDisableEpoch:  3,
+		FixOOGReturnCodeEnableEpoch: 2,
+	}, NewEpochNotifier())
+
+	assert.True(t, handler.IsGlobalMintBurnFlagEnabled())
+	assert.False(t, handler.IsFixOOGReturnCodeFlagEnabled())
+	assert.Equal(t, uint32(2), handler.FixOOGReturnCodeEnableEpoch())
+}
@@ -0,0 +1,109 @@
+package enableEpochs
+
+import (
+	"sync"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.EpochNotifier = (*epochNotifier)(nil)
+
+// epochNotifier keeps the registered handlers and notifies them, in registration order, whenever a new epoch
+// is confirmed
+type epochNotifier struct {
+	mutNotify        sync.Mutex
+	mutHandlers      sync.RWMutex
+	handlers         []vmcommon.EpochSubscriberHandler
+	mutEpoch         sync.RWMutex
+	currentEpoch     uint32
+	currentTimestamp uint64
+	epochWasSet      bool
+}
+
+// NewEpochNotifier creates a new instance of epochNotifier
+func NewEpochNotifier() *epochNotifier {
+	return &epochNotifier{
+		handlers: make([]vmcommon.EpochSubscriberHandler, 0),
+	}
+}
+
+// RegisterNotifyHandler registers a new handler. The handler is immediately notified with the current epoch,
+// so it can set its internal state. The notification is serialized with CheckEpoch, so the handler can not
+// receive a stale epoch after a newer one
+func (en *epochNotifier) RegisterNotifyHandler(handler vmcommon.EpochSubscriberHandler) {
+	if check.IfNil(handler) {
+		return
+	}
+
+	en.mutNotify.Lock()
+	defer en.mutNotify.Unlock()
+
+	en.mutHandlers.Lock()
+	en.handlers = append(en.handlers, handler)
+	en.mutHandlers.Unlock()
+
+	en.mutEpoch.RLock()
+	epoch, timestamp := en.currentEpoch, en.currentTimestamp
+	en.mutEpoch.RUnlock()
+
+	handler.EpochConfirmed(epoch, timestamp)
+}
+
+// CheckEpoch notifies all the registered handlers, in registration order, if the provided epoch differs from
+// the current one
+func (en *epochNotifier) CheckEpoch(epoch uint32, timestamp uint64) {
+	en.mutNotify.Lock()
+	defer en.mutNotify.Unlock()
+
+	en.mutEpoch.Lock()
+	shouldSkip := en.epochWasSet && en.currentEpoch == epoch
+	if shouldSkip {
+		en.mutEpoch.Unlock()
+		return
+	}
+
+	en.epochWasSet = true
+	en.currentEpoch = epoch
+	en.currentTimestamp = timestamp
+	en.mutEpoch.Unlock()
+
+	log.Debug("epochNotifier: new epoch confirmed", "epoch", epoch, "timestamp", timestamp)
+
+	en.mutHandlers.RLock()
+	handlersCopy := make([]vmcommon.EpochSubscriberHandler, len(en.handlers))
+	copy(handlersCopy, en.handlers)
+	en.mutHandlers.RUnlock()
+
+	for _, handler := range handlersCopy {
+		handler.EpochConfirmed(epoch, timestamp)
+	}
+}
+
+// CurrentEpoch returns the last confirmed epoch
+func (en *epochNotifier) CurrentEpoch() uint32 {
+	en.mutEpoch.RLock()
+	defer en.mutEpoch.RUnlock()
+
+	return en.currentEpoch
+}
+
+// NumHandlers returns the number of registered handlers
+func (en *epochNotifier) NumHandlers() int {
+	en.mutHandlers.RLock()
+	defer en.mutHandlers.RUnlock()
+
+	return len(en.handlers)
+}
+
+// UnRegisterAll removes all the registered handlers
+func (en *epochNotifier) UnRegisterAll() {
+	en.mutHandlers.Lock()
+	en.handlers = make([]vmcommon.EpochSubscriberHandler, 0)
+	en.mutHandlers.Unlock()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (en *epochNotifier) IsInterfaceNil() bool {
+	return en == nil
+}
@@ -0,0 +1,120 @@
+package enableEpochs
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core/check"
+	"github.com/kalyan3104/k-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEpochNotifier(t *testing.T) {
+	t.Parallel()
+
+	notifier := NewEpochNotifier()
+	assert.False(t, check.IfNil(notifier))
+	assert.Equal(t, 0, notifier.NumHandlers())
+}
+
+func TestEpochNotifier_RegisterNotifyHandler(t *testing.T) {
+	t.Parallel()
+
+	notifier := NewEpochNotifier()
+	notifier.RegisterNotifyHandler(nil)
+	assert.Equal(t, 0, notifier.NumHandlers())
+
+	notifier.CheckEpoch(4, 100)
+
+	confirmedEpoch := uint32(0)
+	confirmedTimestamp := uint64(0)
+	notifier.RegisterNotifyHandler(&mock.EpochSubscriberHandlerStub{
+		EpochConfirmedCalled: func(epoch uint32, timestamp uint64) {
+			confirmedEpoch = epoch
+			confirmedTimestamp = timestamp
+		},
+	})
+	assert.Equal(t, 1, notifier.NumHandlers())
+	assert.Equal(t, uint32(4), confirmedEpoch)
+	assert.Equal(t, uint64(100), confirmedTimestamp)
+
+	notifier.UnRegisterAll()
+	assert.Equal(t, 0, notifier.NumHandlers())
+}
+
+func TestEpochNotifier_CheckEpochShouldNotifyInRegistrationOrder(t *testing.T) {
+	t.Parallel()
+
+	notifier := NewEpochNotifier()
+	calls := make([]int, 0)
+	for i := 0; i < 5; i++ {
+		index := i
+		notifier.RegisterNotifyHandler(&mock.EpochSubscriberHandlerStub{
+			EpochConfirmedCalled: func(epoch uint32, _ uint64) {
+				if epoch == 0 {
+					return
+				}
+				calls = append(calls, index)
+			},
+		})
+	}
+
+	notifier.CheckEpoch(1, 0)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, calls)
+	assert.Equal(t, uint32(1), notifier.CurrentEpoch())
+
+	notifier.CheckEpoch(1, 10)
+	assert.Len(t, calls, 5, "same epoch should not notify again")
+
+	notifier.CheckEpoch(2, 20)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 0, 1, 2, 3, 4}, calls)
+}
+
+func TestEpochNotifier_CheckEpochFirstCallShouldNotify(t *testing.T) {
+	t.Parallel()
+
+	notifier := NewEpochNotifier()
+	numCalls := 0
+	notifier.RegisterNotifyHandler(&mock.EpochSubscriberHandlerStub{
+		EpochConfirmedCalled: func(_ uint32, _ uint64) {
+			numCalls++
+		},
+	})
+
+	notifier.CheckEpoch(0, 0)
+	assert.Equal(t, 2, numCalls)
+}
+
+func TestEpochNotifier_ConcurrentRegisterAndCheckEpochShouldDeliverLatestEpochLast(t *testing.T) {
+	t.Parallel()
+
+	notifier := NewEpochNotifier()
+	numHandlers := 100
+	numEpochs := uint32(100)
+	lastEpochs := make([]uint32, numHandlers)
+
+	wg := sync.WaitGroup{}
+	wg.Add(numHandlers + 1)
+	go func() {
+		for epoch := uint32(1); epoch <= numEpochs; epoch++ {
+			notifier.CheckEpoch(epoch, uint64(epoch))
+		}
+		wg.Done()
+	}()
+	for i := 0; i < numHandlers; i++ {
+		go func(index int) {
+			notifier.RegisterNotifyHandler(&mock.EpochSubscriberHandlerStub{
+				EpochConfirmedCalled: func(epoch uint32, _ uint64) {
+					assert.GreaterOrEqual(t, epoch, lastEpochs[index])
+					lastEpochs[index] = epoch
+				},
+			})
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, lastEpoch := range lastEpochs {
+		assert.Equal(t, numEpochs, lastEpoch)
+	}
+}
@@ -0,0 +1,6 @@
+package enableEpochs
+
+import "errors"
+
+// ErrNilEpochNotifier signals that a nil epoch notifier has been provided
+var ErrNilEpochNotifier = errors.New("nil epoch notifier")
@@ -4,3 +4,57 @@ import "errors"
 
 // ErrSubtractionOverflow signals that uint64 subtraction overflowed
 var ErrSubtractionOverflow = errors.New("uint64 subtraction overflowed")
+
+// ErrNilVMOutput signals that a nil vm output was provided
+var ErrNilVMOutput = errors.New("nil vm output")
+
+// ErrNilContractCallInput signals that a nil contract call input was provided
+var ErrNilContractCallInput = errors.New("nil contract call input")
+
+// ErrGasRemainingExceedsGasProvided signals that the vm output returns more gas than the input provided
+var ErrGasRemainingExceedsGasProvided = errors.New("gas remaining exceeds gas provided")
+
+// ErrBalanceDeltasMismatch signals that the balance deltas of the output accounts do not match the call value and the fees
+var ErrBalanceDeltasMismatch = errors.New("balance deltas do not sum to the call value minus the fees")
+
+// ErrNegativeBalance signals that an account balance would become negative
+var ErrNegativeBalance = errors.New("negative balance")
+
+// ErrStorageUpdateKeyMismatch signals that a storage update is not indexed under its offset
+var ErrStorageUpdateKeyMismatch = errors.New("storage update key does not match its offset")
+
+// ErrNilOutputTransferValue signals that an output transfer has a nil value
+var ErrNilOutputTransferValue = errors.New("nil output transfer value")
+
+// ErrInvalidOutputTransferCallType signals that an output transfer has an unknown call type
+var ErrInvalidOutputTransferCallType = errors.New("invalid output transfer call type")
+
+// ErrProtectedKeyWrite signals that a storage update writes under a protected key
+var ErrProtectedKeyWrite = errors.New("write under protected key")
+
+// ErrNilContractCreateInput signals that a nil contract create input was provided
+var ErrNilContractCreateInput = errors.New("nil contract create input")
+
+// ErrNilCallValue signals that the input has a nil call value
+var ErrNilCallValue = errors.New("nil call value")
+
+// ErrNegativeCallValue signals that the input has a negative call value
+var ErrNegativeCallValue = errors.New("negative call value")
+
+// ErrInvalidAddressLength signals that an address of the input has an invalid length
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrGasLockedExceedsGasProvided signals that the input locks more gas than it provides
+var ErrGasLockedExceedsGasProvided = errors.New("gas locked exceeds gas provided")
+
+// ErrTooManyArguments signals that the input has more arguments than allowed
+var ErrTooManyArguments = errors.New("too many arguments")
+
+// ErrArgumentsTooLarge signals that the arguments of the input hold more bytes than allowed
+var ErrArgumentsTooLarge = errors.New("arguments too large")
+
+// ErrInvalidDCTTransfer signals that a dct transfer of the input is malformed
+var ErrInvalidDCTTransfer = errors.New("invalid dct transfer")
+
+// ErrInvalidCallType signals that the input has an unknown call type
+var ErrInvalidCallType = errors.New("invalid call type")
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"fmt"
+	"math/big"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+const numBaseTopics = 3
+
+// tokenLogEntry is the layout shared by all the built-in function events: the address of the account which
+// emitted the event, followed by the topics token identifier, nonce, value and the event specific topics
+type tokenLogEntry struct {
+	address     []byte
+	tokenID     []byte
+	nonce       uint64
+	value       *big.Int
+	extraTopics [][]byte
+}
+
+func newTokenLogEntry(identifier string, address []byte, tokenID []byte, nonce uint64, value *big.Int, extraTopics ...[]byte) *vmcommon.LogEntry {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	entry := &vmcommon.LogEntry{
+		Identifier: []byte(identifier),
+		Address:    address,
+		Topics:     [][]byte{tokenID, big.NewInt(0).SetUint64(nonce).Bytes(), value.Bytes()},
+	}
+	entry.Topics = append(entry.Topics, extraTopics...)
+
+	return entry
+}
+
+// decodeTokenLogEntry splits the log entry in its common layout. The entry must hold exactly numExtraTopics event
+// specific topics, or at least numExtraTopics if the event has a variable list of topics
+func decodeTokenLogEntry(entry *vmcommon.LogEntry, numExtraTopics int, isVariadic bool) (*tokenLogEntry, error) {
+	numTopics := len(entry.Topics)
+	minNumTopics := numBaseTopics + numExtraTopics
+	isInvalidNumTopics := numTopics < minNumTopics || (!isVariadic && numTopics != minNumTopics)
+	if isInvalidNumTopics {
+		return nil, fmt.Errorf("%w for %s: expected %d, got %d", ErrInvalidNumberOfTopics, entry.Identifier, minNumTopics, numTopics)
+	}
+
+	nonce, err := decodeUint64Topic(string(entry.Identifier), entry.Topics[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenLogEntry{
+		address:     entry.Address,
+		tokenID:     entry.Topics[0],
+		nonce:       nonce,
+		value:       big.NewInt(0).SetBytes(entry.Topics[2]),
+		extraTopics: entry.Topics[numBaseTopics:],
+	}, nil
+}
+
+func decodeUint64Topic(identifier string, topic []byte) (uint64, error) {
+	value := big.NewInt(0).SetBytes(topic)
+	if !value.IsUint64() {
+		return 0, fmt.Errorf("%w for %s: %x does not fit in 64 bits", ErrInvalidTopic, identifier, topic)
+	}
+
+	return value.Uint64(), nil
+}
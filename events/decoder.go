@@ -0,0 +1,294 @@
+package events
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/kalyan3104/k-core/core"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+type decodeTokenEntryFunc func(identifier string, entry *tokenLogEntry) (Event, error)
+
+type decodeEntryFunc func(entry *vmcommon.LogEntry) (Event, error)
+
+var eventDecoders = map[string]decodeEntryFunc{
+	core.BuiltInFunctionDCTTransfer:                      tokenEventDecoder(1, false, decodeDCTTransfer),
+	core.BuiltInFunctionDCTNFTTransfer:                   tokenEventDecoder(1, false, decodeDCTNFTTransfer),
+	core.BuiltInFunctionMultiDCTNFTTransfer:              tokenEventDecoder(1, false, decodeMultiDCTNFTTransfer),
+	core.BuiltInFunctionDCTBurn:                          tokenEventDecoder(0, false, decodeDCTSupplyChange),
+	core.BuiltInFunctionDCTLocalBurn:                     tokenEventDecoder(0, false, decodeDCTSupplyChange),
+	core.BuiltInFunctionDCTLocalMint:                     tokenEventDecoder(0, false, decodeDCTSupplyChange),
+	core.BuiltInFunctionDCTNFTAddQuantity:                tokenEventDecoder(0, false, decodeDCTSupplyChange),
+	core.BuiltInFunctionDCTNFTBurn:                       tokenEventDecoder(0, false, decodeDCTSupplyChange),
+	core.BuiltInFunctionDCTNFTCreate:                     tokenEventDecoder(1, false, decodeDCTNFTCreate),
+	core.BuiltInFunctionDCTNFTAddURI:                     tokenEventDecoder(0, true, decodeDCTNFTAddURI),
+	core.BuiltInFunctionDCTNFTUpdateAttributes:           tokenEventDecoder(1, false, decodeDCTNFTUpdateAttributes),
+	core.BuiltInFunctionDCTFreeze:                        tokenEventDecoder(1, false, decodeDCTFreezeWipe),
+	core.BuiltInFunctionDCTUnFreeze:                      tokenEventDecoder(1, false, decodeDCTFreezeWipe),
+	core.BuiltInFunctionDCTWipe:                          tokenEventDecoder(1, false, decodeDCTFreezeWipe),
+	core.BuiltInFunctionSetDCTRole:                       tokenEventDecoder(0, true, decodeDCTRoles),
+	core.BuiltInFunctionUnSetDCTRole:                     tokenEventDecoder(0, true, decodeDCTRoles),
+	core.BuiltInFunctionDCTNFTCreateRoleTransfer:         tokenEventDecoder(1, false, decodeDCTNFTCreateRoleTransfer),
+	vmcommon.BuiltInFunctionDCTTransferRoleAddAddress:    tokenEventDecoder(0, true, decodeDCTTransferRoleAddresses),
+	vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress: tokenEventDecoder(0, true, decodeDCTTransferRoleAddresses),
+	core.BuiltInFunctionDCTPause:                         tokenEventDecoder(0, false, decodeDCTGlobalSettings),
+	core.BuiltInFunctionDCTUnPause:                       tokenEventDecoder(0, false, decodeDCTGlobalSettings),
+	core.BuiltInFunctionDCTSetLimitedTransfer:            tokenEventDecoder(0, false, decodeDCTGlobalSettings),
+	core.BuiltInFunctionDCTUnSetLimitedTransfer:          tokenEventDecoder(0, false, decodeDCTGlobalSettings),
+	vmcommon.BuiltInFunctionDCTSetBurnRoleForAll:         tokenEventDecoder(0, false, decodeDCTGlobalSettings),
+	vmcommon.BuiltInFunctionDCTUnSetBurnRoleForAll:       tokenEventDecoder(0, false, decodeDCTGlobalSettings),
+	vmcommon.DCTDeleteMetadata:                           tokenEventDecoder(0, true, decodeDCTDeleteMetadata),
+	vmcommon.DCTAddMetadata:                              tokenEventDecoder(1, false, decodeDCTAddMetadata),
+	core.BuiltInFunctionChangeOwnerAddress:               decodeChangeOwnerAddress,
+	core.BuiltInFunctionClaimDeveloperRewards:            decodeClaimDeveloperRewards,
+	core.BuiltInFunctionSetUserName:                      decodeSetUserName,
+	core.BuiltInFunctionSaveKeyValue:                     decodeSaveKeyValue,
+}
+
+// Decode turns a log entry emitted by a built-in function back into its typed event
+func Decode(entry *vmcommon.LogEntry) (Event, error) {
+	if entry == nil {
+		return nil, ErrNilLogEntry
+	}
+
+	decode, ok := eventDecoders[string(entry.Identifier)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventIdentifier, entry.Identifier)
+	}
+
+	return decode(entry)
+}
+
+func tokenEventDecoder(numExtraTopics int, isVariadic bool, decode decodeTokenEntryFunc) decodeEntryFunc {
+	return func(entry *vmcommon.LogEntry) (Event, error) {
+		tokenEntry, err := decodeTokenLogEntry(entry, numExtraTopics, isVariadic)
+		if err != nil {
+			return nil, err
+		}
+
+		return decode(string(entry.Identifier), tokenEntry)
+	}
+}
+
+func checkNumTopics(entry *vmcommon.LogEntry, expected int) error {
+	if len(entry.Topics) != expected {
+		return fmt.Errorf("%w for %s: expected %d, got %d", ErrInvalidNumberOfTopics, entry.Identifier, expected, len(entry.Topics))
+	}
+
+	return nil
+}
+
+// IsBuiltInFunctionEvent returns true if the identifier belongs to an event which can be decoded
+func IsBuiltInFunctionEvent(identifier []byte) bool {
+	_, ok := eventDecoders[string(identifier)]
+	return ok
+}
+
+func decodeDCTTransfer(_ string, entry *tokenLogEntry) (Event, error) {
+	return &DCTTransfer{
+		Sender:   entry.address,
+		Receiver: entry.extraTopics[0],
+		TokenID:  entry.tokenID,
+		Value:    entry.value,
+	}, nil
+}
+
+func decodeDCTNFTTransfer(_ string, entry *tokenLogEntry) (Event, error) {
+	return &DCTNFTTransfer{
+		Sender:   entry.address,
+		Receiver: entry.extraTopics[0],
+		TokenID:  entry.tokenID,
+		Nonce:    entry.nonce,
+		Value:    entry.value,
+	}, nil
+}
+
+func decodeMultiDCTNFTTransfer(_ string, entry *tokenLogEntry) (Event, error) {
+	return &MultiDCTNFTTransfer{
+		Sender:   entry.address,
+		Receiver: entry.extraTopics[0],
+		TokenID:  entry.tokenID,
+		Nonce:    entry.nonce,
+		Value:    entry.value,
+	}, nil
+}
+
+func decodeDCTSupplyChange(identifier string, entry *tokenLogEntry) (Event, error) {
+	return &DCTSupplyChange{
+		Function: identifier,
+		Account:  entry.address,
+		TokenID:  entry.tokenID,
+		Nonce:    entry.nonce,
+		Value:    entry.value,
+	}, nil
+}
+
+func decodeDCTNFTCreate(_ string, entry *tokenLogEntry) (Event, error) {
+	return &DCTNFTCreate{
+		Creator:   entry.address,
+		TokenID:   entry.tokenID,
+		Nonce:     entry.nonce,
+		Quantity:  entry.value,
+		TokenData: entry.extraTopics[0],
+	}, nil
+}
+
+func decodeDCTNFTAddURI(_ string, entry *tokenLogEntry) (Event, error) {
+	return &DCTNFTAddURI{
+		Account: entry.address,
+		TokenID: entry.tokenID,
+		Nonce:   entry.nonce,
+		URIs:    entry.extraTopics,
+	}, nil
+}
+
+func decodeDCTNFTUpdateAttributes(_ string, entry *tokenLogEntry) (Event, error) {
+	return &DCTNFTUpdateAttributes{
+		Account:    entry.address,
+		TokenID:    entry.tokenID,
+		Nonce:      entry.nonce,
+		Attributes: entry.extraTopics[0],
+	}, nil
+}
+
+func decodeDCTFreezeWipe(identifier string, entry *tokenLogEntry) (Event, error) {
+	return &DCTFreezeWipe{
+		Function: identifier,
+		Caller:   entry.address,
+		Account:  entry.extraTopics[0],
+		TokenID:  entry.tokenID,
+		Nonce:    entry.nonce,
+		Value:    entry.value,
+	}, nil
+}
+
+func decodeDCTRoles(identifier string, entry *tokenLogEntry) (Event, error) {
+	return &DCTRoles{
+		Function: identifier,
+		Account:  entry.address,
+		TokenID:  entry.tokenID,
+		Roles:    entry.extraTopics,
+	}, nil
+}
+
+func decodeDCTNFTCreateRoleTransfer(identifier string, entry *tokenLogEntry) (Event, error) {
+	isNewOwnerTopic := string(entry.extraTopics[0])
+	isNewOwner := isNewOwnerTopic == strconv.FormatBool(true)
+	if !isNewOwner && isNewOwnerTopic != strconv.FormatBool(false) {
+		return nil, fmt.Errorf("%w for %s: %q is not a bool", ErrInvalidTopic, identifier, isNewOwnerTopic)
+	}
+
+	return &DCTNFTCreateRoleTransfer{
+		Account:    entry.address,
+		TokenID:    entry.tokenID,
+		IsNewOwner: isNewOwner,
+	}, nil
+}
+
+func decodeDCTTransferRoleAddresses(identifier string, entry *tokenLogEntry) (Event, error) {
+	return &DCTTransferRoleAddresses{
+		Function:  identifier,
+		Account:   entry.address,
+		TokenID:   entry.tokenID,
+		Addresses: entry.extraTopics,
+	}, nil
+}
+
+func decodeDCTGlobalSettings(identifier string, entry *tokenLogEntry) (Event, error) {
+	return &DCTGlobalSettings{
+		Function: identifier,
+		Caller:   entry.address,
+		TokenID:  entry.tokenID,
+	}, nil
+}
+
+func decodeDCTDeleteMetadata(identifier string, entry *tokenLogEntry) (Event, error) {
+	if len(entry.extraTopics)%2 != 0 {
+		return nil, fmt.Errorf("%w for %s: intervals need a start and an end", ErrInvalidNumberOfTopics, identifier)
+	}
+
+	intervals := make([]NonceInterval, 0, len(entry.extraTopics)/2)
+	for i := 0; i < len(entry.extraTopics); i += 2 {
+		start, err := decodeUint64Topic(identifier, entry.extraTopics[i])
+		if err != nil {
+			return nil, err
+		}
+		end, err := decodeUint64Topic(identifier, entry.extraTopics[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		intervals = append(intervals, NonceInterval{Start: start, End: end})
+	}
+
+	return &DCTDeleteMetadata{
+		Caller:    entry.address,
+		TokenID:   entry.tokenID,
+		Intervals: intervals,
+	}, nil
+}
+
+func decodeDCTAddMetadata(_ string, entry *tokenLogEntry) (Event, error) {
+	return &DCTAddMetadata{
+		Caller:   entry.address,
+		TokenID:  entry.tokenID,
+		Nonce:    entry.nonce,
+		Metadata: entry.extraTopics[0],
+	}, nil
+}
+
+func decodeChangeOwnerAddress(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChangeOwnerAddress{
+		Contract: entry.Address,
+		OldOwner: entry.Topics[0],
+		NewOwner: entry.Topics[1],
+	}, nil
+}
+
+func decodeClaimDeveloperRewards(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClaimDeveloperRewards{
+		Contract: entry.Address,
+		Receiver: entry.Topics[1],
+		Value:    big.NewInt(0).SetBytes(entry.Topics[0]),
+	}, nil
+}
+
+func decodeSetUserName(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetUserName{
+		Account:  entry.Address,
+		UserName: entry.Topics[0],
+	}, nil
+}
+
+func decodeSaveKeyValue(entry *vmcommon.LogEntry) (Event, error) {
+	if len(entry.Topics)%2 != 0 {
+		return nil, fmt.Errorf("%w for %s: each key needs a value", ErrInvalidNumberOfTopics, entry.Identifier)
+	}
+
+	pairs := make([]KeyValuePair, 0, len(entry.Topics)/2)
+	for i := 0; i < len(entry.Topics); i += 2 {
+		pairs = append(pairs, KeyValuePair{Key: entry.Topics[i], Value: entry.Topics[i+1]})
+	}
+
+	return &SaveKeyValue{
+		Account:       entry.Address,
+		KeyValuePairs: pairs,
+	}, nil
+}
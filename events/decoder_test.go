@@ -0,0 +1,89 @@
+package events
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode_Errors(t *testing.T) {
+	t.Parallel()
+
+	event, err := Decode(nil)
+	assert.Nil(t, event)
+	assert.Equal(t, ErrNilLogEntry, err)
+
+	event, err = Decode(&vmcommon.LogEntry{Identifier: []byte("writeLog")})
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, ErrUnknownEventIdentifier))
+
+	event, err = Decode(&vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionDCTTransfer),
+		Topics:     [][]byte{testTokenID, {}, {0x01}},
+	})
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfTopics))
+
+	event, err = Decode(&vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionDCTBurn),
+		Topics:     [][]byte{testTokenID, {}, {0x01}, []byte("extra")},
+	})
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfTopics))
+
+	event, err = Decode(&vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionDCTNFTBurn),
+		Topics:     [][]byte{testTokenID, {0x01, 0, 0, 0, 0, 0, 0, 0, 0}, {0x01}},
+	})
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, ErrInvalidTopic))
+
+	event, err = Decode(&vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionDCTNFTCreateRoleTransfer),
+		Topics:     [][]byte{testTokenID, {}, {}, []byte("1")},
+	})
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, ErrInvalidTopic))
+}
+
+func TestDecode_ShouldRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range testEvents() {
+		event, err := Decode(tc.entry)
+		require.Nil(t, err, tc.event.Identifier())
+		assert.Equal(t, tc.event, event, tc.event.Identifier())
+		assert.Equal(t, tc.entry, event.LogEntry(), tc.event.Identifier())
+	}
+}
+
+func TestDecode_VariadicEventsWithoutExtraTopics(t *testing.T) {
+	t.Parallel()
+
+	entry := &vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionUnSetDCTRole),
+		Address:    testReceiver,
+		Topics:     [][]byte{testTokenID, {}, {}},
+	}
+	event, err := Decode(entry)
+	require.Nil(t, err)
+
+	roles, ok := event.(*DCTRoles)
+	require.True(t, ok)
+	assert.Equal(t, core.BuiltInFunctionUnSetDCTRole, roles.Function)
+	assert.Empty(t, roles.Roles)
+	assert.Equal(t, big.NewInt(0).Bytes(), event.LogEntry().Topics[2])
+}
+
+func TestIsBuiltInFunctionEvent(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsBuiltInFunctionEvent([]byte(core.BuiltInFunctionDCTNFTTransfer)))
+	assert.True(t, IsBuiltInFunctionEvent([]byte(vmcommon.BuiltInFunctionDCTTransferRoleDeleteAddress)))
+	assert.False(t, IsBuiltInFunctionEvent([]byte("writeLog")))
+}
@@ -0,0 +1,15 @@
+package events
+
+import "errors"
+
+// ErrNilLogEntry signals that a nil log entry has been provided
+var ErrNilLogEntry = errors.New("nil log entry")
+
+// ErrUnknownEventIdentifier signals that the log entry identifier does not match any built-in function event
+var ErrUnknownEventIdentifier = errors.New("unknown event identifier")
+
+// ErrInvalidNumberOfTopics signals that the log entry does not hold the number of topics its event requires
+var ErrInvalidNumberOfTopics = errors.New("invalid number of topics")
+
+// ErrInvalidTopic signals that a log entry topic could not be decoded
+var ErrInvalidTopic = errors.New("invalid topic")
@@ -0,0 +1,414 @@
+package events
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/kalyan3104/k-core/core"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var (
+	_ Event = (*DCTTransfer)(nil)
+	_ Event = (*DCTNFTTransfer)(nil)
+	_ Event = (*MultiDCTNFTTransfer)(nil)
+	_ Event = (*DCTSupplyChange)(nil)
+	_ Event = (*DCTNFTCreate)(nil)
+	_ Event = (*DCTNFTAddURI)(nil)
+	_ Event = (*DCTNFTUpdateAttributes)(nil)
+	_ Event = (*DCTFreezeWipe)(nil)
+	_ Event = (*DCTRoles)(nil)
+	_ Event = (*DCTNFTCreateRoleTransfer)(nil)
+	_ Event = (*DCTTransferRoleAddresses)(nil)
+	_ Event = (*DCTGlobalSettings)(nil)
+	_ Event = (*DCTDeleteMetadata)(nil)
+	_ Event = (*DCTAddMetadata)(nil)
+	_ Event = (*ChangeOwnerAddress)(nil)
+	_ Event = (*ClaimDeveloperRewards)(nil)
+	_ Event = (*SetUserName)(nil)
+	_ Event = (*SaveKeyValue)(nil)
+)
+
+// DCTTransfer is logged when fungible tokens are moved between two accounts
+type DCTTransfer struct {
+	Sender   []byte
+	Receiver []byte
+	TokenID  []byte
+	Value    *big.Int
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTTransfer) Identifier() string {
+	return core.BuiltInFunctionDCTTransfer
+}
+
+// LogEntry encodes the event as: address sender, topics token, empty nonce, value, receiver
+func (e *DCTTransfer) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Sender, e.TokenID, 0, e.Value, e.Receiver)
+}
+
+// DCTNFTTransfer is logged when a token with nonce is moved between two accounts
+type DCTNFTTransfer struct {
+	Sender   []byte
+	Receiver []byte
+	TokenID  []byte
+	Nonce    uint64
+	Value    *big.Int
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTNFTTransfer) Identifier() string {
+	return core.BuiltInFunctionDCTNFTTransfer
+}
+
+// LogEntry encodes the event as: address sender, topics token, nonce, value, receiver
+func (e *DCTNFTTransfer) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Sender, e.TokenID, e.Nonce, e.Value, e.Receiver)
+}
+
+// MultiDCTNFTTransfer is logged for each of the tokens moved by a multi transfer
+type MultiDCTNFTTransfer struct {
+	Sender   []byte
+	Receiver []byte
+	TokenID  []byte
+	Nonce    uint64
+	Value    *big.Int
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *MultiDCTNFTTransfer) Identifier() string {
+	return core.BuiltInFunctionMultiDCTNFTTransfer
+}
+
+// LogEntry encodes the event as: address sender, topics token, nonce, value, receiver
+func (e *MultiDCTNFTTransfer) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Sender, e.TokenID, e.Nonce, e.Value, e.Receiver)
+}
+
+// DCTSupplyChange is logged when the supply of a token changes: DCTBurn, DCTLocalBurn, DCTLocalMint,
+// DCTNFTAddQuantity and DCTNFTBurn
+type DCTSupplyChange struct {
+	Function string
+	Account  []byte
+	TokenID  []byte
+	Nonce    uint64
+	Value    *big.Int
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTSupplyChange) Identifier() string {
+	return e.Function
+}
+
+// LogEntry encodes the event as: address account, topics token, nonce, value
+func (e *DCTSupplyChange) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Account, e.TokenID, e.Nonce, e.Value)
+}
+
+// DCTNFTCreate is logged when a new token nonce is created. TokenData holds the marshalled dct.DCToken
+type DCTNFTCreate struct {
+	Creator   []byte
+	TokenID   []byte
+	Nonce     uint64
+	Quantity  *big.Int
+	TokenData []byte
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTNFTCreate) Identifier() string {
+	return core.BuiltInFunctionDCTNFTCreate
+}
+
+// LogEntry encodes the event as: address creator, topics token, nonce, quantity, token data
+func (e *DCTNFTCreate) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Creator, e.TokenID, e.Nonce, e.Quantity, e.TokenData)
+}
+
+// DCTNFTAddURI is logged when URIs are added to a token nonce
+type DCTNFTAddURI struct {
+	Account []byte
+	TokenID []byte
+	Nonce   uint64
+	URIs    [][]byte
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTNFTAddURI) Identifier() string {
+	return core.BuiltInFunctionDCTNFTAddURI
+}
+
+// LogEntry encodes the event as: address account, topics token, nonce, empty value, URIs
+func (e *DCTNFTAddURI) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Account, e.TokenID, e.Nonce, nil, e.URIs...)
+}
+
+// DCTNFTUpdateAttributes is logged when the attributes of a token nonce are replaced
+type DCTNFTUpdateAttributes struct {
+	Account    []byte
+	TokenID    []byte
+	Nonce      uint64
+	Attributes []byte
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTNFTUpdateAttributes) Identifier() string {
+	return core.BuiltInFunctionDCTNFTUpdateAttributes
+}
+
+// LogEntry encodes the event as: address account, topics token, nonce, empty value, attributes
+func (e *DCTNFTUpdateAttributes) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Account, e.TokenID, e.Nonce, nil, e.Attributes)
+}
+
+// DCTFreezeWipe is logged when the tokens of an account are frozen, unfrozen or wiped: DCTFreeze, DCTUnFreeze
+// and DCTWipe. Value holds the token balance of the account
+type DCTFreezeWipe struct {
+	Function string
+	Caller   []byte
+	Account  []byte
+	TokenID  []byte
+	Nonce    uint64
+	Value    *big.Int
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTFreezeWipe) Identifier() string {
+	return e.Function
+}
+
+// LogEntry encodes the event as: address caller, topics token, nonce, value, account
+func (e *DCTFreezeWipe) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Caller, e.TokenID, e.Nonce, e.Value, e.Account)
+}
+
+// DCTRoles is logged when roles are set or unset for an account: SetDCTRole and UnSetDCTRole
+type DCTRoles struct {
+	Function string
+	Account  []byte
+	TokenID  []byte
+	Roles    [][]byte
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTRoles) Identifier() string {
+	return e.Function
+}
+
+// LogEntry encodes the event as: address account, topics token, empty nonce, empty value, roles
+func (e *DCTRoles) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Account, e.TokenID, 0, nil, e.Roles...)
+}
+
+// DCTNFTCreateRoleTransfer is logged when the NFT create role leaves an account, IsNewOwner being false, or
+// reaches an account, IsNewOwner being true
+type DCTNFTCreateRoleTransfer struct {
+	Account    []byte
+	TokenID    []byte
+	IsNewOwner bool
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTNFTCreateRoleTransfer) Identifier() string {
+	return core.BuiltInFunctionDCTNFTCreateRoleTransfer
+}
+
+// LogEntry encodes the event as: address account, topics token, empty nonce, empty value, "true"/"false"
+func (e *DCTNFTCreateRoleTransfer) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Account, e.TokenID, 0, nil, []byte(strconv.FormatBool(e.IsNewOwner)))
+}
+
+// DCTTransferRoleAddresses is logged when the addresses allowed to transfer a token with limited transfer are
+// changed: DCTTransferRoleAddAddress and DCTTransferRoleDeleteAddress. Account is the system account
+type DCTTransferRoleAddresses struct {
+	Function  string
+	Account   []byte
+	TokenID   []byte
+	Addresses [][]byte
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTTransferRoleAddresses) Identifier() string {
+	return e.Function
+}
+
+// LogEntry encodes the event as: address account, topics token, empty nonce, empty value, addresses
+func (e *DCTTransferRoleAddresses) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Account, e.TokenID, 0, nil, e.Addresses...)
+}
+
+// DCTGlobalSettings is logged when a token wide setting is toggled: DCTPause, DCTUnPause, DCTSetLimitedTransfer,
+// DCTUnSetLimitedTransfer, DCTSetBurnRoleForAll and DCTUnSetBurnRoleForAll
+type DCTGlobalSettings struct {
+	Function string
+	Caller   []byte
+	TokenID  []byte
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTGlobalSettings) Identifier() string {
+	return e.Function
+}
+
+// LogEntry encodes the event as: address caller, topics token, empty nonce, empty value
+func (e *DCTGlobalSettings) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Caller, e.TokenID, 0, nil)
+}
+
+// NonceInterval is an inclusive interval of token nonces
+type NonceInterval struct {
+	Start uint64
+	End   uint64
+}
+
+// DCTDeleteMetadata is logged for each token whose metadata is deleted from the system account
+type DCTDeleteMetadata struct {
+	Caller    []byte
+	TokenID   []byte
+	Intervals []NonceInterval
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTDeleteMetadata) Identifier() string {
+	return vmcommon.DCTDeleteMetadata
+}
+
+// LogEntry encodes the event as: address caller, topics token, empty nonce, empty value, followed by the start and
+// the end of each interval
+func (e *DCTDeleteMetadata) LogEntry() *vmcommon.LogEntry {
+	intervals := make([][]byte, 0, 2*len(e.Intervals))
+	for _, interval := range e.Intervals {
+		intervals = append(intervals, big.NewInt(0).SetUint64(interval.Start).Bytes(), big.NewInt(0).SetUint64(interval.End).Bytes())
+	}
+
+	return newTokenLogEntry(e.Identifier(), e.Caller, e.TokenID, 0, nil, intervals...)
+}
+
+// DCTAddMetadata is logged for each token nonce whose metadata is added to the system account. Metadata holds the
+// marshalled dct.MetaData
+type DCTAddMetadata struct {
+	Caller   []byte
+	TokenID  []byte
+	Nonce    uint64
+	Metadata []byte
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *DCTAddMetadata) Identifier() string {
+	return vmcommon.DCTAddMetadata
+}
+
+// LogEntry encodes the event as: address caller, topics token, nonce, empty value, metadata
+func (e *DCTAddMetadata) LogEntry() *vmcommon.LogEntry {
+	return newTokenLogEntry(e.Identifier(), e.Caller, e.TokenID, e.Nonce, nil, e.Metadata)
+}
+
+// ChangeOwnerAddress is logged when the owner of a contract changes
+type ChangeOwnerAddress struct {
+	Contract []byte
+	OldOwner []byte
+	NewOwner []byte
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *ChangeOwnerAddress) Identifier() string {
+	return core.BuiltInFunctionChangeOwnerAddress
+}
+
+// LogEntry encodes the event as: address contract, topics old owner, new owner
+func (e *ChangeOwnerAddress) LogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(e.Identifier()),
+		Address:    e.Contract,
+		Topics:     [][]byte{e.OldOwner, e.NewOwner},
+	}
+}
+
+// ClaimDeveloperRewards is logged when the owner of a contract claims the developer rewards
+type ClaimDeveloperRewards struct {
+	Contract []byte
+	Receiver []byte
+	Value    *big.Int
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *ClaimDeveloperRewards) Identifier() string {
+	return core.BuiltInFunctionClaimDeveloperRewards
+}
+
+// LogEntry encodes the event as: address contract, topics value, receiver
+func (e *ClaimDeveloperRewards) LogEntry() *vmcommon.LogEntry {
+	value := e.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	return &vmcommon.LogEntry{
+		Identifier: []byte(e.Identifier()),
+		Address:    e.Contract,
+		Topics:     [][]byte{value.Bytes(), e.Receiver},
+	}
+}
+
+// SetUserName is logged when the user name of an account is set
+type SetUserName struct {
+	Account  []byte
+	UserName []byte
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *SetUserName) Identifier() string {
+	return core.BuiltInFunctionSetUserName
+}
+
+// LogEntry encodes the event as: address account, topics user name
+func (e *SetUserName) LogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(e.Identifier()),
+		Address:    e.Account,
+		Topics:     [][]byte{e.UserName},
+	}
+}
+
+// KeyValuePair is a value saved under a key of the account storage
+type KeyValuePair struct {
+	Key   []byte
+	Value []byte
+}
+
+// SaveKeyValue is logged when values are saved in the storage of an account. Only the changed values are logged
+type SaveKeyValue struct {
+	Account       []byte
+	KeyValuePairs []KeyValuePair
+}
+
+// Identifier returns the log entry identifier of the event
+func (e *SaveKeyValue) Identifier() string {
+	return core.BuiltInFunctionSaveKeyValue
+}
+
+// LogEntry encodes the event as: address account, topics the key and the value of each pair
+func (e *SaveKeyValue) LogEntry() *vmcommon.LogEntry {
+	topics := make([][]byte, 0, 2*len(e.KeyValuePairs))
+	for _, pair := range e.KeyValuePairs {
+		topics = append(topics, pair.Key, pair.Value)
+	}
+
+	return &vmcommon.LogEntry{
+		Identifier: []byte(e.Identifier()),
+		Address:    e.Account,
+		Topics:     topics,
+	}
+}
+
+// AddToVMOutput appends the log entry of the event to the logs of the vm output
+func AddToVMOutput(vmOutput *vmcommon.VMOutput, event Event) {
+	if vmOutput == nil || event == nil {
+		return
+	}
+
+	if vmOutput.Logs == nil {
+		vmOutput.Logs = make([]*vmcommon.LogEntry, 0, 1)
+	}
+
+	vmOutput.Logs = append(vmOutput.Logs, event.LogEntry())
+}
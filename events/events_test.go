@@ -0,0 +1,212 @@
+package events
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testSender   = []byte("sender")
+	testReceiver = []byte("receiver")
+	testTokenID  = []byte("TKN-abcdef")
+)
+
+// testEvents holds one event of each layout, together with the log entry the built-in functions emit for it. The byte
+// layout must not change
+func testEvents() []struct {
+	event Event
+	entry *vmcommon.LogEntry
+} {
+	return []struct {
+		event Event
+		entry *vmcommon.LogEntry
+	}{
+		{
+			event: &DCTTransfer{Sender: testSender, Receiver: testReceiver, TokenID: testTokenID, Value: big.NewInt(1000)},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionDCTTransfer),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {}, {0x03, 0xe8}, testReceiver},
+			},
+		},
+		{
+			event: &DCTNFTTransfer{Sender: testSender, Receiver: testReceiver, TokenID: testTokenID, Nonce: 258, Value: big.NewInt(1)},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionDCTNFTTransfer),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {0x01, 0x02}, {0x01}, testReceiver},
+			},
+		},
+		{
+			event: &MultiDCTNFTTransfer{Sender: testSender, Receiver: testReceiver, TokenID: testTokenID, Nonce: 1, Value: big.NewInt(2)},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionMultiDCTNFTTransfer),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {0x01}, {0x02}, testReceiver},
+			},
+		},
+		{
+			event: &DCTSupplyChange{Function: core.BuiltInFunctionDCTLocalMint, Account: testSender, TokenID: testTokenID, Value: big.NewInt(5)},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionDCTLocalMint),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {}, {0x05}},
+			},
+		},
+		{
+			event: &DCTSupplyChange{Function: core.BuiltInFunctionDCTNFTBurn, Account: testSender, TokenID: testTokenID, Nonce: 3, Value: big.NewInt(5)},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionDCTNFTBurn),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {0x03}, {0x05}},
+			},
+		},
+		{
+			event: &DCTNFTCreate{Creator: testSender, TokenID: testTokenID, Nonce: 7, Quantity: big.NewInt(1), TokenData: []byte("token data")},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionDCTNFTCreate),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {0x07}, {0x01}, []byte("token data")},
+			},
+		},
+		{
+			event: &DCTNFTAddURI{Account: testSender, TokenID: testTokenID, Nonce: 7, URIs: [][]byte{[]byte("uri1"), []byte("uri2")}},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionDCTNFTAddURI),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {0x07}, {}, []byte("uri1"), []byte("uri2")},
+			},
+		},
+		{
+			event: &DCTNFTUpdateAttributes{Account: testSender, TokenID: testTokenID, Nonce: 7, Attributes: []byte("attributes")},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionDCTNFTUpdateAttributes),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {0x07}, {}, []byte("attributes")},
+			},
+		},
+		{
+			event: &DCTFreezeWipe{Function: core.BuiltInFunctionDCTWipe, Caller: core.DCTSCAddress, Account: testReceiver, TokenID: testTokenID, Nonce: 2, Value: big.NewInt(10)},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionDCTWipe),
+				Address:    core.DCTSCAddress,
+				Topics:     [][]byte{testTokenID, {0x02}, {0x0a}, testReceiver},
+			},
+		},
+		{
+			event: &DCTRoles{Function: core.BuiltInFunctionSetDCTRole, Account: testReceiver, TokenID: testTokenID, Roles: [][]byte{[]byte(core.DCTRoleLocalMint)}},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionSetDCTRole),
+				Address:    testReceiver,
+				Topics:     [][]byte{testTokenID, {}, {}, []byte(core.DCTRoleLocalMint)},
+			},
+		},
+		{
+			event: &DCTNFTCreateRoleTransfer{Account: testReceiver, TokenID: testTokenID, IsNewOwner: true},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionDCTNFTCreateRoleTransfer),
+				Address:    testReceiver,
+				Topics:     [][]byte{testTokenID, {}, {}, []byte("true")},
+			},
+		},
+		{
+			event: &DCTTransferRoleAddresses{Function: vmcommon.BuiltInFunctionDCTTransferRoleAddAddress, Account: vmcommon.SystemAccountAddress, TokenID: testTokenID, Addresses: [][]byte{testSender}},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(vmcommon.BuiltInFunctionDCTTransferRoleAddAddress),
+				Address:    vmcommon.SystemAccountAddress,
+				Topics:     [][]byte{testTokenID, {}, {}, testSender},
+			},
+		},
+		{
+			event: &DCTGlobalSettings{Function: core.BuiltInFunctionDCTPause, Caller: testSender, TokenID: testTokenID},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionDCTPause),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {}, {}},
+			},
+		},
+		{
+			event: &DCTDeleteMetadata{Caller: testSender, TokenID: testTokenID, Intervals: []NonceInterval{{Start: 1, End: 2}, {Start: 5, End: 300}}},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(vmcommon.DCTDeleteMetadata),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {}, {}, {0x01}, {0x02}, {0x05}, {0x01, 0x2c}},
+			},
+		},
+		{
+			event: &DCTAddMetadata{Caller: testSender, TokenID: testTokenID, Nonce: 3, Metadata: []byte("metadata")},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(vmcommon.DCTAddMetadata),
+				Address:    testSender,
+				Topics:     [][]byte{testTokenID, {0x03}, {}, []byte("metadata")},
+			},
+		},
+		{
+			event: &ChangeOwnerAddress{Contract: testReceiver, OldOwner: testSender, NewOwner: []byte("new owner")},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionChangeOwnerAddress),
+				Address:    testReceiver,
+				Topics:     [][]byte{testSender, []byte("new owner")},
+			},
+		},
+		{
+			event: &ClaimDeveloperRewards{Contract: testReceiver, Receiver: testSender, Value: big.NewInt(256)},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionClaimDeveloperRewards),
+				Address:    testReceiver,
+				Topics:     [][]byte{{0x01, 0x00}, testSender},
+			},
+		},
+		{
+			event: &SetUserName{Account: testSender, UserName: []byte("alice")},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionSetUserName),
+				Address:    testSender,
+				Topics:     [][]byte{[]byte("alice")},
+			},
+		},
+		{
+			event: &SaveKeyValue{Account: testSender, KeyValuePairs: []KeyValuePair{{Key: []byte("k1"), Value: []byte("v1")}, {Key: []byte("k2"), Value: []byte{}}}},
+			entry: &vmcommon.LogEntry{
+				Identifier: []byte(core.BuiltInFunctionSaveKeyValue),
+				Address:    testSender,
+				Topics:     [][]byte{[]byte("k1"), []byte("v1"), []byte("k2"), {}},
+			},
+		},
+	}
+}
+
+func TestEvents_LogEntryShouldKeepLayout(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range testEvents() {
+		assert.Equal(t, tc.entry, tc.event.LogEntry(), tc.event.Identifier())
+	}
+}
+
+func TestEvents_LogEntryWithNilValue(t *testing.T) {
+	t.Parallel()
+
+	event := &DCTSupplyChange{Function: core.BuiltInFunctionDCTBurn, Account: testSender, TokenID: testTokenID}
+	assert.Equal(t, [][]byte{testTokenID, {}, {}}, event.LogEntry().Topics)
+}
+
+func TestAddToVMOutput(t *testing.T) {
+	t.Parallel()
+
+	AddToVMOutput(nil, &DCTTransfer{})
+
+	vmOutput := &vmcommon.VMOutput{}
+	AddToVMOutput(vmOutput, nil)
+	assert.Nil(t, vmOutput.Logs)
+
+	first := &DCTTransfer{Sender: testSender, Receiver: testReceiver, TokenID: testTokenID, Value: big.NewInt(1)}
+	second := &DCTNFTTransfer{Sender: testSender, Receiver: testReceiver, TokenID: testTokenID, Nonce: 1, Value: big.NewInt(1)}
+	AddToVMOutput(vmOutput, first)
+	AddToVMOutput(vmOutput, second)
+	assert.Equal(t, []*vmcommon.LogEntry{first.LogEntry(), second.LogEntry()}, vmOutput.Logs)
+}
@@ -0,0 +1,9 @@
+package events
+
+import vmcommon "github.com/kalyan3104/k-vm-common-go"
+
+// Event defines a built-in function event, which is logged as a LogEntry
+type Event interface {
+	Identifier() string
+	LogEntry() *vmcommon.LogEntry
+}
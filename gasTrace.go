@@ -0,0 +1,87 @@
+package vmcommon
+
+import "sync"
+
+// GasTraceEntry holds a single gas charge made during execution
+type GasTraceEntry struct {
+	Label string
+	Units uint64
+	Total uint64
+}
+
+// GasTrace collects, in order, the gas charges made during an execution. All the methods can be called on a nil
+// GasTrace, which ignores the charges, so the collector can be passed through execution unconditionally
+type GasTrace struct {
+	mutEntries sync.RWMutex
+	entries    []GasTraceEntry
+	total      uint64
+}
+
+// NewGasTrace creates a new, empty, gas trace
+func NewGasTrace() *GasTrace {
+	return &GasTrace{
+		entries: make([]GasTraceEntry, 0),
+	}
+}
+
+// Charge records a gas charge under the provided label
+func (gt *GasTrace) Charge(label string, units uint64) {
+	if gt == nil {
+		return
+	}
+
+	gt.mutEntries.Lock()
+	defer gt.mutEntries.Unlock()
+
+	gt.total += units
+	gt.entries = append(gt.entries, GasTraceEntry{
+		Label: label,
+		Units: units,
+		Total: gt.total,
+	})
+}
+
+// Entries returns a copy of the recorded charges, in the order they were made
+func (gt *GasTrace) Entries() []GasTraceEntry {
+	if gt == nil {
+		return nil
+	}
+
+	gt.mutEntries.RLock()
+	defer gt.mutEntries.RUnlock()
+
+	entries := make([]GasTraceEntry, len(gt.entries))
+	copy(entries, gt.entries)
+
+	return entries
+}
+
+// Total returns the sum of all the recorded charges
+func (gt *GasTrace) Total() uint64 {
+	if gt == nil {
+		return 0
+	}
+
+	gt.mutEntries.RLock()
+	defer gt.mutEntries.RUnlock()
+
+	return gt.total
+}
+
+// Clone returns an independent copy of the gas trace, holding the charges recorded so far
+func (gt *GasTrace) Clone() *GasTrace {
+	if gt == nil {
+		return nil
+	}
+
+	gt.mutEntries.RLock()
+	defer gt.mutEntries.RUnlock()
+
+	clone := &GasTrace{
+		entries: make([]GasTraceEntry, len(gt.entries)),
+		total:   gt.total,
+	}
+	copy(clone.entries, gt.entries)
+
+	return clone
+}
@@ -0,0 +1,62 @@
+package vmcommon
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGasTrace_NilShouldIgnoreCharges(t *testing.T) {
+	t.Parallel()
+
+	var gasTrace *GasTrace
+	assert.NotPanics(t, func() {
+		gasTrace.Charge("label", 10)
+	})
+	assert.Nil(t, gasTrace.Entries())
+	assert.Zero(t, gasTrace.Total())
+}
+
+func TestGasTrace_ChargeShouldKeepRunningTotal(t *testing.T) {
+	t.Parallel()
+
+	gasTrace := NewGasTrace()
+	assert.Empty(t, gasTrace.Entries())
+
+	gasTrace.Charge("FunctionCost", 10)
+	gasTrace.Charge("StorePerByte", 0)
+	gasTrace.Charge("PersistPerByte", 25)
+
+	expectedEntries := []GasTraceEntry{
+		{Label: "FunctionCost", Units: 10, Total: 10},
+		{Label: "StorePerByte", Units: 0, Total: 10},
+		{Label: "PersistPerByte", Units: 25, Total: 35},
+	}
+	entries := gasTrace.Entries()
+	assert.Equal(t, expectedEntries, entries)
+	assert.Equal(t, uint64(35), gasTrace.Total())
+
+	entries[0].Units = 100
+	assert.Equal(t, expectedEntries, gasTrace.Entries())
+}
+
+func TestGasTrace_ConcurrentCharges(t *testing.T) {
+	t.Parallel()
+
+	gasTrace := NewGasTrace()
+	numCalls := 100
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func() {
+			gasTrace.Charge("label", 1)
+			_ = gasTrace.Entries()
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(numCalls), gasTrace.Total())
+	assert.Len(t, gasTrace.Entries(), numCalls)
+}
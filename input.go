@@ -65,6 +65,10 @@ type VMInput struct {
 
 	// ReturnCallAfterError
 	ReturnCallAfterError bool
+
+	// GasTrace is an optional collector of the gas charges made during execution, used for debugging.
+	// It is nil in normal execution.
+	GasTrace *GasTrace
 }
 
 // DCTTransfer defines the structure for and DCT / NFT transfer
@@ -0,0 +1,156 @@
+package vmcommon
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kalyan3104/k-core/core"
+)
+
+// InputLimits holds the bounds an input is validated against. A zero value disables the corresponding check
+type InputLimits struct {
+	// AddressLength is the length every address of the input must have
+	AddressLength int
+	// MaxNumArguments is the maximum number of arguments
+	MaxNumArguments int
+	// MaxArgumentsBytes is the maximum number of bytes of all the arguments taken together
+	MaxArgumentsBytes int
+}
+
+// InputValidationError is a validation failure of an input. Err is, or wraps, one of the sentinel errors, so that the
+// failure can be checked with errors.Is, while Field points to the offending field
+type InputValidationError struct {
+	Field string
+	Err   error
+}
+
+// Error returns the description of the failure
+func (e *InputValidationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the sentinel error of the failure
+func (e *InputValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ReturnCode returns the code a VM should end the execution with when rejecting the input
+func (e *InputValidationError) ReturnCode() ReturnCode {
+	switch {
+	case errors.Is(e.Err, ErrGasLockedExceedsGasProvided):
+		return OutOfGas
+	case errors.Is(e.Err, ErrTooManyArguments), errors.Is(e.Err, ErrArgumentsTooLarge):
+		return FunctionWrongSignature
+	case errors.Is(e.Err, ErrInvalidCallType), errors.Is(e.Err, ErrNilContractCallInput), errors.Is(e.Err, ErrNilContractCreateInput):
+		return ExecutionFailed
+	default:
+		return UserError
+	}
+}
+
+// Validate checks the input against the limits, before it is executed
+func (input *ContractCallInput) Validate(limits InputLimits) error {
+	if input == nil {
+		return &InputValidationError{Field: "ContractCallInput", Err: ErrNilContractCallInput}
+	}
+
+	err := input.VMInput.validate(limits)
+	if err != nil {
+		return err
+	}
+
+	return validateAddressLength("RecipientAddr", input.RecipientAddr, limits)
+}
+
+// Validate checks the input against the limits, before it is executed
+func (input *ContractCreateInput) Validate(limits InputLimits) error {
+	if input == nil {
+		return &InputValidationError{Field: "ContractCreateInput", Err: ErrNilContractCreateInput}
+	}
+
+	return input.VMInput.validate(limits)
+}
+
+func (vmInput *VMInput) validate(limits InputLimits) error {
+	if vmInput.CallValue == nil {
+		return &InputValidationError{Field: "CallValue", Err: ErrNilCallValue}
+	}
+	if vmInput.CallValue.Sign() < 0 {
+		return &InputValidationError{Field: "CallValue", Err: ErrNegativeCallValue}
+	}
+
+	err := validateAddressLength("CallerAddr", vmInput.CallerAddr, limits)
+	if err != nil {
+		return err
+	}
+
+	if vmInput.GasLocked > vmInput.GasProvided {
+		return &InputValidationError{Field: "GasLocked", Err: ErrGasLockedExceedsGasProvided}
+	}
+	if !isKnownCallType(vmInput.CallType) {
+		return &InputValidationError{Field: "CallType", Err: ErrInvalidCallType}
+	}
+
+	if limits.MaxNumArguments > 0 && len(vmInput.Arguments) > limits.MaxNumArguments {
+		return &InputValidationError{Field: "Arguments", Err: ErrTooManyArguments}
+	}
+	if limits.MaxArgumentsBytes > 0 {
+		argumentsBytes := 0
+		for _, arg := range vmInput.Arguments {
+			argumentsBytes += len(arg)
+		}
+		if argumentsBytes > limits.MaxArgumentsBytes {
+			return &InputValidationError{Field: "Arguments", Err: ErrArgumentsTooLarge}
+		}
+	}
+
+	for i, transfer := range vmInput.DCTTransfers {
+		err = validateDCTTransfer(fmt.Sprintf("DCTTransfers[%d]", i), transfer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateAddressLength(field string, address []byte, limits InputLimits) error {
+	if limits.AddressLength > 0 && len(address) != limits.AddressLength {
+		return &InputValidationError{Field: field, Err: ErrInvalidAddressLength}
+	}
+
+	return nil
+}
+
+// validateDCTTransfer checks that the transfer moves a non-negative value of a named token. A fungible token can not
+// have a nonce, while a non fungible transfer can move a fungible token, so it may have no nonce
+func validateDCTTransfer(field string, transfer *DCTTransfer) error {
+	invalidTransferErr := func(reason string) error {
+		return &InputValidationError{Field: field, Err: fmt.Errorf("%w: %s", ErrInvalidDCTTransfer, reason)}
+	}
+
+	if transfer == nil {
+		return invalidTransferErr("nil transfer")
+	}
+	if transfer.DCTValue == nil {
+		return invalidTransferErr("nil value")
+	}
+	if transfer.DCTValue.Sign() < 0 {
+		return invalidTransferErr("negative value")
+	}
+	if len(transfer.DCTTokenName) == 0 {
+		return invalidTransferErr("empty token name")
+	}
+
+	switch core.DCTType(transfer.DCTTokenType) {
+	case core.Fungible:
+		if transfer.DCTTokenNonce > 0 {
+			return invalidTransferErr("fungible token with nonce")
+		}
+	case core.NonFungible:
+	default:
+		return invalidTransferErr("unknown token type")
+	}
+
+	return nil
+}
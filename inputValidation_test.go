@@ -0,0 +1,223 @@
+package vmcommon
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core"
+	"github.com/kalyan3104/k-core/data/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testInputLimits = InputLimits{
+	AddressLength:     32,
+	MaxNumArguments:   3,
+	MaxArgumentsBytes: 10,
+}
+
+func createValidContractCallInput() *ContractCallInput {
+	return &ContractCallInput{
+		VMInput: VMInput{
+			CallerAddr:  bytes.Repeat([]byte{1}, 32),
+			Arguments:   [][]byte{[]byte("arg")},
+			CallValue:   big.NewInt(0),
+			CallType:    vm.DirectCall,
+			GasProvided: 100,
+			GasLocked:   10,
+			DCTTransfers: []*DCTTransfer{
+				{DCTValue: big.NewInt(1), DCTTokenName: []byte("TKN-abcdef"), DCTTokenType: uint32(core.Fungible)},
+				{DCTValue: big.NewInt(1), DCTTokenName: []byte("NFT-abcdef"), DCTTokenType: uint32(core.NonFungible), DCTTokenNonce: 2},
+			},
+		},
+		RecipientAddr: bytes.Repeat([]byte{2}, 32),
+		Function:      "function",
+	}
+}
+
+func TestContractCallInput_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		mutate             func(input *ContractCallInput)
+		limits             InputLimits
+		expectedErr        error
+		expectedReturnCode ReturnCode
+	}{
+		{
+			name:   "valid input",
+			mutate: func(_ *ContractCallInput) {},
+			limits: testInputLimits,
+		},
+		{
+			name: "no limits",
+			mutate: func(input *ContractCallInput) {
+				input.CallerAddr = nil
+				input.Arguments = [][]byte{{1}, {2}, {3}, bytes.Repeat([]byte{4}, 100)}
+			},
+		},
+		{
+			name:               "nil call value",
+			mutate:             func(input *ContractCallInput) { input.CallValue = nil },
+			limits:             testInputLimits,
+			expectedErr:        ErrNilCallValue,
+			expectedReturnCode: UserError,
+		},
+		{
+			name:               "negative call value",
+			mutate:             func(input *ContractCallInput) { input.CallValue = big.NewInt(-1) },
+			limits:             testInputLimits,
+			expectedErr:        ErrNegativeCallValue,
+			expectedReturnCode: UserError,
+		},
+		{
+			name:               "invalid caller address length",
+			mutate:             func(input *ContractCallInput) { input.CallerAddr = []byte("caller") },
+			limits:             testInputLimits,
+			expectedErr:        ErrInvalidAddressLength,
+			expectedReturnCode: UserError,
+		},
+		{
+			name:               "invalid recipient address length",
+			mutate:             func(input *ContractCallInput) { input.RecipientAddr = nil },
+			limits:             testInputLimits,
+			expectedErr:        ErrInvalidAddressLength,
+			expectedReturnCode: UserError,
+		},
+		{
+			name:               "gas locked exceeds gas provided",
+			mutate:             func(input *ContractCallInput) { input.GasLocked = 101 },
+			limits:             testInputLimits,
+			expectedErr:        ErrGasLockedExceedsGasProvided,
+			expectedReturnCode: OutOfGas,
+		},
+		{
+			name:               "invalid call type",
+			mutate:             func(input *ContractCallInput) { input.CallType = vm.CallType(99) },
+			limits:             testInputLimits,
+			expectedErr:        ErrInvalidCallType,
+			expectedReturnCode: ExecutionFailed,
+		},
+		{
+			name:               "too many arguments",
+			mutate:             func(input *ContractCallInput) { input.Arguments = [][]byte{{1}, {2}, {3}, {4}} },
+			limits:             testInputLimits,
+			expectedErr:        ErrTooManyArguments,
+			expectedReturnCode: FunctionWrongSignature,
+		},
+		{
+			name:               "arguments too large",
+			mutate:             func(input *ContractCallInput) { input.Arguments = [][]byte{[]byte("12345"), []byte("123456")} },
+			limits:             testInputLimits,
+			expectedErr:        ErrArgumentsTooLarge,
+			expectedReturnCode: FunctionWrongSignature,
+		},
+		{
+			name:               "nil dct transfer",
+			mutate:             func(input *ContractCallInput) { input.DCTTransfers[1] = nil },
+			limits:             testInputLimits,
+			expectedErr:        ErrInvalidDCTTransfer,
+			expectedReturnCode: UserError,
+		},
+		{
+			name:               "nil dct value",
+			mutate:             func(input *ContractCallInput) { input.DCTTransfers[0].DCTValue = nil },
+			limits:             testInputLimits,
+			expectedErr:        ErrInvalidDCTTransfer,
+			expectedReturnCode: UserError,
+		},
+		{
+			name:               "negative dct value",
+			mutate:             func(input *ContractCallInput) { input.DCTTransfers[0].DCTValue = big.NewInt(-1) },
+			limits:             testInputLimits,
+			expectedErr:        ErrInvalidDCTTransfer,
+			expectedReturnCode: UserError,
+		},
+		{
+			name:               "empty token name",
+			mutate:             func(input *ContractCallInput) { input.DCTTransfers[0].DCTTokenName = nil },
+			limits:             testInputLimits,
+			expectedErr:        ErrInvalidDCTTransfer,
+			expectedReturnCode: UserError,
+		},
+		{
+			name:               "fungible token with nonce",
+			mutate:             func(input *ContractCallInput) { input.DCTTransfers[0].DCTTokenNonce = 1 },
+			limits:             testInputLimits,
+			expectedErr:        ErrInvalidDCTTransfer,
+			expectedReturnCode: UserError,
+		},
+		{
+			name:   "non fungible transfer of a fungible token",
+			mutate: func(input *ContractCallInput) { input.DCTTransfers[1].DCTTokenNonce = 0 },
+			limits: testInputLimits,
+		},
+		{
+			name:               "unknown token type",
+			mutate:             func(input *ContractCallInput) { input.DCTTransfers[1].DCTTokenType = 7 },
+			limits:             testInputLimits,
+			expectedErr:        ErrInvalidDCTTransfer,
+			expectedReturnCode: UserError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := createValidContractCallInput()
+			tt.mutate(input)
+
+			err := input.Validate(tt.limits)
+			if tt.expectedErr == nil {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.expectedErr), err)
+			var validationErr *InputValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.expectedReturnCode, validationErr.ReturnCode())
+		})
+	}
+}
+
+func TestContractCallInput_ValidateNilInput(t *testing.T) {
+	t.Parallel()
+
+	var input *ContractCallInput
+	err := input.Validate(testInputLimits)
+	assert.True(t, errors.Is(err, ErrNilContractCallInput))
+
+	var validationErr *InputValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, ExecutionFailed, validationErr.ReturnCode())
+}
+
+func TestContractCreateInput_Validate(t *testing.T) {
+	t.Parallel()
+
+	var nilInput *ContractCreateInput
+	assert.True(t, errors.Is(nilInput.Validate(testInputLimits), ErrNilContractCreateInput))
+
+	input := &ContractCreateInput{
+		VMInput:      createValidContractCallInput().VMInput,
+		ContractCode: []byte("code"),
+	}
+	assert.Nil(t, input.Validate(testInputLimits))
+
+	input.CallValue = nil
+	err := input.Validate(testInputLimits)
+	assert.True(t, errors.Is(err, ErrNilCallValue))
+	assert.Equal(t, "CallValue: nil call value", err.Error())
+
+	input.CallValue = big.NewInt(0)
+	input.DCTTransfers[0].DCTTokenName = nil
+	err = input.Validate(testInputLimits)
+	assert.True(t, errors.Is(err, ErrInvalidDCTTransfer))
+	assert.Equal(t, "DCTTransfers[0]: invalid dct transfer: empty token name", err.Error())
+}
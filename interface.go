@@ -332,51 +332,126 @@ type AcceptPayableChecker interface {
 	IsInterfaceNil() bool
 }
 
-// EnableEpochsHandler is used to verify which flags are set in the current epoch based on EnableEpochs config
-type EnableEpochsHandler interface {
+// BuiltInFunctionGasEstimator defines a built-in function able to compute the gas it consumes for an input
+// from the current gas costs and the input only: accounts are neither read nor mutated. When the cost depends on the
+// stored data, it is derived from the arguments: the values are considered to be stored under empty keys and the token
+// data copied to another shard is charged by the length of the transfer arguments
+type BuiltInFunctionGasEstimator interface {
+	EstimateGas(vmInput *ContractCallInput) (uint64, error)
+	IsInterfaceNil() bool
+}
+
+// BuiltInFunctionReadWriteSetProvider defines a built-in function able to derive, from the input only, the account
+// data a call reads and writes
+type BuiltInFunctionReadWriteSetProvider interface {
+	ReadWriteSet(vmInput *ContractCallInput) (*ReadWriteSet, error)
+	IsInterfaceNil() bool
+}
+
+// LegacyEnableEpochsHandler holds the flag specific methods of the enable epochs handler, kept for compatibility.
+// Each of them is an adapter over IsFlagEnabled or GetActivationEpoch
+type LegacyEnableEpochsHandler interface {
+	// Deprecated: use IsFlagEnabled(GlobalMintBurnFlag)
 	IsGlobalMintBurnFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(DCTTransferRoleFlag)
 	IsDCTTransferRoleFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(BuiltInFunctionsFlag)
 	IsBuiltInFunctionsFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(CheckCorrectTokenIDForTransferRoleFlag)
 	IsCheckCorrectTokenIDForTransferRoleFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(MultiDCTTransferFixOnCallBackFlag)
 	IsMultiDCTTransferFixOnCallBackFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(FixOOGReturnCodeFlag)
 	IsFixOOGReturnCodeFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(RemoveNonUpdatedStorageFlag)
 	IsRemoveNonUpdatedStorageFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(CreateNFTThroughExecByCallerFlag)
 	IsCreateNFTThroughExecByCallerFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(StorageAPICostOptimizationFlag)
 	IsStorageAPICostOptimizationFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(FailExecutionOnEveryAPIErrorFlag)
 	IsFailExecutionOnEveryAPIErrorFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(ManagedCryptoAPIsFlag)
 	IsManagedCryptoAPIsFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(SCDeployFlag)
 	IsSCDeployFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(AheadOfTimeGasUsageFlag)
 	IsAheadOfTimeGasUsageFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(RepairCallbackFlag)
 	IsRepairCallbackFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(DisableExecByCallerFlag)
 	IsDisableExecByCallerFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(RefactorContextFlag)
 	IsRefactorContextFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(CheckFunctionArgumentFlag)
 	IsCheckFunctionArgumentFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(CheckExecuteOnReadOnlyFlag)
 	IsCheckExecuteOnReadOnlyFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(FixAsyncCallbackCheckFlag)
 	IsFixAsyncCallbackCheckFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(SaveToSystemAccountFlag)
 	IsSaveToSystemAccountFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(CheckFrozenCollectionFlag)
 	IsCheckFrozenCollectionFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(SendAlwaysFlag)
 	IsSendAlwaysFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(ValueLengthCheckFlag)
 	IsValueLengthCheckFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(CheckTransferFlag)
 	IsCheckTransferFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(TransferToMetaFlag)
 	IsTransferToMetaFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(DCTNFTImprovementV1Flag)
 	IsDCTNFTImprovementV1FlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(FixOldTokenLiquidityFlag)
 	IsFixOldTokenLiquidityEnabled() bool
+	// Deprecated: use IsFlagEnabled(RuntimeMemStoreLimitFlag)
 	IsRuntimeMemStoreLimitEnabled() bool
+	// Deprecated: use IsFlagEnabled(MaxBlockchainHookCountersFlag)
 	IsMaxBlockchainHookCountersFlagEnabled() bool
+	// Deprecated: use IsFlagEnabled(WipeSingleNFTLiquidityDecreaseFlag)
 	IsWipeSingleNFTLiquidityDecreaseEnabled() bool
+	// Deprecated: use IsFlagEnabled(AlwaysSaveTokenMetaDataFlag)
 	IsAlwaysSaveTokenMetaDataEnabled() bool
+	// Deprecated: use IsFlagEnabled(RuntimeCodeSizeFixFlag)
 	IsRuntimeCodeSizeFixEnabled() bool
 
+	// Deprecated: use GetActivationEpoch(MultiDCTTransferFixOnCallBackFlag)
 	MultiDCTTransferAsyncCallBackEnableEpoch() uint32
+	// Deprecated: use GetActivationEpoch(FixOOGReturnCodeFlag)
 	FixOOGReturnCodeEnableEpoch() uint32
+	// Deprecated: use GetActivationEpoch(RemoveNonUpdatedStorageFlag)
 	RemoveNonUpdatedStorageEnableEpoch() uint32
+	// Deprecated: use GetActivationEpoch(CreateNFTThroughExecByCallerFlag)
 	CreateNFTThroughExecByCallerEnableEpoch() uint32
+	// Deprecated: use GetActivationEpoch(FailExecutionOnEveryAPIErrorFlag)
 	FixFailExecutionOnErrorEnableEpoch() uint32
+	// Deprecated: use GetActivationEpoch(ManagedCryptoAPIsFlag)
 	ManagedCryptoAPIEnableEpoch() uint32
+	// Deprecated: use GetActivationEpoch(DisableExecByCallerFlag)
 	DisableExecByCallerEnableEpoch() uint32
+	// Deprecated: use GetActivationEpoch(RefactorContextFlag)
 	RefactorContextEnableEpoch() uint32
+	// Deprecated: use GetActivationEpoch(CheckExecuteOnReadOnlyFlag)
 	CheckExecuteReadOnlyEnableEpoch() uint32
+	// Deprecated: use GetActivationEpoch(StorageAPICostOptimizationFlag)
 	StorageAPICostOptimizationEnableEpoch() uint32
+}
+
+// EnableEpochsHandler is used to verify which flags are set in the current epoch based on EnableEpochs config.
+// GetActivationEpoch returns the first epoch the flag is enabled in, so it returns 0 for the flags enabled only
+// before their configured epoch, as GlobalMintBurnFlag
+type EnableEpochsHandler interface {
+	LegacyEnableEpochsHandler
+	IsFlagDefined(flag EnableEpochFlag) bool
+	IsFlagEnabled(flag EnableEpochFlag) bool
+	GetActivationEpoch(flag EnableEpochFlag) uint32
+	IsInterfaceNil() bool
+}
 
+// Hasher provides hashing services
+type Hasher interface {
+	Compute(string) []byte
+	Size() int
 	IsInterfaceNil() bool
 }
@@ -1,5 +1,7 @@
 package mock
 
+import vmcommon "github.com/kalyan3104/k-vm-common-go"
+
 // EnableEpochsHandlerStub -
 type EnableEpochsHandlerStub struct {
 	IsGlobalMintBurnFlagEnabledField                     bool
@@ -34,6 +36,7 @@ type EnableEpochsHandlerStub struct {
 	IsMaxBlockchainHookCountersFlagEnabledField          bool
 	IsWipeSingleNFTLiquidityDecreaseEnabledField         bool
 	IsAlwaysSaveTokenMetaDataEnabledField                bool
+	IsBuiltInFunctionsLogsFlagEnabledField               bool
 	MultiDCTTransferAsyncCallBackEnableEpochField        uint32
 	FixOOGReturnCodeEnableEpochField                     uint32
 	RemoveNonUpdatedStorageEnableEpochField              uint32
@@ -46,6 +49,90 @@ type EnableEpochsHandlerStub struct {
 	RefactorContextEnableEpochField                      uint32
 	CheckExecuteReadOnlyEnableEpochField                 uint32
 	StorageAPICostOptimizationEnableEpochField           uint32
+	IsFlagDefinedCalled                                  func(flag vmcommon.EnableEpochFlag) bool
+	IsFlagEnabledCalled                                  func(flag vmcommon.EnableEpochFlag) bool
+	GetActivationEpochCalled                             func(flag vmcommon.EnableEpochFlag) uint32
+}
+
+// IsFlagDefined -
+func (stub *EnableEpochsHandlerStub) IsFlagDefined(flag vmcommon.EnableEpochFlag) bool {
+	if stub.IsFlagDefinedCalled != nil {
+		return stub.IsFlagDefinedCalled(flag)
+	}
+
+	_, found := stub.flagsFields()[flag]
+	return found
+}
+
+// IsFlagEnabled -
+func (stub *EnableEpochsHandlerStub) IsFlagEnabled(flag vmcommon.EnableEpochFlag) bool {
+	if stub.IsFlagEnabledCalled != nil {
+		return stub.IsFlagEnabledCalled(flag)
+	}
+
+	return stub.flagsFields()[flag]
+}
+
+// GetActivationEpoch -
+func (stub *EnableEpochsHandlerStub) GetActivationEpoch(flag vmcommon.EnableEpochFlag) uint32 {
+	if stub.GetActivationEpochCalled != nil {
+		return stub.GetActivationEpochCalled(flag)
+	}
+
+	return stub.epochsFields()[flag]
+}
+
+func (stub *EnableEpochsHandlerStub) flagsFields() map[vmcommon.EnableEpochFlag]bool {
+	return map[vmcommon.EnableEpochFlag]bool{
+		vmcommon.GlobalMintBurnFlag:                     stub.IsGlobalMintBurnFlagEnabledField,
+		vmcommon.DCTTransferRoleFlag:                    stub.IsDCTTransferRoleFlagEnabledField,
+		vmcommon.BuiltInFunctionsFlag:                   stub.IsBuiltInFunctionsFlagEnabledField,
+		vmcommon.CheckCorrectTokenIDForTransferRoleFlag: stub.IsCheckCorrectTokenIDForTransferRoleFlagEnabledField,
+		vmcommon.MultiDCTTransferFixOnCallBackFlag:      stub.IsMultiDCTTransferFixOnCallBackFlagEnabledField,
+		vmcommon.FixOOGReturnCodeFlag:                   stub.IsFixOOGReturnCodeFlagEnabledField,
+		vmcommon.RemoveNonUpdatedStorageFlag:            stub.IsRemoveNonUpdatedStorageFlagEnabledField,
+		vmcommon.CreateNFTThroughExecByCallerFlag:       stub.IsCreateNFTThroughExecByCallerFlagEnabledField,
+		vmcommon.StorageAPICostOptimizationFlag:         stub.IsStorageAPICostOptimizationFlagEnabledField,
+		vmcommon.FailExecutionOnEveryAPIErrorFlag:       stub.IsFailExecutionOnEveryAPIErrorFlagEnabledField,
+		vmcommon.ManagedCryptoAPIsFlag:                  stub.IsManagedCryptoAPIsFlagEnabledField,
+		vmcommon.SCDeployFlag:                           stub.IsSCDeployFlagEnabledField,
+		vmcommon.AheadOfTimeGasUsageFlag:                stub.IsAheadOfTimeGasUsageFlagEnabledField,
+		vmcommon.RepairCallbackFlag:                     stub.IsRepairCallbackFlagEnabledField,
+		vmcommon.DisableExecByCallerFlag:                stub.IsDisableExecByCallerFlagEnabledField,
+		vmcommon.RefactorContextFlag:                    stub.IsRefactorContextFlagEnabledField,
+		vmcommon.CheckFunctionArgumentFlag:              stub.IsCheckFunctionArgumentFlagEnabledField,
+		vmcommon.CheckExecuteOnReadOnlyFlag:             stub.IsCheckExecuteOnReadOnlyFlagEnabledField,
+		vmcommon.FixAsyncCallbackCheckFlag:              stub.IsFixAsyncCallbackCheckFlagEnabledField,
+		vmcommon.SaveToSystemAccountFlag:                stub.IsSaveToSystemAccountFlagEnabledField,
+		vmcommon.CheckFrozenCollectionFlag:              stub.IsCheckFrozenCollectionFlagEnabledField,
+		vmcommon.SendAlwaysFlag:                         stub.IsSendAlwaysFlagEnabledField,
+		vmcommon.ValueLengthCheckFlag:                   stub.IsValueLengthCheckFlagEnabledField,
+		vmcommon.CheckTransferFlag:                      stub.IsCheckTransferFlagEnabledField,
+		vmcommon.TransferToMetaFlag:                     stub.IsTransferToMetaFlagEnabledField,
+		vmcommon.DCTNFTImprovementV1Flag:                stub.IsDCTNFTImprovementV1FlagEnabledField,
+		vmcommon.FixOldTokenLiquidityFlag:               stub.IsFixOldTokenLiquidityEnabledField,
+		vmcommon.RuntimeMemStoreLimitFlag:               stub.IsRuntimeMemStoreLimitEnabledField,
+		vmcommon.RuntimeCodeSizeFixFlag:                 stub.IsRuntimeCodeSizeFixEnabledField,
+		vmcommon.MaxBlockchainHookCountersFlag:          stub.IsMaxBlockchainHookCountersFlagEnabledField,
+		vmcommon.WipeSingleNFTLiquidityDecreaseFlag:     stub.IsWipeSingleNFTLiquidityDecreaseEnabledField,
+		vmcommon.AlwaysSaveTokenMetaDataFlag:            stub.IsAlwaysSaveTokenMetaDataEnabledField,
+		vmcommon.BuiltInFunctionsLogsFlag:               stub.IsBuiltInFunctionsLogsFlagEnabledField,
+	}
+}
+
+func (stub *EnableEpochsHandlerStub) epochsFields() map[vmcommon.EnableEpochFlag]uint32 {
+	return map[vmcommon.EnableEpochFlag]uint32{
+		vmcommon.MultiDCTTransferFixOnCallBackFlag: stub.MultiDCTTransferAsyncCallBackEnableEpochField,
+		vmcommon.FixOOGReturnCodeFlag:              stub.FixOOGReturnCodeEnableEpochField,
+		vmcommon.RemoveNonUpdatedStorageFlag:       stub.RemoveNonUpdatedStorageEnableEpochField,
+		vmcommon.CreateNFTThroughExecByCallerFlag:  stub.CreateNFTThroughExecByCallerEnableEpochField,
+		vmcommon.FailExecutionOnEveryAPIErrorFlag:  stub.FixFailExecutionOnErrorEnableEpochField,
+		vmcommon.ManagedCryptoAPIsFlag:             stub.ManagedCryptoAPIEnableEpochField,
+		vmcommon.DisableExecByCallerFlag:           stub.DisableExecByCallerEnableEpochField,
+		vmcommon.RefactorContextFlag:               stub.RefactorContextEnableEpochField,
+		vmcommon.CheckExecuteOnReadOnlyFlag:        stub.CheckExecuteReadOnlyEnableEpochField,
+		vmcommon.StorageAPICostOptimizationFlag:    stub.StorageAPICostOptimizationEnableEpochField,
+	}
 }
 
 // IsGlobalMintBurnFlagEnabled -
@@ -0,0 +1,18 @@
+package mock
+
+// EpochSubscriberHandlerStub -
+type EpochSubscriberHandlerStub struct {
+	EpochConfirmedCalled func(epoch uint32, timestamp uint64)
+}
+
+// EpochConfirmed -
+func (stub *EpochSubscriberHandlerStub) EpochConfirmed(epoch uint32, timestamp uint64) {
+	if stub.EpochConfirmedCalled != nil {
+		stub.EpochConfirmedCalled(epoch, timestamp)
+	}
+}
+
+// IsInterfaceNil -
+func (stub *EpochSubscriberHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"reflect"
+
+	"github.com/kalyan3104/k-core/core"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+// CreateGasMap returns a gas map holding every base operation and built-in function cost set to the provided value
+func CreateGasMap(value uint64) map[string]map[string]uint64 {
+	return map[string]map[string]uint64{
+		core.BaseOperationCostString: createCostsMap(vmcommon.BaseOperationCost{}, value),
+		core.BuiltInCostString:       createCostsMap(vmcommon.BuiltInCost{}, value),
+	}
+}
+
+func createCostsMap(costs interface{}, value uint64) map[string]uint64 {
+	costsMap := make(map[string]uint64)
+	costsType := reflect.TypeOf(costs)
+	for i := 0; i < costsType.NumField(); i++ {
+		costsMap[costsType.Field(i).Name] = value
+	}
+
+	return costsMap
+}
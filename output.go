@@ -155,6 +155,10 @@ type VMOutput struct {
 	// The logs should be accessible to the UI.
 	// The logs are part of the transaction receipt.
 	Logs []*LogEntry
+
+	// GasTrace lists, line by line, the gas charges made during execution.
+	// It is set only in debug mode and it is not part of the execution result.
+	GasTrace *GasTrace
 }
 
 // GetFirstReturnData is a helper function that returns the first ReturnData of VMOutput, interpreted as specified.
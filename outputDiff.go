@@ -0,0 +1,273 @@
+package vmcommon
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// OutputDiffOptions selects which parts of the outputs are left out of the comparison
+type OutputDiffOptions struct {
+	// IgnoreGas skips the gas remaining, the gas refund, the gas used by the accounts and the gas limit and gas
+	// locked of the output transfers
+	IgnoreGas bool
+	// IgnoreLogs skips the log entries
+	IgnoreLogs bool
+}
+
+// OutputDifference is a difference between two outputs, found at the path of the field
+type OutputDifference struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// String returns the human-readable form of the difference
+func (d OutputDifference) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s", d.Path, d.Expected, d.Actual)
+}
+
+// DiffVMOutputs compares the two outputs field by field and returns the differences in field order, with the map
+// entries sorted by key. Nil and empty slices and maps are equal and so are nil and zero big integers. The GasTrace
+// is a debug only field and it is not compared. A nil output is equal to an empty one
+func DiffVMOutputs(expected *VMOutput, actual *VMOutput, options OutputDiffOptions) []OutputDifference {
+	d := &outputDiffer{options: options}
+	d.diffVMOutputs("VMOutput", expected, actual)
+
+	return d.differences
+}
+
+// VMOutputsEqual returns true if the two outputs have no differences
+func VMOutputsEqual(expected *VMOutput, actual *VMOutput, options OutputDiffOptions) bool {
+	return len(DiffVMOutputs(expected, actual, options)) == 0
+}
+
+// DiffOutputAccounts compares the two output accounts field by field and returns the differences in field order
+func DiffOutputAccounts(expected *OutputAccount, actual *OutputAccount, options OutputDiffOptions) []OutputDifference {
+	d := &outputDiffer{options: options}
+	d.diffOutputAccounts("OutputAccount", expected, actual)
+
+	return d.differences
+}
+
+type outputDiffer struct {
+	options     OutputDiffOptions
+	differences []OutputDifference
+}
+
+func (d *outputDiffer) add(path string, expected string, actual string) {
+	d.differences = append(d.differences, OutputDifference{Path: path, Expected: expected, Actual: actual})
+}
+
+func (d *outputDiffer) diffVMOutputs(path string, expected *VMOutput, actual *VMOutput) {
+	if expected == nil {
+		expected = &VMOutput{}
+	}
+	if actual == nil {
+		actual = &VMOutput{}
+	}
+
+	d.diffBytesList(path+".ReturnData", expected.ReturnData, actual.ReturnData)
+	if expected.ReturnCode != actual.ReturnCode {
+		d.add(path+".ReturnCode", expected.ReturnCode.String(), actual.ReturnCode.String())
+	}
+	if expected.ReturnMessage != actual.ReturnMessage {
+		d.add(path+".ReturnMessage", fmt.Sprintf("%q", expected.ReturnMessage), fmt.Sprintf("%q", actual.ReturnMessage))
+	}
+	if !d.options.IgnoreGas {
+		d.diffUint64(path+".GasRemaining", expected.GasRemaining, actual.GasRemaining)
+		d.diffBigInt(path+".GasRefund", expected.GasRefund, actual.GasRefund)
+	}
+
+	for _, key := range unionOfOutputAccountKeys(expected.OutputAccounts, actual.OutputAccounts) {
+		accountPath := fmt.Sprintf("%s.OutputAccounts[%s]", path, formatDiffBytes([]byte(key)))
+		expectedAccount, expectedFound := expected.OutputAccounts[key]
+		actualAccount, actualFound := actual.OutputAccounts[key]
+		if !expectedFound || !actualFound {
+			d.add(accountPath, formatPresence(expectedFound), formatPresence(actualFound))
+			continue
+		}
+
+		d.diffOutputAccounts(accountPath, expectedAccount, actualAccount)
+	}
+
+	d.diffBytesList(path+".DeletedAccounts", expected.DeletedAccounts, actual.DeletedAccounts)
+	d.diffBytesList(path+".TouchedAccounts", expected.TouchedAccounts, actual.TouchedAccounts)
+
+	if !d.options.IgnoreLogs {
+		d.diffLogs(path+".Logs", expected.Logs, actual.Logs)
+	}
+}
+
+func (d *outputDiffer) diffOutputAccounts(path string, expected *OutputAccount, actual *OutputAccount) {
+	if expected == nil || actual == nil {
+		if expected != actual {
+			d.add(path, formatNil(expected == nil), formatNil(actual == nil))
+		}
+		return
+	}
+
+	d.diffBytes(path+".Address", expected.Address, actual.Address)
+	d.diffUint64(path+".Nonce", expected.Nonce, actual.Nonce)
+	d.diffBigInt(path+".Balance", expected.Balance, actual.Balance)
+	d.diffBigInt(path+".BalanceDelta", expected.BalanceDelta, actual.BalanceDelta)
+	d.diffStorageUpdates(path+".StorageUpdates", expected.StorageUpdates, actual.StorageUpdates)
+	d.diffBytes(path+".Code", expected.Code, actual.Code)
+	d.diffBytes(path+".CodeMetadata", expected.CodeMetadata, actual.CodeMetadata)
+	d.diffBytes(path+".CodeDeployerAddress", expected.CodeDeployerAddress, actual.CodeDeployerAddress)
+	d.diffOutputTransfers(path+".OutputTransfers", expected.OutputTransfers, actual.OutputTransfers)
+	if !d.options.IgnoreGas {
+		d.diffUint64(path+".GasUsed", expected.GasUsed, actual.GasUsed)
+	}
+	d.diffUint64(path+".BytesAddedToStorage", expected.BytesAddedToStorage, actual.BytesAddedToStorage)
+	d.diffUint64(path+".BytesDeletedFromStorage", expected.BytesDeletedFromStorage, actual.BytesDeletedFromStorage)
+}
+
+func (d *outputDiffer) diffStorageUpdates(path string, expected map[string]*StorageUpdate, actual map[string]*StorageUpdate) {
+	for _, key := range unionOfStorageUpdateKeys(expected, actual) {
+		updatePath := fmt.Sprintf("%s[%s]", path, formatDiffBytes([]byte(key)))
+		expectedUpdate, expectedFound := expected[key]
+		actualUpdate, actualFound := actual[key]
+		if !expectedFound || !actualFound {
+			d.add(updatePath, formatPresence(expectedFound), formatPresence(actualFound))
+			continue
+		}
+		if expectedUpdate == nil || actualUpdate == nil {
+			if expectedUpdate != actualUpdate {
+				d.add(updatePath, formatNil(expectedUpdate == nil), formatNil(actualUpdate == nil))
+			}
+			continue
+		}
+
+		d.diffBytes(updatePath+".Offset", expectedUpdate.Offset, actualUpdate.Offset)
+		d.diffBytes(updatePath+".Data", expectedUpdate.Data, actualUpdate.Data)
+		if expectedUpdate.Written != actualUpdate.Written {
+			d.add(updatePath+".Written", fmt.Sprint(expectedUpdate.Written), fmt.Sprint(actualUpdate.Written))
+		}
+	}
+}
+
+func (d *outputDiffer) diffOutputTransfers(path string, expected []OutputTransfer, actual []OutputTransfer) {
+	if len(expected) != len(actual) {
+		d.add(path+".len", fmt.Sprint(len(expected)), fmt.Sprint(len(actual)))
+	}
+
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		transferPath := fmt.Sprintf("%s[%d]", path, i)
+		d.diffBigInt(transferPath+".Value", expected[i].Value, actual[i].Value)
+		if !d.options.IgnoreGas {
+			d.diffUint64(transferPath+".GasLimit", expected[i].GasLimit, actual[i].GasLimit)
+			d.diffUint64(transferPath+".GasLocked", expected[i].GasLocked, actual[i].GasLocked)
+		}
+		d.diffBytes(transferPath+".Data", expected[i].Data, actual[i].Data)
+		if expected[i].CallType != actual[i].CallType {
+			d.add(transferPath+".CallType", expected[i].CallType.ToString(), actual[i].CallType.ToString())
+		}
+		d.diffBytes(transferPath+".SenderAddress", expected[i].SenderAddress, actual[i].SenderAddress)
+	}
+}
+
+func (d *outputDiffer) diffLogs(path string, expected []*LogEntry, actual []*LogEntry) {
+	if len(expected) != len(actual) {
+		d.add(path+".len", fmt.Sprint(len(expected)), fmt.Sprint(len(actual)))
+	}
+
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		logPath := fmt.Sprintf("%s[%d]", path, i)
+		if expected[i] == nil || actual[i] == nil {
+			if expected[i] != actual[i] {
+				d.add(logPath, formatNil(expected[i] == nil), formatNil(actual[i] == nil))
+			}
+			continue
+		}
+
+		d.diffBytes(logPath+".Identifier", expected[i].Identifier, actual[i].Identifier)
+		d.diffBytes(logPath+".Address", expected[i].Address, actual[i].Address)
+		d.diffBytesList(logPath+".Topics", expected[i].Topics, actual[i].Topics)
+		d.diffBytes(logPath+".Data", expected[i].Data, actual[i].Data)
+	}
+}
+
+func (d *outputDiffer) diffBytesList(path string, expected [][]byte, actual [][]byte) {
+	if len(expected) != len(actual) {
+		d.add(path+".len", fmt.Sprint(len(expected)), fmt.Sprint(len(actual)))
+	}
+
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		d.diffBytes(fmt.Sprintf("%s[%d]", path, i), expected[i], actual[i])
+	}
+}
+
+func (d *outputDiffer) diffBytes(path string, expected []byte, actual []byte) {
+	if string(expected) != string(actual) {
+		d.add(path, formatDiffBytes(expected), formatDiffBytes(actual))
+	}
+}
+
+func (d *outputDiffer) diffUint64(path string, expected uint64, actual uint64) {
+	if expected != actual {
+		d.add(path, fmt.Sprint(expected), fmt.Sprint(actual))
+	}
+}
+
+func (d *outputDiffer) diffBigInt(path string, expected *big.Int, actual *big.Int) {
+	expectedValue := bigIntString(expected)
+	actualValue := bigIntString(actual)
+	if expectedValue != actualValue {
+		d.add(path, expectedValue, actualValue)
+	}
+}
+
+func unionOfOutputAccountKeys(expected map[string]*OutputAccount, actual map[string]*OutputAccount) []string {
+	keys := sortedOutputAccountKeys(expected)
+	for _, key := range sortedOutputAccountKeys(actual) {
+		_, found := expected[key]
+		if !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func unionOfStorageUpdateKeys(expected map[string]*StorageUpdate, actual map[string]*StorageUpdate) []string {
+	keys := sortedStorageUpdateKeys(expected)
+	for _, key := range sortedStorageUpdateKeys(actual) {
+		_, found := expected[key]
+		if !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// formatDiffBytes returns the bytes as a quoted string when they are printable and as hex otherwise
+func formatDiffBytes(value []byte) string {
+	for _, b := range value {
+		if b < 0x20 || b > 0x7e {
+			return "0x" + hex.EncodeToString(value)
+		}
+	}
+
+	return fmt.Sprintf("%q", value)
+}
+
+func formatPresence(found bool) string {
+	if found {
+		return "present"
+	}
+
+	return "missing"
+}
+
+func formatNil(isNil bool) string {
+	if isNil {
+		return "nil"
+	}
+
+	return "not nil"
+}
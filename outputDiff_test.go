@@ -0,0 +1,96 @@
+package vmcommon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/data/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffVMOutputs_EqualOutputs(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, DiffVMOutputs(createEncodingTestVMOutput(), createEncodingTestVMOutput(), OutputDiffOptions{}))
+	assert.Empty(t, DiffVMOutputs(nil, &VMOutput{}, OutputDiffOptions{}))
+	assert.True(t, VMOutputsEqual(nil, nil, OutputDiffOptions{}))
+
+	withNil := &VMOutput{OutputAccounts: map[string]*OutputAccount{"addr": {Address: []byte("addr")}}}
+	withEmpty := &VMOutput{
+		ReturnData: make([][]byte, 0),
+		GasRefund:  big.NewInt(0),
+		OutputAccounts: map[string]*OutputAccount{
+			"addr": {
+				Address:        []byte("addr"),
+				Balance:        big.NewInt(0),
+				BalanceDelta:   big.NewInt(0),
+				StorageUpdates: make(map[string]*StorageUpdate),
+				Code:           make([]byte, 0),
+			},
+		},
+		Logs: make([]*LogEntry, 0),
+	}
+	assert.True(t, VMOutputsEqual(withNil, withEmpty, OutputDiffOptions{}))
+
+	withTrace := createEncodingTestVMOutput()
+	withTrace.GasTrace = NewGasTrace()
+	withTrace.GasTrace.Charge("label", 1)
+	assert.True(t, VMOutputsEqual(createEncodingTestVMOutput(), withTrace, OutputDiffOptions{}))
+}
+
+func TestDiffVMOutputs_ReportsPathQualifiedDifferences(t *testing.T) {
+	t.Parallel()
+
+	actual := createEncodingTestVMOutput()
+	actual.ReturnData = actual.ReturnData[:1]
+	actual.ReturnCode = UserError
+	actual.OutputAccounts["addr1"].BalanceDelta = big.NewInt(-6)
+	actual.OutputAccounts["addr1"].StorageUpdates["key1"].Data = []byte{0x00, 0x01}
+	delete(actual.OutputAccounts["addr1"].StorageUpdates, "key2")
+	actual.OutputAccounts["addr1"].OutputTransfers[0].CallType = vm.DirectCall
+	actual.OutputAccounts["addr3"] = &OutputAccount{Address: []byte("addr3")}
+	actual.Logs[0].Topics[0] = []byte("other")
+
+	expectedDifferences := []OutputDifference{
+		{Path: "VMOutput.ReturnData.len", Expected: "2", Actual: "1"},
+		{Path: "VMOutput.ReturnCode", Expected: "ok", Actual: "user error"},
+		{Path: `VMOutput.OutputAccounts["addr1"].BalanceDelta`, Expected: "-5", Actual: "-6"},
+		{Path: `VMOutput.OutputAccounts["addr1"].StorageUpdates["key1"].Data`, Expected: `"value1"`, Actual: "0x0001"},
+		{Path: `VMOutput.OutputAccounts["addr1"].StorageUpdates["key2"]`, Expected: "present", Actual: "missing"},
+		{Path: `VMOutput.OutputAccounts["addr1"].OutputTransfers[0].CallType`, Expected: vm.AsynchronousCall.ToString(), Actual: vm.DirectCall.ToString()},
+		{Path: `VMOutput.OutputAccounts["addr3"]`, Expected: "missing", Actual: "present"},
+		{Path: "VMOutput.Logs[0].Topics[0]", Expected: `"topic"`, Actual: `"other"`},
+	}
+	assert.Equal(t, expectedDifferences, DiffVMOutputs(createEncodingTestVMOutput(), actual, OutputDiffOptions{}))
+	assert.Equal(t, `VMOutput.ReturnCode: expected ok, got user error`, expectedDifferences[1].String())
+}
+
+func TestDiffVMOutputs_Options(t *testing.T) {
+	t.Parallel()
+
+	actual := createEncodingTestVMOutput()
+	actual.GasRemaining = 0
+	actual.GasRefund = nil
+	actual.OutputAccounts["addr1"].GasUsed = 7
+	actual.OutputAccounts["addr1"].OutputTransfers[0].GasLimit = 0
+	actual.Logs = nil
+
+	differences := DiffVMOutputs(createEncodingTestVMOutput(), actual, OutputDiffOptions{})
+	assert.Equal(t, 5, len(differences))
+
+	differences = DiffVMOutputs(createEncodingTestVMOutput(), actual, OutputDiffOptions{IgnoreGas: true})
+	assert.Equal(t, []OutputDifference{{Path: "VMOutput.Logs.len", Expected: "1", Actual: "0"}}, differences)
+
+	assert.True(t, VMOutputsEqual(createEncodingTestVMOutput(), actual, OutputDiffOptions{IgnoreGas: true, IgnoreLogs: true}))
+}
+
+func TestDiffOutputAccounts(t *testing.T) {
+	t.Parallel()
+
+	expected := &OutputAccount{Address: []byte("addr"), Nonce: 1}
+	actual := &OutputAccount{Address: []byte("addr"), Nonce: 2, BalanceDelta: big.NewInt(0)}
+
+	assert.Equal(t, []OutputDifference{{Path: "OutputAccount.Nonce", Expected: "1", Actual: "2"}}, DiffOutputAccounts(expected, actual, OutputDiffOptions{}))
+	assert.Equal(t, []OutputDifference{{Path: "OutputAccount", Expected: "not nil", Actual: "nil"}}, DiffOutputAccounts(expected, nil, OutputDiffOptions{}))
+	assert.Empty(t, DiffOutputAccounts(nil, nil, OutputDiffOptions{}))
+}
@@ -0,0 +1,347 @@
+package vmcommon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"sort"
+)
+
+// canonicalEncodingVersion prefixes the canonical binary encoding, so that the layout can evolve without two
+// different layouts ever producing the same bytes
+const canonicalEncodingVersion = byte(1)
+
+// CanonicalBytes returns a deterministic binary encoding of the VMOutput: the output accounts are sorted by their map
+// key, the storage updates by their map key, nil and zero big integers encode the same and so do nil and empty byte
+// slices. The GasTrace is a debug only field and it is not encoded. A nil VMOutput encodes as an empty one
+func (vmOutput *VMOutput) CanonicalBytes() []byte {
+	if vmOutput == nil {
+		vmOutput = &VMOutput{}
+	}
+
+	enc := &canonicalEncoder{}
+	enc.buff.WriteByte(canonicalEncodingVersion)
+	enc.writeBytesList(vmOutput.ReturnData)
+	enc.writeUint64(uint64(vmOutput.ReturnCode))
+	enc.writeBytes([]byte(vmOutput.ReturnMessage))
+	enc.writeUint64(vmOutput.GasRemaining)
+	enc.writeBigInt(vmOutput.GasRefund)
+
+	keys := sortedOutputAccountKeys(vmOutput.OutputAccounts)
+	enc.writeUint64(uint64(len(keys)))
+	for _, key := range keys {
+		enc.writeBytes([]byte(key))
+		enc.writeOutputAccount(vmOutput.OutputAccounts[key])
+	}
+
+	enc.writeBytesList(vmOutput.DeletedAccounts)
+	enc.writeBytesList(vmOutput.TouchedAccounts)
+
+	enc.writeUint64(uint64(len(vmOutput.Logs)))
+	for _, logEntry := range vmOutput.Logs {
+		enc.writeLogEntry(logEntry)
+	}
+
+	return enc.buff.Bytes()
+}
+
+// Hash returns the sha256 hash of the canonical binary encoding of the VMOutput. Two outputs have the same hash
+// if and only if they have the same canonical encoding
+func (vmOutput *VMOutput) Hash() []byte {
+	hash := sha256.Sum256(vmOutput.CanonicalBytes())
+	return hash[:]
+}
+
+// CanonicalJSON returns a deterministic, human-readable JSON encoding of the VMOutput, normalized the same way as
+// CanonicalBytes. Byte slices are hex encoded, big integers are decimal strings and the maps become lists sorted by
+// key, so that two encodings can be diffed line by line
+func (vmOutput *VMOutput) CanonicalJSON() ([]byte, error) {
+	if vmOutput == nil {
+		vmOutput = &VMOutput{}
+	}
+
+	keys := sortedOutputAccountKeys(vmOutput.OutputAccounts)
+	outputAccounts := make([]*canonicalOutputAccount, 0, len(keys))
+	for _, key := range keys {
+		outputAccounts = append(outputAccounts, newCanonicalOutputAccount(key, vmOutput.OutputAccounts[key]))
+	}
+
+	logs := make([]*canonicalLogEntry, 0, len(vmOutput.Logs))
+	for _, logEntry := range vmOutput.Logs {
+		logs = append(logs, newCanonicalLogEntry(logEntry))
+	}
+
+	canonical := &canonicalVMOutput{
+		ReturnData:      hexList(vmOutput.ReturnData),
+		ReturnCode:      int(vmOutput.ReturnCode),
+		ReturnMessage:   vmOutput.ReturnMessage,
+		GasRemaining:    vmOutput.GasRemaining,
+		GasRefund:       bigIntString(vmOutput.GasRefund),
+		OutputAccounts:  outputAccounts,
+		DeletedAccounts: hexList(vmOutput.DeletedAccounts),
+		TouchedAccounts: hexList(vmOutput.TouchedAccounts),
+		Logs:            logs,
+	}
+
+	return json.MarshalIndent(canonical, "", "  ")
+}
+
+type canonicalEncoder struct {
+	buff bytes.Buffer
+}
+
+func (enc *canonicalEncoder) writeUint64(value uint64) {
+	var encoded [8]byte
+	binary.BigEndian.PutUint64(encoded[:], value)
+	enc.buff.Write(encoded[:])
+}
+
+func (enc *canonicalEncoder) writeBool(value bool) {
+	if value {
+		enc.buff.WriteByte(1)
+		return
+	}
+	enc.buff.WriteByte(0)
+}
+
+func (enc *canonicalEncoder) writeBytes(value []byte) {
+	enc.writeUint64(uint64(len(value)))
+	enc.buff.Write(value)
+}
+
+func (enc *canonicalEncoder) writeBytesList(values [][]byte) {
+	enc.writeUint64(uint64(len(values)))
+	for _, value := range values {
+		enc.writeBytes(value)
+	}
+}
+
+func (enc *canonicalEncoder) writeBigInt(value *big.Int) {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	enc.writeBool(value.Sign() < 0)
+	enc.writeBytes(value.Bytes())
+}
+
+// writeNilMarker writes whether the pointer was nil and returns true if it was, as a nil pointer and a pointer to an
+// empty struct are different outputs
+func (enc *canonicalEncoder) writeNilMarker(isNil bool) bool {
+	enc.writeBool(isNil)
+	return isNil
+}
+
+func (enc *canonicalEncoder) writeOutputAccount(account *OutputAccount) {
+	if enc.writeNilMarker(account == nil) {
+		return
+	}
+
+	enc.writeBytes(account.Address)
+	enc.writeUint64(account.Nonce)
+	enc.writeBigInt(account.Balance)
+
+	keys := sortedStorageUpdateKeys(account.StorageUpdates)
+	enc.writeUint64(uint64(len(keys)))
+	for _, key := range keys {
+		enc.writeBytes([]byte(key))
+		update := account.StorageUpdates[key]
+		if enc.writeNilMarker(update == nil) {
+			continue
+		}
+		enc.writeBytes(update.Offset)
+		enc.writeBytes(update.Data)
+		enc.writeBool(update.Written)
+	}
+
+	enc.writeBytes(account.Code)
+	enc.writeBytes(account.CodeMetadata)
+	enc.writeBytes(account.CodeDeployerAddress)
+	enc.writeBigInt(account.BalanceDelta)
+
+	enc.writeUint64(uint64(len(account.OutputTransfers)))
+	for _, transfer := range account.OutputTransfers {
+		enc.writeBigInt(transfer.Value)
+		enc.writeUint64(transfer.GasLimit)
+		enc.writeUint64(transfer.GasLocked)
+		enc.writeBytes(transfer.Data)
+		enc.writeUint64(uint64(transfer.CallType))
+		enc.writeBytes(transfer.SenderAddress)
+	}
+
+	enc.writeUint64(account.GasUsed)
+	enc.writeUint64(account.BytesAddedToStorage)
+	enc.writeUint64(account.BytesDeletedFromStorage)
+}
+
+func (enc *canonicalEncoder) writeLogEntry(logEntry *LogEntry) {
+	if enc.writeNilMarker(logEntry == nil) {
+		return
+	}
+
+	enc.writeBytes(logEntry.Identifier)
+	enc.writeBytes(logEntry.Address)
+	enc.writeBytesList(logEntry.Topics)
+	enc.writeBytes(logEntry.Data)
+}
+
+func sortedOutputAccountKeys(outputAccounts map[string]*OutputAccount) []string {
+	keys := make([]string, 0, len(outputAccounts))
+	for key := range outputAccounts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedStorageUpdateKeys(storageUpdates map[string]*StorageUpdate) []string {
+	keys := make([]string, 0, len(storageUpdates))
+	for key := range storageUpdates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+type canonicalVMOutput struct {
+	ReturnData      []string                  `json:"returnData"`
+	ReturnCode      int                       `json:"returnCode"`
+	ReturnMessage   string                    `json:"returnMessage"`
+	GasRemaining    uint64                    `json:"gasRemaining"`
+	GasRefund       string                    `json:"gasRefund"`
+	OutputAccounts  []*canonicalOutputAccount `json:"outputAccounts"`
+	DeletedAccounts []string                  `json:"deletedAccounts"`
+	TouchedAccounts []string                  `json:"touchedAccounts"`
+	Logs            []*canonicalLogEntry      `json:"logs"`
+}
+
+type canonicalOutputAccount struct {
+	Key                     string                    `json:"key"`
+	IsNil                   bool                      `json:"isNil,omitempty"`
+	Address                 string                    `json:"address"`
+	Nonce                   uint64                    `json:"nonce"`
+	Balance                 string                    `json:"balance"`
+	StorageUpdates          []*canonicalStorageUpdate `json:"storageUpdates"`
+	Code                    string                    `json:"code"`
+	CodeMetadata            string                    `json:"codeMetadata"`
+	CodeDeployerAddress     string                    `json:"codeDeployerAddress"`
+	BalanceDelta            string                    `json:"balanceDelta"`
+	OutputTransfers         []*canonicalTransfer      `json:"outputTransfers"`
+	GasUsed                 uint64                    `json:"gasUsed"`
+	BytesAddedToStorage     uint64                    `json:"bytesAddedToStorage"`
+	BytesDeletedFromStorage uint64                    `json:"bytesDeletedFromStorage"`
+}
+
+type canonicalStorageUpdate struct {
+	Key     string `json:"key"`
+	IsNil   bool   `json:"isNil,omitempty"`
+	Offset  string `json:"offset"`
+	Data    string `json:"data"`
+	Written bool   `json:"written"`
+}
+
+type canonicalTransfer struct {
+	Value         string `json:"value"`
+	GasLimit      uint64 `json:"gasLimit"`
+	GasLocked     uint64 `json:"gasLocked"`
+	Data          string `json:"data"`
+	CallType      int    `json:"callType"`
+	SenderAddress string `json:"senderAddress"`
+}
+
+type canonicalLogEntry struct {
+	IsNil      bool     `json:"isNil,omitempty"`
+	Identifier string   `json:"identifier"`
+	Address    string   `json:"address"`
+	Topics     []string `json:"topics"`
+	Data       string   `json:"data"`
+}
+
+func newCanonicalOutputAccount(key string, account *OutputAccount) *canonicalOutputAccount {
+	if account == nil {
+		return &canonicalOutputAccount{
+			Key:             hex.EncodeToString([]byte(key)),
+			IsNil:           true,
+			Balance:         "0",
+			BalanceDelta:    "0",
+			StorageUpdates:  make([]*canonicalStorageUpdate, 0),
+			OutputTransfers: make([]*canonicalTransfer, 0),
+		}
+	}
+
+	storageKeys := sortedStorageUpdateKeys(account.StorageUpdates)
+	storageUpdates := make([]*canonicalStorageUpdate, 0, len(storageKeys))
+	for _, storageKey := range storageKeys {
+		update := account.StorageUpdates[storageKey]
+		canonicalUpdate := &canonicalStorageUpdate{Key: hex.EncodeToString([]byte(storageKey)), IsNil: update == nil}
+		if update != nil {
+			canonicalUpdate.Offset = hex.EncodeToString(update.Offset)
+			canonicalUpdate.Data = hex.EncodeToString(update.Data)
+			canonicalUpdate.Written = update.Written
+		}
+		storageUpdates = append(storageUpdates, canonicalUpdate)
+	}
+
+	transfers := make([]*canonicalTransfer, 0, len(account.OutputTransfers))
+	for _, transfer := range account.OutputTransfers {
+		transfers = append(transfers, &canonicalTransfer{
+			Value:         bigIntString(transfer.Value),
+			GasLimit:      transfer.GasLimit,
+			GasLocked:     transfer.GasLocked,
+			Data:          hex.EncodeToString(transfer.Data),
+			CallType:      int(transfer.CallType),
+			SenderAddress: hex.EncodeToString(transfer.SenderAddress),
+		})
+	}
+
+	return &canonicalOutputAccount{
+		Key:                     hex.EncodeToString([]byte(key)),
+		Address:                 hex.EncodeToString(account.Address),
+		Nonce:                   account.Nonce,
+		Balance:                 bigIntString(account.Balance),
+		StorageUpdates:          storageUpdates,
+		Code:                    hex.EncodeToString(account.Code),
+		CodeMetadata:            hex.EncodeToString(account.CodeMetadata),
+		CodeDeployerAddress:     hex.EncodeToString(account.CodeDeployerAddress),
+		BalanceDelta:            bigIntString(account.BalanceDelta),
+		OutputTransfers:         transfers,
+		GasUsed:                 account.GasUsed,
+		BytesAddedToStorage:     account.BytesAddedToStorage,
+		BytesDeletedFromStorage: account.BytesDeletedFromStorage,
+	}
+}
+
+func newCanonicalLogEntry(logEntry *LogEntry) *canonicalLogEntry {
+	if logEntry == nil {
+		return &canonicalLogEntry{IsNil: true, Topics: make([]string, 0)}
+	}
+
+	return &canonicalLogEntry{
+		Identifier: hex.EncodeToString(logEntry.Identifier),
+		Address:    hex.EncodeToString(logEntry.Address),
+		Topics:     hexList(logEntry.Topics),
+		Data:       hex.EncodeToString(logEntry.Data),
+	}
+}
+
+func hexList(values [][]byte) []string {
+	encoded := make([]string, 0, len(values))
+	for _, value := range values {
+		encoded = append(encoded, hex.EncodeToString(value))
+	}
+
+	return encoded
+}
+
+func bigIntString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+
+	return value.String()
+}
@@ -0,0 +1,170 @@
+package vmcommon
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/data/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createEncodingTestVMOutput() *VMOutput {
+	return &VMOutput{
+		ReturnData:    [][]byte{[]byte("ret1"), []byte("ret2")},
+		ReturnCode:    Ok,
+		ReturnMessage: "message",
+		GasRemaining:  100,
+		GasRefund:     big.NewInt(10),
+		OutputAccounts: map[string]*OutputAccount{
+			"addr1": {
+				Address: []byte("addr1"),
+				Nonce:   1,
+				StorageUpdates: map[string]*StorageUpdate{
+					"key2": {Offset: []byte("key2"), Data: []byte("value2"), Written: true},
+					"key1": {Offset: []byte("key1"), Data: []byte("value1"), Written: true},
+				},
+				BalanceDelta: big.NewInt(-5),
+				OutputTransfers: []OutputTransfer{
+					{Value: big.NewInt(5), GasLimit: 3, Data: []byte("data"), CallType: vm.AsynchronousCall, SenderAddress: []byte("addr2")},
+				},
+			},
+			"addr2": {
+				Address:      []byte("addr2"),
+				BalanceDelta: big.NewInt(5),
+			},
+		},
+		DeletedAccounts: [][]byte{[]byte("deleted")},
+		TouchedAccounts: [][]byte{[]byte("touched")},
+		Logs: []*LogEntry{
+			{Identifier: []byte("id"), Address: []byte("addr1"), Topics: [][]byte{[]byte("topic")}, Data: []byte("data")},
+		},
+	}
+}
+
+func TestVMOutput_CanonicalBytesIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	expected := createEncodingTestVMOutput().CanonicalBytes()
+	for i := 0; i < 50; i++ {
+		require.Equal(t, expected, createEncodingTestVMOutput().CanonicalBytes())
+	}
+
+	expectedJSON, err := createEncodingTestVMOutput().CanonicalJSON()
+	require.Nil(t, err)
+	for i := 0; i < 50; i++ {
+		encoded, _ := createEncodingTestVMOutput().CanonicalJSON()
+		require.Equal(t, expectedJSON, encoded)
+	}
+}
+
+func TestVMOutput_CanonicalBytesNormalization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil output encodes as an empty one", func(t *testing.T) {
+		t.Parallel()
+
+		var nilOutput *VMOutput
+		assert.Equal(t, (&VMOutput{}).CanonicalBytes(), nilOutput.CanonicalBytes())
+		assert.Equal(t, (&VMOutput{}).Hash(), nilOutput.Hash())
+	})
+	t.Run("nil and zero big integers", func(t *testing.T) {
+		t.Parallel()
+
+		withNil := createEncodingTestVMOutput()
+		withNil.GasRefund = nil
+		withNil.OutputAccounts["addr2"].BalanceDelta = nil
+		withZero := createEncodingTestVMOutput()
+		withZero.GasRefund = big.NewInt(0)
+		withZero.OutputAccounts["addr2"].BalanceDelta = big.NewInt(0)
+		withZero.OutputAccounts["addr2"].Balance = big.NewInt(0)
+
+		assert.Equal(t, withZero.Hash(), withNil.Hash())
+	})
+	t.Run("nil and empty slices and maps", func(t *testing.T) {
+		t.Parallel()
+
+		withNil := &VMOutput{OutputAccounts: map[string]*OutputAccount{"addr": {Address: []byte("addr")}}}
+		withEmpty := &VMOutput{
+			ReturnData: make([][]byte, 0),
+			OutputAccounts: map[string]*OutputAccount{
+				"addr": {Address: []byte("addr"), StorageUpdates: make(map[string]*StorageUpdate), Code: make([]byte, 0)},
+			},
+			Logs: make([]*LogEntry, 0),
+		}
+
+		assert.Equal(t, withEmpty.Hash(), withNil.Hash())
+	})
+	t.Run("gas trace is not encoded", func(t *testing.T) {
+		t.Parallel()
+
+		withTrace := createEncodingTestVMOutput()
+		withTrace.GasTrace = NewGasTrace()
+		withTrace.GasTrace.Charge("label", 10)
+
+		assert.Equal(t, createEncodingTestVMOutput().Hash(), withTrace.Hash())
+	})
+}
+
+func TestVMOutput_HashChangesWithEachField(t *testing.T) {
+	t.Parallel()
+
+	mutations := map[string]func(vmOutput *VMOutput){
+		"return data":        func(o *VMOutput) { o.ReturnData[1] = []byte("other") },
+		"return data split":  func(o *VMOutput) { o.ReturnData = [][]byte{[]byte("ret1r"), []byte("et2")} },
+		"return code":        func(o *VMOutput) { o.ReturnCode = UserError },
+		"return message":     func(o *VMOutput) { o.ReturnMessage = "other" },
+		"gas remaining":      func(o *VMOutput) { o.GasRemaining++ },
+		"gas refund":         func(o *VMOutput) { o.GasRefund = big.NewInt(11) },
+		"negative big int":   func(o *VMOutput) { o.OutputAccounts["addr1"].BalanceDelta = big.NewInt(5) },
+		"nonce":              func(o *VMOutput) { o.OutputAccounts["addr1"].Nonce++ },
+		"storage data":       func(o *VMOutput) { o.OutputAccounts["addr1"].StorageUpdates["key1"].Data = []byte("other") },
+		"storage written":    func(o *VMOutput) { o.OutputAccounts["addr1"].StorageUpdates["key1"].Written = false },
+		"nil storage update": func(o *VMOutput) { o.OutputAccounts["addr1"].StorageUpdates["key1"] = nil },
+		"output transfer":    func(o *VMOutput) { o.OutputAccounts["addr1"].OutputTransfers[0].GasLocked = 1 },
+		"nil account":        func(o *VMOutput) { o.OutputAccounts["addr2"] = nil },
+		"missing account":    func(o *VMOutput) { delete(o.OutputAccounts, "addr2") },
+		"deleted accounts":   func(o *VMOutput) { o.DeletedAccounts = nil },
+		"touched accounts":   func(o *VMOutput) { o.TouchedAccounts = append(o.TouchedAccounts, []byte("other")) },
+		"log topics":         func(o *VMOutput) { o.Logs[0].Topics = nil },
+		"nil log":            func(o *VMOutput) { o.Logs[0] = nil },
+	}
+
+	hash := createEncodingTestVMOutput().Hash()
+	for name, mutate := range mutations {
+		vmOutput := createEncodingTestVMOutput()
+		mutate(vmOutput)
+		assert.NotEqual(t, hash, vmOutput.Hash(), name)
+	}
+}
+
+func TestVMOutput_CanonicalJSON(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := createEncodingTestVMOutput().CanonicalJSON()
+	require.Nil(t, err)
+
+	decoded := &canonicalVMOutput{}
+	err = json.Unmarshal(encoded, decoded)
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"72657431", "72657432"}, decoded.ReturnData)
+	assert.Equal(t, "10", decoded.GasRefund)
+	require.Equal(t, 2, len(decoded.OutputAccounts))
+	assert.Equal(t, "6164647231", decoded.OutputAccounts[0].Key)
+	assert.Equal(t, "6164647232", decoded.OutputAccounts[1].Key)
+	assert.Equal(t, "-5", decoded.OutputAccounts[0].BalanceDelta)
+	assert.Equal(t, "0", decoded.OutputAccounts[0].Balance)
+	require.Equal(t, 2, len(decoded.OutputAccounts[0].StorageUpdates))
+	assert.Equal(t, "6b657931", decoded.OutputAccounts[0].StorageUpdates[0].Key)
+	assert.Equal(t, "6b657932", decoded.OutputAccounts[0].StorageUpdates[1].Key)
+	assert.Equal(t, int(vm.AsynchronousCall), decoded.OutputAccounts[0].OutputTransfers[0].CallType)
+	assert.Equal(t, []string{"746f706963"}, decoded.Logs[0].Topics)
+
+	var nilOutput *VMOutput
+	encodedNil, err := nilOutput.CanonicalJSON()
+	require.Nil(t, err)
+	encodedEmpty, _ := (&VMOutput{}).CanonicalJSON()
+	assert.Equal(t, encodedEmpty, encodedNil)
+}
@@ -0,0 +1,134 @@
+package vmcommon
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kalyan3104/k-core/data/vm"
+)
+
+// OutputInvariantsOptions holds the execution context needed by the invariants which can not be derived from the
+// input and the output alone
+type OutputInvariantsOptions struct {
+	// Fees is the value taken out by the execution, which is not found in the balance deltas. Nil means zero
+	Fees *big.Int
+	// Balances holds, indexed by address, the balances of the accounts before the execution. When a balance is
+	// provided, the balance after applying the delta of the account must not be negative
+	Balances map[string]*big.Int
+	// SkipBalanceDeltasCheck disables the check of the balance deltas sum, for the executions creating or
+	// destroying value, like claiming the developer rewards
+	SkipBalanceDeltasCheck bool
+	// AllowProtectedKeys allows the writes under the protected keys, which is only the case for protocol code
+	AllowProtectedKeys bool
+}
+
+// OutputInvariantError is an invariant violation found in a vm output. Err is one of the sentinel errors, so that
+// the violation can be checked with errors.Is, while Path points to the offending field
+type OutputInvariantError struct {
+	Path string
+	Err  error
+}
+
+// Error returns the description of the violation
+func (e *OutputInvariantError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the sentinel error of the violation
+func (e *OutputInvariantError) Unwrap() error {
+	return e.Err
+}
+
+// CheckVMOutputInvariants checks the vm output against the input which produced it, before it is applied. All the
+// violations are returned, joined, as *OutputInvariantError. The checked invariants are:
+//   - GasRemaining is not greater than GasProvided.
+//   - The balance deltas of all the output accounts sum to the call value minus the fees, as the call value is
+//     debited before the execution and the fees are not part of the output.
+//   - No balance becomes negative: neither OutputAccount.Balance, when set, nor the balance provided in the options
+//     after applying the delta.
+//   - Each storage update is indexed under its offset.
+//   - Each output transfer has a value and a known call type.
+//   - No storage update writes under a protected key, unless allowed by the options.
+func CheckVMOutputInvariants(vmInput *ContractCallInput, vmOutput *VMOutput, options OutputInvariantsOptions) error {
+	if vmInput == nil {
+		return ErrNilContractCallInput
+	}
+	if vmOutput == nil {
+		return ErrNilVMOutput
+	}
+
+	violations := make([]error, 0)
+	addViolation := func(err error, pathFormat string, args ...interface{}) {
+		violations = append(violations, &OutputInvariantError{Path: fmt.Sprintf(pathFormat, args...), Err: err})
+	}
+
+	if vmOutput.GasRemaining > vmInput.GasProvided {
+		addViolation(ErrGasRemainingExceedsGasProvided, "GasRemaining")
+	}
+
+	deltasSum := big.NewInt(0)
+	for _, key := range sortedOutputAccountKeys(vmOutput.OutputAccounts) {
+		account := vmOutput.OutputAccounts[key]
+		if account == nil {
+			continue
+		}
+		accountPath := fmt.Sprintf("OutputAccounts[%s]", formatDiffBytes([]byte(key)))
+
+		if account.BalanceDelta != nil {
+			deltasSum.Add(deltasSum, account.BalanceDelta)
+		}
+		if account.Balance != nil && account.Balance.Sign() < 0 {
+			addViolation(ErrNegativeBalance, "%s.Balance", accountPath)
+		}
+		balance, found := options.Balances[string(account.Address)]
+		if found && balance != nil && addBigInts(balance, account.BalanceDelta).Sign() < 0 {
+			addViolation(ErrNegativeBalance, "%s.BalanceDelta", accountPath)
+		}
+
+		for _, storageKey := range sortedStorageUpdateKeys(account.StorageUpdates) {
+			update := account.StorageUpdates[storageKey]
+			if update == nil {
+				continue
+			}
+			updatePath := fmt.Sprintf("%s.StorageUpdates[%s]", accountPath, formatDiffBytes([]byte(storageKey)))
+
+			if storageKey != string(update.Offset) {
+				addViolation(ErrStorageUpdateKeyMismatch, "%s.Offset", updatePath)
+			}
+			if update.Written && !options.AllowProtectedKeys && !IsAllowedToSaveUnderKey(update.Offset) {
+				addViolation(ErrProtectedKeyWrite, "%s", updatePath)
+			}
+		}
+
+		for i, transfer := range account.OutputTransfers {
+			if transfer.Value == nil {
+				addViolation(ErrNilOutputTransferValue, "%s.OutputTransfers[%d].Value", accountPath, i)
+			}
+			if !isKnownCallType(transfer.CallType) {
+				addViolation(ErrInvalidOutputTransferCallType, "%s.OutputTransfers[%d].CallType", accountPath, i)
+			}
+		}
+	}
+
+	if !options.SkipBalanceDeltasCheck {
+		expectedSum := addBigInts(vmInput.CallValue, nil)
+		if options.Fees != nil {
+			expectedSum.Sub(expectedSum, options.Fees)
+		}
+		if deltasSum.Cmp(expectedSum) != 0 {
+			addViolation(fmt.Errorf("%w: expected %s, got %s", ErrBalanceDeltasMismatch, expectedSum, deltasSum), "OutputAccounts")
+		}
+	}
+
+	return errors.Join(violations...)
+}
+
+func isKnownCallType(callType vm.CallType) bool {
+	switch callType {
+	case vm.DirectCall, vm.AsynchronousCall, vm.AsynchronousCallBack, vm.DCTTransferAndExecute, vm.ExecOnDestByCaller:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,194 @@
+package vmcommon
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core"
+	"github.com/kalyan3104/k-core/data/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createInvariantsTestInput() *ContractCallInput {
+	return &ContractCallInput{
+		VMInput: VMInput{
+			CallerAddr:  []byte("caller"),
+			CallValue:   big.NewInt(10),
+			GasProvided: 100,
+		},
+		RecipientAddr: []byte("contract"),
+	}
+}
+
+func createInvariantsTestOutput() *VMOutput {
+	return &VMOutput{
+		GasRemaining: 40,
+		OutputAccounts: map[string]*OutputAccount{
+			"contract": {
+				Address:      []byte("contract"),
+				BalanceDelta: big.NewInt(7),
+				StorageUpdates: map[string]*StorageUpdate{
+					"key": {Offset: []byte("key"), Data: []byte("value"), Written: true},
+				},
+			},
+			"receiver": {
+				Address:      []byte("receiver"),
+				BalanceDelta: big.NewInt(3),
+				OutputTransfers: []OutputTransfer{
+					{Value: big.NewInt(3), CallType: vm.DirectCall, SenderAddress: []byte("contract")},
+				},
+			},
+		},
+	}
+}
+
+func TestCheckVMOutputInvariants(t *testing.T) {
+	t.Parallel()
+
+	protectedKey := []byte(core.ProtectedKeyPrefix + "key")
+	tests := []struct {
+		name        string
+		mutate      func(input *ContractCallInput, output *VMOutput)
+		options     OutputInvariantsOptions
+		expectedErr error
+	}{
+		{
+			name:   "valid output",
+			mutate: func(_ *ContractCallInput, _ *VMOutput) {},
+		},
+		{
+			name:        "gas remaining exceeds gas provided",
+			mutate:      func(_ *ContractCallInput, output *VMOutput) { output.GasRemaining = 101 },
+			expectedErr: ErrGasRemainingExceedsGasProvided,
+		},
+		{
+			name: "balance deltas do not sum to the call value",
+			mutate: func(_ *ContractCallInput, output *VMOutput) {
+				output.OutputAccounts["receiver"].BalanceDelta = big.NewInt(4)
+			},
+			expectedErr: ErrBalanceDeltasMismatch,
+		},
+		{
+			name: "balance deltas sum to the call value minus the fees",
+			mutate: func(_ *ContractCallInput, output *VMOutput) {
+				output.OutputAccounts["receiver"].BalanceDelta = big.NewInt(1)
+			},
+			options: OutputInvariantsOptions{Fees: big.NewInt(2)},
+		},
+		{
+			name:    "balance deltas check skipped",
+			mutate:  func(_ *ContractCallInput, output *VMOutput) { output.OutputAccounts["receiver"].BalanceDelta = nil },
+			options: OutputInvariantsOptions{SkipBalanceDeltasCheck: true},
+		},
+		{
+			name: "negative output balance",
+			mutate: func(_ *ContractCallInput, output *VMOutput) {
+				output.OutputAccounts["contract"].Balance = big.NewInt(-1)
+			},
+			expectedErr: ErrNegativeBalance,
+		},
+		{
+			name: "negative resulting balance",
+			mutate: func(input *ContractCallInput, output *VMOutput) {
+				input.CallValue = big.NewInt(0)
+				output.OutputAccounts["contract"].BalanceDelta = big.NewInt(-3)
+			},
+			options:     OutputInvariantsOptions{Balances: map[string]*big.Int{"contract": big.NewInt(2)}},
+			expectedErr: ErrNegativeBalance,
+		},
+		{
+			name: "resulting balance of zero",
+			mutate: func(input *ContractCallInput, output *VMOutput) {
+				input.CallValue = big.NewInt(0)
+				output.OutputAccounts["contract"].BalanceDelta = big.NewInt(-3)
+			},
+			options: OutputInvariantsOptions{Balances: map[string]*big.Int{"contract": big.NewInt(3)}},
+		},
+		{
+			name: "storage update key mismatch",
+			mutate: func(_ *ContractCallInput, output *VMOutput) {
+				output.OutputAccounts["contract"].StorageUpdates["key"].Offset = []byte("other")
+			},
+			expectedErr: ErrStorageUpdateKeyMismatch,
+		},
+		{
+			name: "nil output transfer value",
+			mutate: func(_ *ContractCallInput, output *VMOutput) {
+				output.OutputAccounts["receiver"].OutputTransfers[0].Value = nil
+			},
+			expectedErr: ErrNilOutputTransferValue,
+		},
+		{
+			name: "invalid output transfer call type",
+			mutate: func(_ *ContractCallInput, output *VMOutput) {
+				output.OutputAccounts["receiver"].OutputTransfers[0].CallType = vm.CallType(37)
+			},
+			expectedErr: ErrInvalidOutputTransferCallType,
+		},
+		{
+			name: "write under protected key",
+			mutate: func(_ *ContractCallInput, output *VMOutput) {
+				output.OutputAccounts["contract"].StorageUpdates[string(protectedKey)] = &StorageUpdate{Offset: protectedKey, Written: true}
+			},
+			expectedErr: ErrProtectedKeyWrite,
+		},
+		{
+			name: "read under protected key",
+			mutate: func(_ *ContractCallInput, output *VMOutput) {
+				output.OutputAccounts["contract"].StorageUpdates[string(protectedKey)] = &StorageUpdate{Offset: protectedKey}
+			},
+		},
+		{
+			name: "write under protected key allowed for protocol code",
+			mutate: func(_ *ContractCallInput, output *VMOutput) {
+				output.OutputAccounts["contract"].StorageUpdates[string(protectedKey)] = &StorageUpdate{Offset: protectedKey, Written: true}
+			},
+			options: OutputInvariantsOptions{AllowProtectedKeys: true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := createInvariantsTestInput()
+			output := createInvariantsTestOutput()
+			tt.mutate(input, output)
+
+			err := CheckVMOutputInvariants(input, output, tt.options)
+			if tt.expectedErr == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.expectedErr), err)
+		})
+	}
+}
+
+func TestCheckVMOutputInvariants_NilArguments(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ErrNilContractCallInput, CheckVMOutputInvariants(nil, &VMOutput{}, OutputInvariantsOptions{}))
+	assert.Equal(t, ErrNilVMOutput, CheckVMOutputInvariants(createInvariantsTestInput(), nil, OutputInvariantsOptions{}))
+}
+
+func TestCheckVMOutputInvariants_ReportsAllViolations(t *testing.T) {
+	t.Parallel()
+
+	output := createInvariantsTestOutput()
+	output.GasRemaining = 1000
+	output.OutputAccounts["receiver"].OutputTransfers[0].Value = nil
+
+	err := CheckVMOutputInvariants(createInvariantsTestInput(), output, OutputInvariantsOptions{})
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrGasRemainingExceedsGasProvided))
+	assert.True(t, errors.Is(err, ErrNilOutputTransferValue))
+
+	var invariantErr *OutputInvariantError
+	require.True(t, errors.As(err, &invariantErr))
+	assert.Equal(t, "GasRemaining", invariantErr.Path)
+	assert.Contains(t, err.Error(), `OutputAccounts["receiver"].OutputTransfers[0].Value: nil output transfer value`)
+}
@@ -0,0 +1,193 @@
+package vmcommon
+
+import "math/big"
+
+// MergeVMOutputs merges the outputs of two executions into a new output, where right is the output of the execution
+// which ran after (or nested inside) the execution of left. None of the inputs is modified, but the byte slices are
+// shared with the inputs. A nil input is treated as an empty output. The semantics are:
+//   - ReturnData, Logs: the entries of left, followed by the entries of right.
+//   - ReturnCode, GasRemaining: the ones of right, as it is the latest execution.
+//   - ReturnMessage: the one of right, or the one of left if right has none.
+//   - GasRefund: the sum of both refunds.
+//   - DeletedAccounts, TouchedAccounts: the addresses of left, followed by the addresses of right not already listed.
+//   - OutputAccounts: the union of both maps. An account found in both is merged as described by mergeOutputAccount.
+//   - GasTrace: the charges of left, followed by the charges of right, if any of the inputs has a trace.
+func MergeVMOutputs(left *VMOutput, right *VMOutput) *VMOutput {
+	if left == nil {
+		left = &VMOutput{}
+	}
+	if right == nil {
+		right = &VMOutput{}
+	}
+
+	merged := &VMOutput{
+		ReturnData:      appendBytesLists(left.ReturnData, right.ReturnData),
+		ReturnCode:      right.ReturnCode,
+		ReturnMessage:   right.ReturnMessage,
+		GasRemaining:    right.GasRemaining,
+		GasRefund:       addBigInts(left.GasRefund, right.GasRefund),
+		OutputAccounts:  make(map[string]*OutputAccount, len(left.OutputAccounts)+len(right.OutputAccounts)),
+		DeletedAccounts: appendUniqueAddresses(left.DeletedAccounts, right.DeletedAccounts),
+		TouchedAccounts: appendUniqueAddresses(left.TouchedAccounts, right.TouchedAccounts),
+		Logs:            make([]*LogEntry, 0, len(left.Logs)+len(right.Logs)),
+		GasTrace:        mergeGasTraces(left.GasTrace, right.GasTrace),
+	}
+	if len(merged.ReturnMessage) == 0 {
+		merged.ReturnMessage = left.ReturnMessage
+	}
+
+	for key, account := range left.OutputAccounts {
+		merged.OutputAccounts[key] = mergeOutputAccount(nil, account)
+	}
+	for key, account := range right.OutputAccounts {
+		merged.OutputAccounts[key] = mergeOutputAccount(merged.OutputAccounts[key], account)
+	}
+
+	merged.Logs = append(merged.Logs, left.Logs...)
+	merged.Logs = append(merged.Logs, right.Logs...)
+
+	return merged
+}
+
+// mergeOutputAccount returns a new account holding right applied over left. Unlike OutputAccount.MergeOutputAccounts,
+// which expects right to already contain the output transfers of left, the two accounts are considered the results of
+// distinct executions:
+//   - Address, Balance, Code, CodeMetadata, CodeDeployerAddress: the ones of right, if set, otherwise the ones of left.
+//   - Nonce: the highest of the two.
+//   - BalanceDelta, GasUsed, BytesAddedToStorage, BytesDeletedFromStorage: the sums of the two.
+//   - StorageUpdates: the union of both maps. For a key written by both, the update of right is the final value of
+//     the key, as it was written last, while Written is set if any of the two updates was written.
+//   - OutputTransfers: the transfers of left, followed by the transfers of right, so that the transfer indexes of
+//     left are kept and the indexes of right are shifted by the number of transfers of left.
+func mergeOutputAccount(left *OutputAccount, right *OutputAccount) *OutputAccount {
+	if left == nil {
+		left = &OutputAccount{}
+	}
+	if right == nil {
+		right = &OutputAccount{}
+	}
+
+	merged := &OutputAccount{
+		Address:                 left.Address,
+		Nonce:                   left.Nonce,
+		Balance:                 copyBigInt(left.Balance),
+		StorageUpdates:          make(map[string]*StorageUpdate, len(left.StorageUpdates)+len(right.StorageUpdates)),
+		Code:                    left.Code,
+		CodeMetadata:            left.CodeMetadata,
+		CodeDeployerAddress:     left.CodeDeployerAddress,
+		BalanceDelta:            addBigInts(left.BalanceDelta, right.BalanceDelta),
+		OutputTransfers:         make([]OutputTransfer, 0, len(left.OutputTransfers)+len(right.OutputTransfers)),
+		GasUsed:                 left.GasUsed + right.GasUsed,
+		BytesAddedToStorage:     left.BytesAddedToStorage + right.BytesAddedToStorage,
+		BytesDeletedFromStorage: left.BytesDeletedFromStorage + right.BytesDeletedFromStorage,
+	}
+	if len(right.Address) > 0 {
+		merged.Address = right.Address
+	}
+	if right.Nonce > merged.Nonce {
+		merged.Nonce = right.Nonce
+	}
+	if right.Balance != nil {
+		merged.Balance = copyBigInt(right.Balance)
+	}
+	if len(right.Code) > 0 {
+		merged.Code = right.Code
+	}
+	if len(right.CodeMetadata) > 0 {
+		merged.CodeMetadata = right.CodeMetadata
+	}
+	if len(right.CodeDeployerAddress) > 0 {
+		merged.CodeDeployerAddress = right.CodeDeployerAddress
+	}
+
+	for key, update := range left.StorageUpdates {
+		merged.StorageUpdates[key] = copyStorageUpdate(update)
+	}
+	for key, update := range right.StorageUpdates {
+		leftUpdate := merged.StorageUpdates[key]
+		merged.StorageUpdates[key] = copyStorageUpdate(update)
+		if leftUpdate != nil && update != nil && leftUpdate.Written {
+			merged.StorageUpdates[key].Written = true
+		}
+	}
+
+	for _, transfer := range left.OutputTransfers {
+		merged.OutputTransfers = append(merged.OutputTransfers, copyOutputTransfer(transfer))
+	}
+	for _, transfer := range right.OutputTransfers {
+		merged.OutputTransfers = append(merged.OutputTransfers, copyOutputTransfer(transfer))
+	}
+
+	return merged
+}
+
+func copyStorageUpdate(update *StorageUpdate) *StorageUpdate {
+	if update == nil {
+		return nil
+	}
+
+	updateCopy := *update
+	return &updateCopy
+}
+
+func copyOutputTransfer(transfer OutputTransfer) OutputTransfer {
+	transfer.Value = copyBigInt(transfer.Value)
+	return transfer
+}
+
+func copyBigInt(value *big.Int) *big.Int {
+	if value == nil {
+		return nil
+	}
+
+	return big.NewInt(0).Set(value)
+}
+
+// addBigInts returns the sum of the two values as a new big integer, treating nil as zero
+func addBigInts(first *big.Int, second *big.Int) *big.Int {
+	sum := big.NewInt(0)
+	if first != nil {
+		sum.Add(sum, first)
+	}
+	if second != nil {
+		sum.Add(sum, second)
+	}
+
+	return sum
+}
+
+func appendBytesLists(first [][]byte, second [][]byte) [][]byte {
+	result := make([][]byte, 0, len(first)+len(second))
+	result = append(result, first...)
+	return append(result, second...)
+}
+
+func appendUniqueAddresses(first [][]byte, second [][]byte) [][]byte {
+	result := make([][]byte, 0, len(first)+len(second))
+	existing := make(map[string]struct{}, len(first)+len(second))
+	for _, addresses := range [][][]byte{first, second} {
+		for _, address := range addresses {
+			_, found := existing[string(address)]
+			if found {
+				continue
+			}
+			existing[string(address)] = struct{}{}
+			result = append(result, address)
+		}
+	}
+
+	return result
+}
+
+func mergeGasTraces(left *GasTrace, right *GasTrace) *GasTrace {
+	if left == nil && right == nil {
+		return nil
+	}
+
+	merged := NewGasTrace()
+	for _, entry := range append(left.Entries(), right.Entries()...) {
+		merged.Charge(entry.Label, entry.Units)
+	}
+
+	return merged
+}
@@ -0,0 +1,184 @@
+package vmcommon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/data/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeVMOutputs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		left     *VMOutput
+		right    *VMOutput
+		expected *VMOutput
+	}{
+		{
+			name:     "both nil",
+			expected: &VMOutput{},
+		},
+		{
+			name:     "nil left",
+			right:    createEncodingTestVMOutput(),
+			expected: createEncodingTestVMOutput(),
+		},
+		{
+			name:     "nil right keeps left except the latest execution fields",
+			left:     createEncodingTestVMOutput(),
+			expected: func() *VMOutput { o := createEncodingTestVMOutput(); o.GasRemaining = 0; return o }(),
+		},
+		{
+			name: "return data and logs are appended in execution order",
+			left: &VMOutput{
+				ReturnData: [][]byte{[]byte("a")},
+				Logs:       []*LogEntry{{Identifier: []byte("first")}},
+			},
+			right: &VMOutput{
+				ReturnData: [][]byte{[]byte("b"), []byte("c")},
+				Logs:       []*LogEntry{{Identifier: []byte("second")}, {Identifier: []byte("third")}},
+			},
+			expected: &VMOutput{
+				ReturnData: [][]byte{[]byte("a"), []byte("b"), []byte("c")},
+				Logs:       []*LogEntry{{Identifier: []byte("first")}, {Identifier: []byte("second")}, {Identifier: []byte("third")}},
+			},
+		},
+		{
+			name:     "return code and gas remaining come from right, gas refunds are added",
+			left:     &VMOutput{ReturnCode: UserError, ReturnMessage: "left", GasRemaining: 100, GasRefund: big.NewInt(3)},
+			right:    &VMOutput{ReturnCode: Ok, GasRemaining: 40, GasRefund: big.NewInt(4)},
+			expected: &VMOutput{ReturnCode: Ok, ReturnMessage: "left", GasRemaining: 40, GasRefund: big.NewInt(7)},
+		},
+		{
+			name:     "return message of right takes precedence",
+			left:     &VMOutput{ReturnMessage: "left"},
+			right:    &VMOutput{ReturnCode: OutOfGas, ReturnMessage: "right"},
+			expected: &VMOutput{ReturnCode: OutOfGas, ReturnMessage: "right"},
+		},
+		{
+			name:     "deleted and touched accounts are deduplicated",
+			left:     &VMOutput{DeletedAccounts: [][]byte{[]byte("a")}, TouchedAccounts: [][]byte{[]byte("a"), []byte("b")}},
+			right:    &VMOutput{DeletedAccounts: [][]byte{[]byte("b"), []byte("a")}, TouchedAccounts: [][]byte{[]byte("c"), []byte("a")}},
+			expected: &VMOutput{DeletedAccounts: [][]byte{[]byte("a"), []byte("b")}, TouchedAccounts: [][]byte{[]byte("a"), []byte("b"), []byte("c")}},
+		},
+		{
+			name: "distinct accounts are kept",
+			left: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), BalanceDelta: big.NewInt(1)},
+			}},
+			right: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"b": {Address: []byte("b"), BalanceDelta: big.NewInt(2)},
+			}},
+			expected: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), BalanceDelta: big.NewInt(1)},
+				"b": {Address: []byte("b"), BalanceDelta: big.NewInt(2)},
+			}},
+		},
+		{
+			name: "common account sums the deltas and keeps the highest nonce and the latest code",
+			left: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), Nonce: 5, BalanceDelta: big.NewInt(10), Code: []byte("old"), GasUsed: 1, BytesAddedToStorage: 2},
+			}},
+			right: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), Nonce: 3, BalanceDelta: big.NewInt(-4), Code: []byte("new"), GasUsed: 2, BytesDeletedFromStorage: 1},
+			}},
+			expected: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), Nonce: 5, BalanceDelta: big.NewInt(6), Code: []byte("new"), GasUsed: 3, BytesAddedToStorage: 2, BytesDeletedFromStorage: 1},
+			}},
+		},
+		{
+			name: "conflicting storage writes keep the last value",
+			left: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), StorageUpdates: map[string]*StorageUpdate{
+					"k1": {Offset: []byte("k1"), Data: []byte("left"), Written: true},
+					"k2": {Offset: []byte("k2"), Data: []byte("left"), Written: true},
+					"k3": {Offset: []byte("k3"), Data: []byte("left")},
+				}},
+			}},
+			right: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), StorageUpdates: map[string]*StorageUpdate{
+					"k1": {Offset: []byte("k1"), Data: []byte("right"), Written: true},
+					"k2": {Offset: []byte("k2"), Data: []byte("left")},
+					"k4": {Offset: []byte("k4"), Data: []byte("right"), Written: true},
+				}},
+			}},
+			expected: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), StorageUpdates: map[string]*StorageUpdate{
+					"k1": {Offset: []byte("k1"), Data: []byte("right"), Written: true},
+					"k2": {Offset: []byte("k2"), Data: []byte("left"), Written: true},
+					"k3": {Offset: []byte("k3"), Data: []byte("left")},
+					"k4": {Offset: []byte("k4"), Data: []byte("right"), Written: true},
+				}},
+			}},
+		},
+		{
+			name: "output transfers of right follow the ones of left",
+			left: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), OutputTransfers: []OutputTransfer{
+					{Value: big.NewInt(1), Data: []byte("t0"), CallType: vm.DirectCall},
+				}},
+			}},
+			right: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), OutputTransfers: []OutputTransfer{
+					{Value: big.NewInt(2), Data: []byte("t1"), CallType: vm.AsynchronousCall},
+					{Value: big.NewInt(3), Data: []byte("t2"), CallType: vm.AsynchronousCallBack},
+				}},
+			}},
+			expected: &VMOutput{OutputAccounts: map[string]*OutputAccount{
+				"a": {Address: []byte("a"), OutputTransfers: []OutputTransfer{
+					{Value: big.NewInt(1), Data: []byte("t0"), CallType: vm.DirectCall},
+					{Value: big.NewInt(2), Data: []byte("t1"), CallType: vm.AsynchronousCall},
+					{Value: big.NewInt(3), Data: []byte("t2"), CallType: vm.AsynchronousCallBack},
+				}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			merged := MergeVMOutputs(tt.left, tt.right)
+			assert.Empty(t, DiffVMOutputs(tt.expected, merged, OutputDiffOptions{}))
+		})
+	}
+}
+
+func TestMergeVMOutputs_DoesNotModifyTheInputs(t *testing.T) {
+	t.Parallel()
+
+	left := createEncodingTestVMOutput()
+	right := createEncodingTestVMOutput()
+	right.OutputAccounts["addr1"].StorageUpdates["key1"].Data = []byte("other")
+
+	merged := MergeVMOutputs(left, right)
+	merged.GasRefund.SetInt64(1000)
+	merged.OutputAccounts["addr1"].BalanceDelta.SetInt64(1000)
+	merged.OutputAccounts["addr1"].StorageUpdates["key2"].Written = false
+	merged.OutputAccounts["addr1"].OutputTransfers[0].Value.SetInt64(1000)
+	merged.ReturnData[0] = []byte("changed")
+
+	assert.True(t, VMOutputsEqual(createEncodingTestVMOutput(), left, OutputDiffOptions{}))
+	assert.Equal(t, []byte("other"), right.OutputAccounts["addr1"].StorageUpdates["key1"].Data)
+	assert.Equal(t, big.NewInt(-5), right.OutputAccounts["addr1"].BalanceDelta)
+}
+
+func TestMergeVMOutputs_GasTrace(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, MergeVMOutputs(&VMOutput{}, &VMOutput{}).GasTrace)
+
+	left := &VMOutput{GasTrace: NewGasTrace()}
+	left.GasTrace.Charge("first", 2)
+	right := &VMOutput{GasTrace: NewGasTrace()}
+	right.GasTrace.Charge("second", 3)
+
+	merged := MergeVMOutputs(left, right)
+	require.NotNil(t, merged.GasTrace)
+	assert.Equal(t, []GasTraceEntry{{Label: "first", Units: 2, Total: 2}, {Label: "second", Units: 3, Total: 5}}, merged.GasTrace.Entries())
+}
@@ -1,7 +1,5 @@
 package parsers
 
-import "strings"
-
 type callArgsParser struct {
 }
 
@@ -13,64 +11,55 @@ func NewCallArgsParser() *callArgsParser {
 // ParseData parses strings of the following format:
 // functionRaw@argFooHex@argBarHex...
 func (parser *callArgsParser) ParseData(data string) (string, [][]byte, error) {
-	var function string
-	var arguments [][]byte
-
-	tokens, err := tokenize(data)
+	functionLength, arguments, err := parser.parseDataInPlace([]byte(data))
 	if err != nil {
 		return "", nil, err
 	}
 
-	function, err = parser.parseFunction(tokens)
-	if err != nil {
-		return "", nil, err
-	}
+	return data[:functionLength], arguments, nil
+}
 
-	arguments, err = parser.parseArguments(tokens)
+// ParseDataBytes parses the same format as ParseData, without changing the provided data
+func (parser *callArgsParser) ParseDataBytes(data []byte) (string, [][]byte, error) {
+	buffer := append(make([]byte, 0, len(data)), data...)
+	functionLength, arguments, err := parser.parseDataInPlace(buffer)
 	if err != nil {
 		return "", nil, err
 	}
 
-	return function, arguments, nil
+	return string(data[:functionLength]), arguments, nil
 }
 
-// ParseArguments parses strings of the following format:
-// argFoo@hex(argBarHex)...
-func (parser *callArgsParser) ParseArguments(data string) ([][]byte, error) {
-	tokens := strings.Split(data, atSeparator)
-	arguments := make([][]byte, 0, len(tokens))
-	arguments = append(arguments, []byte(tokens[0]))
-	parsedArgs, err := parser.parseArguments(tokens)
-	if err != nil {
-		return nil, err
+// parseDataInPlace decodes the arguments over the provided buffer, returning the length of the function, which is
+// left untouched at the start of the buffer
+func (parser *callArgsParser) parseDataInPlace(buffer []byte) (int, [][]byte, error) {
+	tokenizer := NewDataTokenizer(buffer)
+	tokenizer.Next()
+	functionLength := len(tokenizer.Token())
+	if functionLength == 0 {
+		return 0, nil, ErrTokenizeFailed
 	}
-	arguments = append(arguments, parsedArgs...)
-
-	return arguments, nil
-}
 
-func (parser *callArgsParser) parseFunction(tokens []string) (string, error) {
-	if len(tokens) < minNumCallArguments {
-		return "", ErrNilFunction
+	arguments, err := decodeRemainingTokensInPlace(tokenizer, make([][]byte, 0, tokenizer.NumTokens()-1))
+	if err != nil {
+		return 0, nil, err
 	}
 
-	function := tokens[indexOfFunction]
-	return function, nil
+	return functionLength, arguments, nil
 }
 
-func (parser *callArgsParser) parseArguments(tokens []string) ([][]byte, error) {
-	arguments := make([][]byte, 0)
-
-	for i := minNumCallArguments; i < len(tokens); i++ {
-		argument, err := decodeToken(tokens[i])
-		if err != nil {
-			return nil, err
-		}
+// ParseArguments parses strings of the following format:
+// argFoo@hex(argBarHex)...
+func (parser *callArgsParser) ParseArguments(data string) ([][]byte, error) {
+	buffer := []byte(data)
+	tokenizer := NewDataTokenizer(buffer)
+	tokenizer.Next()
+	first := tokenizer.Token()
 
-		arguments = append(arguments, argument)
-	}
+	arguments := make([][]byte, 0, tokenizer.NumTokens())
+	arguments = append(arguments, first[:len(first):len(first)])
 
-	return arguments, nil
+	return decodeRemainingTokensInPlace(tokenizer, arguments)
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
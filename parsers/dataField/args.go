@@ -2,10 +2,16 @@ package datafield
 
 import (
 	"github.com/kalyan3104/k-core/marshal"
+	"github.com/kalyan3104/k-vm-common-go/builtInFunctions"
+	"github.com/kalyan3104/k-vm-common-go/parsers"
 )
 
-// ArgsOperationDataFieldParser holds all the components required to create a new instance of data field parser
+// ArgsOperationDataFieldParser holds all the components required to create a new instance of data field parser.
+// BuiltInFunctionsRegistry is optional, the protocol built-in functions are used if not provided.
+// VMTypesRegistry is optional as well, the protocol VM types are used if not provided
 type ArgsOperationDataFieldParser struct {
-	AddressLength int
-	Marshalizer   marshal.Marshalizer
+	AddressLength            int
+	Marshalizer              marshal.Marshalizer
+	BuiltInFunctionsRegistry builtInFunctions.BuiltInFunctionsRegistryHandler
+	VMTypesRegistry          parsers.VMTypesRegistryHandler
 }
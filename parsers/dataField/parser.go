@@ -1,6 +1,7 @@
 package datafield
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"math/big"
@@ -10,12 +11,16 @@ import (
 	"github.com/kalyan3104/k-core/core/sharding"
 	"github.com/kalyan3104/k-core/data/transaction"
 	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/builtInFunctions"
 	"github.com/kalyan3104/k-vm-common-go/parsers"
 )
 
 const (
-	operationTransfer = `transfer`
-	operationDeploy   = `scDeploy`
+	operationTransfer          = `transfer`
+	operationDeploy            = `scDeploy`
+	operationDeployFromSource  = `scDeployFromSource`
+	operationUpgrade           = `scUpgrade`
+	operationUpgradeFromSource = `scUpgradeFromSource`
 
 	minArgumentsQuantityOperationDCT = 2
 	minArgumentsQuantityOperationNFT = 3
@@ -31,11 +36,30 @@ const (
 
 var errInvalidAddressLength = errors.New("invalid address length")
 
+var deployFromSourcePrefix = []byte(parsers.DeployFromSourceFunctionName + "@")
+
+var upgradeOperations = map[string]string{
+	parsers.UpgradeContractFunctionName:   operationUpgrade,
+	parsers.UpgradeFromSourceFunctionName: operationUpgradeFromSource,
+	parsers.DeployFromSourceFunctionName:  operationDeployFromSource,
+}
+
+// dataArgsParser parses the data field bytes without converting them to a string first
+type dataArgsParser interface {
+	ParseDataBytes(data []byte) (string, [][]byte, error)
+}
+
+// upgradeDataParser parses the data field of the contract upgrades and of the deploys or upgrades from source
+type upgradeDataParser interface {
+	ParseData(data string) (*parsers.UpgradeArgs, error)
+}
+
 type operationDataFieldParser struct {
 	builtInFunctionsList []string
 
 	addressLength     int
-	argsParser        vmcommon.CallArgsParser
+	argsParser        dataArgsParser
+	upgradeArgsParser upgradeDataParser
 	dctTransferParser vmcommon.DCTTransferParser
 }
 
@@ -48,7 +72,33 @@ func NewOperationDataFieldParser(args *ArgsOperationDataFieldParser) (*operation
 		return nil, errInvalidAddressLength
 	}
 
+	registry := args.BuiltInFunctionsRegistry
+	if check.IfNil(registry) {
+		defaultRegistry, err := builtInFunctions.NewDefaultBuiltInFunctionsRegistry()
+		if err != nil {
+			return nil, err
+		}
+		registry = defaultRegistry
+	}
+
+	vmTypesRegistry := args.VMTypesRegistry
+	if check.IfNil(vmTypesRegistry) {
+		defaultVMTypesRegistry, err := parsers.NewDefaultVMTypesRegistry()
+		if err != nil {
+			return nil, err
+		}
+		vmTypesRegistry = defaultVMTypesRegistry
+	}
+
 	argsParser := parsers.NewCallArgsParser()
+	upgradeArgsParser, err := parsers.NewUpgradeArgsParser(parsers.ArgsUpgradeArgsParser{
+		AddressLength:   args.AddressLength,
+		VMTypesRegistry: vmTypesRegistry,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	dctTransferParser, err := parsers.NewDCTTransferParser(args.Marshalizer)
 	if err != nil {
 		return nil, err
@@ -56,9 +106,10 @@ func NewOperationDataFieldParser(args *ArgsOperationDataFieldParser) (*operation
 
 	return &operationDataFieldParser{
 		argsParser:           argsParser,
+		upgradeArgsParser:    upgradeArgsParser,
 		dctTransferParser:    dctTransferParser,
 		addressLength:        args.AddressLength,
-		builtInFunctionsList: getAllBuiltInFunctions(),
+		builtInFunctionsList: getAllBuiltInFunctions(registry),
 	}, nil
 }
 
@@ -75,10 +126,19 @@ func (odp *operationDataFieldParser) parse(dataField []byte, sender, receiver []
 	isSCDeploy := len(dataField) > 0 && isEmptyAddr(odp.addressLength, receiver)
 	if isSCDeploy {
 		responseParse.Operation = operationDeploy
+		// the data field of a deploy holds the contract code, so it is only parsed when it is a deploy from source
+		if !bytes.HasPrefix(dataField, deployFromSourcePrefix) {
+			return responseParse
+		}
+
+		operation, isUpgrade := odp.parseUpgradeOperation(dataField)
+		if isUpgrade && operation == operationDeployFromSource {
+			responseParse.Operation = operation
+		}
 		return responseParse
 	}
 
-	function, args, err := odp.argsParser.ParseData(string(dataField))
+	function, args, err := odp.argsParser.ParseDataBytes(dataField)
 	if err != nil {
 		return responseParse
 	}
@@ -101,6 +161,12 @@ func (odp *operationDataFieldParser) parse(dataField []byte, sender, receiver []
 			return NewResponseParseDataAsRelayed()
 		}
 		return odp.parseRelayed(function, args, receiver, numOfShards)
+	case parsers.UpgradeContractFunctionName, parsers.UpgradeFromSourceFunctionName:
+		operation, isUpgrade := odp.parseUpgradeOperation(dataField)
+		if isUpgrade {
+			responseParse.Operation = operation
+			return responseParse
+		}
 	}
 
 	isBuiltInFunc := isBuiltInFunction(odp.builtInFunctionsList, function)
@@ -154,6 +220,16 @@ func (odp *operationDataFieldParser) parseRelayed(function string, args [][]byte
 	}
 }
 
+// parseUpgradeOperation returns the operation of a valid upgrade or deploy from source data field
+func (odp *operationDataFieldParser) parseUpgradeOperation(dataField []byte) (string, bool) {
+	upgradeArgs, err := odp.upgradeArgsParser.ParseData(string(dataField))
+	if err != nil {
+		return "", false
+	}
+
+	return upgradeOperations[upgradeArgs.Function], true
+}
+
 func extractInnerTx(function string, args [][]byte, receiver []byte) (*transaction.Transaction, bool) {
 	tx := &transaction.Transaction{}
 
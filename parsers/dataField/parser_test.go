@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/kalyan3104/k-core/core"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/builtInFunctions"
 	"github.com/kalyan3104/k-vm-common-go/mock"
+	"github.com/kalyan3104/k-vm-common-go/parsers"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,6 +43,33 @@ func TestNewOperationDataFieldParser(t *testing.T) {
 	})
 }
 
+func TestOperationDataFieldParser_ParseRegisteredBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+	res := parser.Parse([]byte("DCTAddMetadata@5454545454"), sender, receiver, 3)
+	require.Equal(t, &ResponseParseData{Operation: vmcommon.DCTAddMetadata}, res)
+
+	res = parser.Parse([]byte("customBuiltInFunction@01"), sender, receiver, 3)
+	require.Equal(t, &ResponseParseData{Operation: operationTransfer}, res)
+
+	registry, err := builtInFunctions.NewDefaultBuiltInFunctionsRegistry()
+	require.Nil(t, err)
+	err = registry.Register(builtInFunctions.BuiltInFunctionDescriptor{
+		Name: "customBuiltInFunction",
+		Constructor: func(args builtInFunctions.ArgsNewBuiltInFunction) (vmcommon.BuiltinFunction, error) {
+			return &mock.BuiltInFunctionStub{}, nil
+		},
+	})
+	require.Nil(t, err)
+
+	arguments := createMockArgumentsOperationParser()
+	arguments.BuiltInFunctionsRegistry = registry
+	parser, _ = NewOperationDataFieldParser(arguments)
+	res = parser.Parse([]byte("customBuiltInFunction@01"), sender, receiver, 3)
+	require.Equal(t, &ResponseParseData{Operation: "customBuiltInFunction"}, res)
+}
+
 func TestParseQuantityOperationsDCT(t *testing.T) {
 	t.Parallel()
 
@@ -318,6 +348,17 @@ func TestOperationDataFieldParser_ParseRelayed(t *testing.T) {
 	})
 }
 
+type upgradeDataParserStub struct {
+	ParseDataCalled func(data string) (*parsers.UpgradeArgs, error)
+}
+
+// ParseData -
+func (stub *upgradeDataParserStub) ParseData(data string) (*parsers.UpgradeArgs, error) {
+	return stub.ParseDataCalled(data)
+}
+
+const sourceContractHex = "000000000000000005001e2a1428dd1e3a5146b3960d9e0f4a50369904ee5483"
+
 func TestParseSCDeploy(t *testing.T) {
 	arguments := createMockArgumentsOperationParser()
 	parser, _ := NewOperationDataFieldParser(arguments)
@@ -333,4 +374,88 @@ func TestParseSCDeploy(t *testing.T) {
 			Operation: operationDeploy,
 		}, res)
 	})
+
+	t.Run("ScDeployShouldNotParseTheCode", func(t *testing.T) {
+		t.Parallel()
+
+		parserWithStub, _ := NewOperationDataFieldParser(arguments)
+		parserWithStub.upgradeArgsParser = &upgradeDataParserStub{
+			ParseDataCalled: func(data string) (*parsers.UpgradeArgs, error) {
+				require.Fail(t, "the code of a deploy should not be parsed")
+				return nil, nil
+			},
+		}
+
+		dataField := []byte("deployFromSourc@0101020304050607")
+		rcvAddr := make([]byte, 32)
+
+		res := parserWithStub.Parse(dataField, sender, rcvAddr, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationDeploy,
+		}, res)
+	})
+
+	t.Run("ScDeployFromSource", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("deployFromSource@" + sourceContractHex + "@0500@0100")
+		rcvAddr := make([]byte, 32)
+
+		res := parser.Parse(dataField, sender, rcvAddr, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationDeployFromSource,
+		}, res)
+	})
+
+	t.Run("ScDeployFromSourceUnknownVMType", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("deployFromSource@" + sourceContractHex + "@0123@0100")
+		rcvAddr := make([]byte, 32)
+
+		res := parser.Parse(dataField, sender, rcvAddr, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationDeploy,
+		}, res)
+	})
+}
+
+func TestParseSCUpgrade(t *testing.T) {
+	arguments := createMockArgumentsOperationParser()
+	parser, _ := NewOperationDataFieldParser(arguments)
+	contractAddress, _ := hex.DecodeString("00000000000000000500a655b2b534218d6d8cfa1f219960be2f462e92565483")
+
+	t.Run("ScUpgrade", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("upgradeContract@0101020304050607@0100@01")
+
+		res := parser.Parse(dataField, sender, contractAddress, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationUpgrade,
+		}, res)
+	})
+
+	t.Run("ScUpgradeFromSource", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("upgradeFromSource@" + sourceContractHex + "@0100")
+
+		res := parser.Parse(dataField, sender, contractAddress, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationUpgradeFromSource,
+		}, res)
+	})
+
+	t.Run("InvalidUpgradeShouldCallFunction", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := []byte("upgradeContract@0101020304050607")
+
+		res := parser.Parse(dataField, sender, contractAddress, 3)
+		require.Equal(t, &ResponseParseData{
+			Operation: operationTransfer,
+			Function:  parsers.UpgradeContractFunctionName,
+		}, res)
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"unicode"
 
 	"github.com/kalyan3104/k-core/core"
+	"github.com/kalyan3104/k-vm-common-go/builtInFunctions"
 )
 
 const (
@@ -15,33 +16,14 @@ const (
 	dctRandomSequenceLength = 6
 )
 
-func getAllBuiltInFunctions() []string {
+// getAllBuiltInFunctions returns the names of the registered built-in functions together with the DCT role
+// names, which are also parsed as operations
+func getAllBuiltInFunctions(registry builtInFunctions.BuiltInFunctionsRegistryHandler) []string {
+	return append(registry.Names(), getAllDCTRoles()...)
+}
+
+func getAllDCTRoles() []string {
 	return []string{
-		core.BuiltInFunctionClaimDeveloperRewards,
-		core.BuiltInFunctionChangeOwnerAddress,
-		core.BuiltInFunctionSetUserName,
-		core.BuiltInFunctionSaveKeyValue,
-		core.BuiltInFunctionDCTTransfer,
-		core.BuiltInFunctionDCTBurn,
-		core.BuiltInFunctionDCTFreeze,
-		core.BuiltInFunctionDCTUnFreeze,
-		core.BuiltInFunctionDCTWipe,
-		core.BuiltInFunctionDCTPause,
-		core.BuiltInFunctionDCTUnPause,
-		core.BuiltInFunctionSetDCTRole,
-		core.BuiltInFunctionUnSetDCTRole,
-		core.BuiltInFunctionDCTSetLimitedTransfer,
-		core.BuiltInFunctionDCTUnSetLimitedTransfer,
-		core.BuiltInFunctionDCTLocalMint,
-		core.BuiltInFunctionDCTLocalBurn,
-		core.BuiltInFunctionDCTNFTTransfer,
-		core.BuiltInFunctionDCTNFTCreate,
-		core.BuiltInFunctionDCTNFTAddQuantity,
-		core.BuiltInFunctionDCTNFTCreateRoleTransfer,
-		core.BuiltInFunctionDCTNFTBurn,
-		core.BuiltInFunctionDCTNFTAddURI,
-		core.BuiltInFunctionDCTNFTUpdateAttributes,
-		core.BuiltInFunctionMultiDCTNFTTransfer,
 		core.DCTRoleLocalMint,
 		core.DCTRoleLocalBurn,
 		core.DCTRoleNFTCreate,
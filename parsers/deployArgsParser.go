@@ -25,86 +25,50 @@ func NewDeployArgsParser() *deployArgsParser {
 func (parser *deployArgsParser) ParseData(data string) (*DeployArgs, error) {
 	result := &DeployArgs{}
 
-	tokens, err := tokenize(data)
-	if err != nil {
-		return nil, err
+	buffer := []byte(data)
+	tokenizer := NewDataTokenizer(buffer)
+	tokenizer.Next()
+	if len(tokenizer.Token()) == 0 {
+		return nil, ErrTokenizeFailed
 	}
 
-	if len(tokens) < minNumDeployArguments {
+	numTokens := tokenizer.NumTokens()
+	if numTokens < minNumDeployArguments {
 		return nil, ErrInvalidDeployArguments
 	}
 
-	result.Code, err = parser.parseCode(tokens)
-	if err != nil {
-		return nil, err
-	}
-
-	result.VMType, err = parser.parseVMType(tokens)
-	if err != nil {
-		return nil, err
-	}
-
-	result.CodeMetadata, err = parser.parseCodeMetadata(tokens)
-	if err != nil {
-		return nil, err
-	}
-
-	result.Arguments, err = parser.parseArguments(tokens)
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
-}
-
-func (parser *deployArgsParser) parseCode(tokens []string) ([]byte, error) {
-	codeHex := tokens[indexOfCode]
-	code, err := decodeToken(codeHex)
+	var err error
+	result.Code, err = tokenizer.AppendDecoded(buffer[:0:len(buffer)])
 	if err != nil {
 		return nil, ErrInvalidCode
 	}
+	written := len(result.Code)
+	result.Code = result.Code[:written:written]
 
-	return code, nil
-}
-
-func (parser *deployArgsParser) parseVMType(tokens []string) ([]byte, error) {
-	vmTypeHex := tokens[indexOfVMType]
-	if len(vmTypeHex) == 0 {
+	tokenizer.Next()
+	if len(tokenizer.Token()) == 0 {
 		return nil, ErrInvalidVMType
 	}
-
-	vmType, err := decodeToken(vmTypeHex)
+	result.VMType, err = tokenizer.AppendDecoded(buffer[written:written:len(buffer)])
 	if err != nil {
 		return nil, ErrInvalidVMType
 	}
+	written += len(result.VMType)
+	result.VMType = result.VMType[:len(result.VMType):len(result.VMType)]
 
-	return vmType, nil
-}
-
-func (parser *deployArgsParser) parseCodeMetadata(tokens []string) (vmcommon.CodeMetadata, error) {
-	codeMetadataHex := tokens[indexOfCodeMetadata]
-	codeMetadataBytes, err := decodeToken(codeMetadataHex)
+	tokenizer.Next()
+	codeMetadataBytes, err := tokenizer.AppendDecoded(buffer[written:written:len(buffer)])
 	if err != nil {
-		return vmcommon.CodeMetadata{}, ErrInvalidCodeMetadata
+		return nil, ErrInvalidCodeMetadata
 	}
+	result.CodeMetadata = vmcommon.CodeMetadataFromBytes(codeMetadataBytes)
 
-	codeMetadata := vmcommon.CodeMetadataFromBytes(codeMetadataBytes)
-	return codeMetadata, nil
-}
-
-func (parser *deployArgsParser) parseArguments(tokens []string) ([][]byte, error) {
-	arguments := make([][]byte, 0)
-
-	for i := startIndexOfConstructorArguments; i < len(tokens); i++ {
-		argument, err := decodeToken(tokens[i])
-		if err != nil {
-			return nil, err
-		}
-
-		arguments = append(arguments, argument)
+	result.Arguments, err = decodeRemainingTokensInPlace(tokenizer, make([][]byte, 0, numTokens-startIndexOfConstructorArguments))
+	if err != nil {
+		return nil, err
 	}
 
-	return arguments, nil
+	return result, nil
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
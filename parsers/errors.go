@@ -33,3 +33,51 @@ var ErrNotEnoughArguments = errors.New("not enough arguments")
 
 // ErrNilMarshalizer signals that marshaller is nil
 var ErrNilMarshalizer = errors.New("nil marshaller")
+
+// ErrInvalidMaxNumArguments signals that an invalid maximum number of arguments was provided
+var ErrInvalidMaxNumArguments = errors.New("invalid maximum number of arguments")
+
+// ErrInvalidMaxArgumentLength signals that an invalid maximum argument length was provided
+var ErrInvalidMaxArgumentLength = errors.New("invalid maximum argument length")
+
+// ErrInvalidMaxFunctionLength signals that an invalid maximum function length was provided
+var ErrInvalidMaxFunctionLength = errors.New("invalid maximum function length")
+
+// ErrTooManyArguments signals that the data holds more arguments than allowed
+var ErrTooManyArguments = errors.New("too many arguments")
+
+// ErrArgumentTooLong signals that an argument is longer than allowed
+var ErrArgumentTooLong = errors.New("argument too long")
+
+// ErrFunctionTooLong signals that the function is longer than allowed
+var ErrFunctionTooLong = errors.New("function too long")
+
+// ErrInvalidHexCharacter signals that an argument holds a character which is not a hex digit
+var ErrInvalidHexCharacter = errors.New("invalid hex character")
+
+// ErrUppercaseHex signals that an argument holds uppercase hex digits, which the policy rejects
+var ErrUppercaseHex = errors.New("uppercase hex")
+
+// ErrOddLengthHex signals that an argument holds an odd number of hex digits, which the policy rejects
+var ErrOddLengthHex = errors.New("odd length hex")
+
+// ErrInvalidAddressLength signals that an invalid address length was provided
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrNilVMTypesRegistry signals that a nil VM types registry was provided
+var ErrNilVMTypesRegistry = errors.New("nil vm types registry")
+
+// ErrVMTypeAlreadyRegistered signals that the VM type was already registered
+var ErrVMTypeAlreadyRegistered = errors.New("vm type already registered")
+
+// ErrUnknownVMType signals that the VM type is not known by the VM types registry
+var ErrUnknownVMType = errors.New("unknown vm type")
+
+// ErrInvalidUpgradeArguments signals invalid upgrade arguments
+var ErrInvalidUpgradeArguments = errors.New("invalid upgrade arguments")
+
+// ErrUnknownUpgradeFunction signals that the data field does not start with an upgrade or deploy from source function
+var ErrUnknownUpgradeFunction = errors.New("unknown upgrade function")
+
+// ErrInvalidSourceAddress signals an invalid address of the source contract
+var ErrInvalidSourceAddress = errors.New("invalid source address")
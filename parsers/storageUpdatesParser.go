@@ -16,30 +16,31 @@ func NewStorageUpdatesParser() *storageUpdatesParser {
 
 // GetStorageUpdates parse data into storage updates
 func (parser *storageUpdatesParser) GetStorageUpdates(data string) ([]*vmcommon.StorageUpdate, error) {
-	data = trimLeadingSeparatorChar(data)
+	buffer := []byte(data)
+	if len(buffer) > 0 && buffer[0] == atSeparatorChar {
+		buffer = buffer[1:]
+	}
 
-	tokens, err := tokenize(data)
-	if err != nil {
-		return nil, err
+	tokenizer := NewDataTokenizer(buffer)
+	tokenizer.Next()
+	if len(tokenizer.Token()) == 0 {
+		return nil, ErrTokenizeFailed
+	}
+
+	numTokens := tokenizer.NumTokens()
+	if numTokens%2 != 0 {
+		return nil, ErrInvalidDataString
 	}
-	err = requireNumTokensIsEven(tokens)
+
+	tokenizer.Reset(buffer)
+	decoded, err := decodeRemainingTokensInPlace(tokenizer, make([][]byte, 0, numTokens))
 	if err != nil {
 		return nil, err
 	}
 
-	storageUpdates := make([]*vmcommon.StorageUpdate, 0, len(tokens))
-	for i := 0; i < len(tokens); i += 2 {
-		offset, err := decodeToken(tokens[i])
-		if err != nil {
-			return nil, err
-		}
-
-		value, err := decodeToken(tokens[i+1])
-		if err != nil {
-			return nil, err
-		}
-
-		storageUpdate := &vmcommon.StorageUpdate{Offset: offset, Data: value}
+	storageUpdates := make([]*vmcommon.StorageUpdate, 0, len(decoded)/2)
+	for i := 0; i < len(decoded); i += 2 {
+		storageUpdate := &vmcommon.StorageUpdate{Offset: decoded[i], Data: decoded[i+1]}
 		storageUpdates = append(storageUpdates, storageUpdate)
 	}
 
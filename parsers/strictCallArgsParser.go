@@ -0,0 +1,171 @@
+package parsers
+
+import (
+	"fmt"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.CallArgsParser = (*strictCallArgsParser)(nil)
+
+// HexPolicy defines which hex encodings of the arguments the strict parser accepts, besides lowercase digits with an
+// even length
+type HexPolicy struct {
+	AllowUppercase bool
+	// AllowOddLength accepts an odd number of digits, decoded as if a leading zero digit was present
+	AllowOddLength bool
+}
+
+// ArgsStrictCallArgsParser holds the limits and the policy of the strict call arguments parser
+type ArgsStrictCallArgsParser struct {
+	// MaxNumArguments bounds the number of arguments, the function not being counted
+	MaxNumArguments int
+	// MaxArgumentLength bounds the length of every decoded argument, in bytes
+	MaxArgumentLength int
+	// MaxFunctionLength bounds the length of the function, which is not hex encoded, in bytes
+	MaxFunctionLength int
+	HexPolicy         HexPolicy
+}
+
+// TokenError is a parsing failure of a token of the data. Index is the position of the token, the function being
+// on position 0, while Err is one of the sentinel errors
+type TokenError struct {
+	Index int
+	Err   error
+}
+
+// Error returns the description of the failure
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("token %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the sentinel error of the failure
+func (e *TokenError) Unwrap() error {
+	return e.Err
+}
+
+type strictCallArgsParser struct {
+	maxNumArguments   int
+	maxArgumentLength int
+	maxFunctionLength int
+	hexPolicy         HexPolicy
+}
+
+// NewStrictCallArgsParser creates a call arguments parser meant for untrusted data, which bounds the number and the
+// length of the function and of the arguments and checks their hex encoding against the policy. The data is walked token by token, so
+// an invalid data is rejected before decoding, or even reaching, the tokens which follow the offending one
+func NewStrictCallArgsParser(args ArgsStrictCallArgsParser) (*strictCallArgsParser, error) {
+	if args.MaxNumArguments <= 0 {
+		return nil, ErrInvalidMaxNumArguments
+	}
+	if args.MaxArgumentLength <= 0 {
+		return nil, ErrInvalidMaxArgumentLength
+	}
+	if args.MaxFunctionLength <= 0 {
+		return nil, ErrInvalidMaxFunctionLength
+	}
+
+	return &strictCallArgsParser{
+		maxNumArguments:   args.MaxNumArguments,
+		maxArgumentLength: args.MaxArgumentLength,
+		maxFunctionLength: args.MaxFunctionLength,
+		hexPolicy:         args.HexPolicy,
+	}, nil
+}
+
+// ParseData parses strings of the following format:
+// functionRaw@argFooHex@argBarHex...
+func (parser *strictCallArgsParser) ParseData(data string) (string, [][]byte, error) {
+	tokenizer := NewDataTokenizer([]byte(data))
+	tokenizer.Next()
+	functionLength := len(tokenizer.Token())
+	if functionLength == 0 {
+		return "", nil, &TokenError{Index: 0, Err: ErrNilFunction}
+	}
+	if functionLength > parser.maxFunctionLength {
+		return "", nil, &TokenError{Index: 0, Err: ErrFunctionTooLong}
+	}
+
+	arguments, err := parser.parseArguments(tokenizer, parser.maxNumArguments)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return data[:functionLength], arguments, nil
+}
+
+// ParseArguments parses strings of the following format:
+// argFoo@hex(argBarHex)...
+func (parser *strictCallArgsParser) ParseArguments(data string) ([][]byte, error) {
+	tokenizer := NewDataTokenizer([]byte(data))
+	tokenizer.Next()
+	first := tokenizer.Token()
+	if len(first) > parser.maxArgumentLength {
+		return nil, &TokenError{Index: 0, Err: ErrArgumentTooLong}
+	}
+
+	parsedArgs, err := parser.parseArguments(tokenizer, parser.maxNumArguments-1)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments := make([][]byte, 0, len(parsedArgs)+1)
+	arguments = append(arguments, first[:len(first):len(first)])
+	arguments = append(arguments, parsedArgs...)
+
+	return arguments, nil
+}
+
+// parseArguments checks and decodes the tokens left in the tokenizer over the data they are read from
+func (parser *strictCallArgsParser) parseArguments(tokenizer *dataTokenizer, maxNumArguments int) ([][]byte, error) {
+	data := tokenizer.data
+	written := tokenizer.end + 1
+	arguments := make([][]byte, 0)
+	for tokenizer.Next() {
+		if len(arguments) == maxNumArguments {
+			return nil, &TokenError{Index: tokenizer.Index(), Err: ErrTooManyArguments}
+		}
+
+		token := tokenizer.Token()
+		err := parser.checkToken(token)
+		if err != nil {
+			return nil, &TokenError{Index: tokenizer.Index(), Err: err}
+		}
+
+		decoded, _ := appendDecodedHex(data[written:written:len(data)], token, parser.hexPolicy.AllowOddLength)
+		arguments = append(arguments, decoded[:len(decoded):len(decoded)])
+		written += len(decoded)
+	}
+
+	return arguments, nil
+}
+
+// checkToken checks the token against the limits and the policy, so that it can be decoded
+func (parser *strictCallArgsParser) checkToken(token []byte) error {
+	if (len(token)+1)/2 > parser.maxArgumentLength {
+		return ErrArgumentTooLong
+	}
+
+	for _, c := range token {
+		switch {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'f':
+		case c >= 'A' && c <= 'F':
+			if !parser.hexPolicy.AllowUppercase {
+				return ErrUppercaseHex
+			}
+		default:
+			return ErrInvalidHexCharacter
+		}
+	}
+
+	if len(token)%2 != 0 && !parser.hexPolicy.AllowOddLength {
+		return ErrOddLengthHex
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (parser *strictCallArgsParser) IsInterfaceNil() bool {
+	return parser == nil
+}
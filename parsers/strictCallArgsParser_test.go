@@ -0,0 +1,135 @@
+package parsers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createStrictParserArgs() ArgsStrictCallArgsParser {
+	return ArgsStrictCallArgsParser{
+		MaxNumArguments:   3,
+		MaxArgumentLength: 4,
+		MaxFunctionLength: 8,
+	}
+}
+
+func requireTokenError(t *testing.T, err error, expectedIndex int, expectedErr error) {
+	var tokenErr *TokenError
+	require.True(t, errors.As(err, &tokenErr), err)
+	require.Equal(t, expectedIndex, tokenErr.Index)
+	require.True(t, errors.Is(err, expectedErr), err)
+}
+
+func TestNewStrictCallArgsParser(t *testing.T) {
+	t.Parallel()
+
+	args := createStrictParserArgs()
+	args.MaxNumArguments = 0
+	parser, err := NewStrictCallArgsParser(args)
+	require.Nil(t, parser)
+	require.Equal(t, ErrInvalidMaxNumArguments, err)
+
+	args = createStrictParserArgs()
+	args.MaxArgumentLength = -1
+	parser, err = NewStrictCallArgsParser(args)
+	require.Nil(t, parser)
+	require.Equal(t, ErrInvalidMaxArgumentLength, err)
+
+	args = createStrictParserArgs()
+	args.MaxFunctionLength = 0
+	parser, err = NewStrictCallArgsParser(args)
+	require.Nil(t, parser)
+	require.Equal(t, ErrInvalidMaxFunctionLength, err)
+
+	parser, err = NewStrictCallArgsParser(createStrictParserArgs())
+	require.Nil(t, err)
+	require.False(t, parser.IsInterfaceNil())
+}
+
+func TestStrictCallArgsParser_ParseData(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewStrictCallArgsParser(createStrictParserArgs())
+
+	function, arguments, err := parser.ParseData("fooBar")
+	require.Nil(t, err)
+	require.Equal(t, "fooBar", function)
+	require.Equal(t, [][]byte{}, arguments)
+
+	function, arguments, err = parser.ParseData("fooBar@0a0a@@0b0b0b0b")
+	require.Nil(t, err)
+	require.Equal(t, "fooBar", function)
+	require.Equal(t, [][]byte{{10, 10}, {}, {11, 11, 11, 11}}, arguments)
+}
+
+func TestStrictCallArgsParser_ParseDataErrors(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewStrictCallArgsParser(createStrictParserArgs())
+
+	tests := []struct {
+		name          string
+		data          string
+		expectedIndex int
+		expectedErr   error
+	}{
+		{name: "empty data", data: "", expectedIndex: 0, expectedErr: ErrNilFunction},
+		{name: "empty function", data: "@0a", expectedIndex: 0, expectedErr: ErrNilFunction},
+		{name: "function too long", data: "fooBarBaz@0a", expectedIndex: 0, expectedErr: ErrFunctionTooLong},
+		{name: "too many arguments", data: "foo@01@02@03@04@05", expectedIndex: 4, expectedErr: ErrTooManyArguments},
+		{name: "argument too long", data: "foo@01@0102030405", expectedIndex: 2, expectedErr: ErrArgumentTooLong},
+		{name: "odd argument too long", data: "foo@102030405", expectedIndex: 1, expectedErr: ErrArgumentTooLong},
+		{name: "invalid hex character", data: "foo@0g", expectedIndex: 1, expectedErr: ErrInvalidHexCharacter},
+		{name: "uppercase hex", data: "foo@0a@0A", expectedIndex: 2, expectedErr: ErrUppercaseHex},
+		{name: "odd length hex", data: "foo@0a0", expectedIndex: 1, expectedErr: ErrOddLengthHex},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			function, arguments, err := parser.ParseData(tt.data)
+			require.Equal(t, "", function)
+			require.Nil(t, arguments)
+			requireTokenError(t, err, tt.expectedIndex, tt.expectedErr)
+		})
+	}
+}
+
+func TestStrictCallArgsParser_HexPolicy(t *testing.T) {
+	t.Parallel()
+
+	args := createStrictParserArgs()
+	args.HexPolicy = HexPolicy{AllowUppercase: true, AllowOddLength: true}
+	parser, _ := NewStrictCallArgsParser(args)
+
+	_, arguments, err := parser.ParseData("foo@0A0b@abc@1")
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{{10, 11}, {0x0a, 0xbc}, {1}}, arguments)
+
+	_, _, err = parser.ParseData("foo@0x01")
+	requireTokenError(t, err, 1, ErrInvalidHexCharacter)
+}
+
+func TestStrictCallArgsParser_ParseArguments(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewStrictCallArgsParser(createStrictParserArgs())
+
+	arguments, err := parser.ParseArguments("")
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{{}}, arguments)
+
+	arguments, err = parser.ParseArguments("1@0a0a@0b0b")
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{{49}, {10, 10}, {11, 11}}, arguments)
+
+	_, err = parser.ParseArguments("1@0a@0b@0c")
+	requireTokenError(t, err, 3, ErrTooManyArguments)
+
+	_, err = parser.ParseArguments("12345@0a")
+	requireTokenError(t, err, 0, ErrArgumentTooLong)
+}
@@ -1,41 +1,151 @@
 package parsers
 
 import (
-	"encoding/hex"
-	"strings"
+	"bytes"
 )
 
-func tokenize(data string) ([]string, error) {
-	tokens := strings.Split(data, atSeparator)
+// dataTokenizer walks the @ separated tokens of a data field one at a time, without splitting the data up front.
+// The tokens are sub-slices of the data and they can be hex decoded into a buffer provided by the caller, so walking
+// and decoding a data field allocates nothing
+type dataTokenizer struct {
+	data  []byte
+	start int
+	end   int
+	index int
+	done  bool
+}
+
+// NewDataTokenizer creates a tokenizer over the data. An empty data holds a single, empty, token
+func NewDataTokenizer(data []byte) *dataTokenizer {
+	tokenizer := &dataTokenizer{}
+	tokenizer.Reset(data)
+
+	return tokenizer
+}
+
+// Reset makes the tokenizer walk the provided data from its start, so the tokenizer can be reused
+func (tokenizer *dataTokenizer) Reset(data []byte) {
+	tokenizer.data = data
+	tokenizer.start = 0
+	tokenizer.end = -1
+	tokenizer.index = -1
+	tokenizer.done = false
+}
+
+// Next moves to the next token, returning false once all the tokens were walked
+func (tokenizer *dataTokenizer) Next() bool {
+	if tokenizer.done {
+		return false
+	}
 
-	if len(tokens) == 0 || len(tokens[0]) == 0 {
-		return nil, ErrTokenizeFailed
+	tokenizer.index++
+	tokenizer.start = tokenizer.end + 1
+	separatorPosition := bytes.IndexByte(tokenizer.data[tokenizer.start:], atSeparatorChar)
+	if separatorPosition < 0 {
+		tokenizer.end = len(tokenizer.data)
+		tokenizer.done = true
+		return true
 	}
 
-	return tokens, nil
+	tokenizer.end = tokenizer.start + separatorPosition
+	return true
 }
 
-func decodeToken(token string) ([]byte, error) {
-	decoded, err := hex.DecodeString(token)
-	if err != nil {
-		return nil, ErrTokenizeFailed
+// Token returns the current token, as a sub-slice of the data
+func (tokenizer *dataTokenizer) Token() []byte {
+	return tokenizer.data[tokenizer.start:tokenizer.end]
+}
+
+// Index returns the position of the current token, the first token being on position 0
+func (tokenizer *dataTokenizer) Index() int {
+	return tokenizer.index
+}
+
+// NumTokens returns the number of tokens of the whole data
+func (tokenizer *dataTokenizer) NumTokens() int {
+	return bytes.Count(tokenizer.data, []byte(atSeparator)) + 1
+}
+
+// AppendDecoded hex decodes the current token and appends the result to the buffer, returning the extended buffer.
+// Nothing is allocated if the buffer has enough capacity. The buffer may even share its memory with the data, as long
+// as it does not start after the current token, since every byte is written only after its digits were read
+func (tokenizer *dataTokenizer) AppendDecoded(buffer []byte) ([]byte, error) {
+	decoded, ok := appendDecodedHex(buffer, tokenizer.Token(), false)
+	if !ok {
+		return buffer, ErrTokenizeFailed
 	}
 
 	return decoded, nil
 }
 
-func trimLeadingSeparatorChar(data string) string {
-	if len(data) > 0 && data[0] == atSeparatorChar {
-		data = data[1:]
+// decodeNextTokenInPlace moves to the next token and decodes it over the data the tokenizer walks, from the provided
+// position on, returning the decoded token and the position following it
+func decodeNextTokenInPlace(tokenizer *dataTokenizer, written int) ([]byte, int, error) {
+	tokenizer.Next()
+	decoded, err := tokenizer.AppendDecoded(tokenizer.data[written:written:len(tokenizer.data)])
+	if err != nil {
+		return nil, written, err
 	}
 
-	return data
+	return decoded[:len(decoded):len(decoded)], written + len(decoded), nil
 }
 
-func requireNumTokensIsEven(tokens []string) error {
-	if len(tokens)%2 == 0 {
-		return nil
+// decodeRemainingTokensInPlace decodes the tokens left in the tokenizer over the data they are read from, starting
+// at the position of the first one, so the data must be owned by the parser. The arguments share the data memory, but
+// their capacity is bounded, so appending to one of them does not change the next one
+func decodeRemainingTokensInPlace(tokenizer *dataTokenizer, arguments [][]byte) ([][]byte, error) {
+	data := tokenizer.data
+	written := tokenizer.end + 1
+	for tokenizer.Next() {
+		decoded, err := tokenizer.AppendDecoded(data[written:written:len(data)])
+		if err != nil {
+			return nil, err
+		}
+
+		arguments = append(arguments, decoded[:len(decoded):len(decoded)])
+		written += len(decoded)
 	}
 
-	return ErrInvalidDataString
+	return arguments, nil
+}
+
+// appendDecodedHex appends the hex decoded token to the buffer. An odd number of digits, if allowed, is decoded as if
+// a leading zero digit was present
+func appendDecodedHex(buffer []byte, token []byte, allowOddLength bool) ([]byte, bool) {
+	if len(token)%2 != 0 {
+		if !allowOddLength {
+			return buffer, false
+		}
+
+		low, ok := fromHexChar(token[0])
+		if !ok {
+			return buffer, false
+		}
+		buffer = append(buffer, low)
+		token = token[1:]
+	}
+
+	for i := 0; i < len(token); i += 2 {
+		high, okHigh := fromHexChar(token[i])
+		low, okLow := fromHexChar(token[i+1])
+		if !okHigh || !okLow {
+			return buffer, false
+		}
+		buffer = append(buffer, high<<4|low)
+	}
+
+	return buffer, true
+}
+
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	default:
+		return 0, false
+	}
 }
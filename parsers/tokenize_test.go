@@ -0,0 +1,169 @@
+package parsers
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createBenchmarkCallData() string {
+	arguments := []string{
+		hex.EncodeToString([]byte("TKN-0a1b2c")),
+		hex.EncodeToString([]byte{0x0d, 0xe0, 0xb6, 0xb3, 0xa7, 0x64, 0x00, 0x00}),
+		hex.EncodeToString([]byte(strings.Repeat("f", 32))),
+		hex.EncodeToString([]byte("swapTokensFixedInput")),
+		hex.EncodeToString([]byte("WREWA-abcdef")),
+		"01",
+	}
+
+	return "MultiDCTNFTTransfer@" + strings.Join(arguments, "@")
+}
+
+func TestDataTokenizer(t *testing.T) {
+	t.Parallel()
+
+	tokenizer := NewDataTokenizer([]byte("a@@0b0c@"))
+	require.Equal(t, 4, tokenizer.NumTokens())
+
+	expected := []string{"a", "", "0b0c", ""}
+	for expectedIndex, expectedToken := range expected {
+		require.True(t, tokenizer.Next())
+		require.Equal(t, expectedIndex, tokenizer.Index())
+		require.Equal(t, expectedToken, string(tokenizer.Token()))
+	}
+	require.False(t, tokenizer.Next())
+
+	tokenizer.Reset([]byte(""))
+	require.True(t, tokenizer.Next())
+	require.Equal(t, []byte{}, tokenizer.Token())
+	require.False(t, tokenizer.Next())
+}
+
+func TestDataTokenizer_AppendDecoded(t *testing.T) {
+	t.Parallel()
+
+	tokenizer := NewDataTokenizer([]byte("0A0b@zz@abc"))
+	buffer := make([]byte, 0, 8)
+
+	tokenizer.Next()
+	decoded, err := tokenizer.AppendDecoded(buffer)
+	require.Nil(t, err)
+	require.Equal(t, []byte{10, 11}, decoded)
+
+	tokenizer.Next()
+	decoded, err = tokenizer.AppendDecoded(decoded)
+	require.Equal(t, ErrTokenizeFailed, err)
+	require.Equal(t, []byte{10, 11}, decoded)
+
+	tokenizer.Next()
+	_, err = tokenizer.AppendDecoded(buffer)
+	require.Equal(t, ErrTokenizeFailed, err)
+}
+
+func TestDataTokenizer_DoesNotAllocate(t *testing.T) {
+	data := []byte(createBenchmarkCallData())
+	tokenizer := NewDataTokenizer(data)
+	buffer := make([]byte, 0, len(data))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		tokenizer.Reset(data)
+		tokenizer.Next()
+		buffer = buffer[:0]
+		for tokenizer.Next() {
+			buffer, _ = tokenizer.AppendDecoded(buffer)
+		}
+	})
+	require.Equal(t, float64(0), allocs)
+}
+
+func TestCallArgsParser_ArgumentsDoNotOverlap(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("foo@0102@0304")
+	function, arguments, err := NewCallArgsParser().ParseDataBytes(data)
+	require.Nil(t, err)
+	require.Equal(t, "foo", function)
+	require.Equal(t, "foo@0102@0304", string(data))
+
+	arguments[0] = append(arguments[0], 0xff)
+	require.Equal(t, []byte{3, 4}, arguments[1])
+}
+
+// BenchmarkSplitAndDecode measures the previous approach of splitting the whole data and decoding every argument in
+// its own slice, as a reference for the parsers benchmarks
+func BenchmarkSplitAndDecode(b *testing.B) {
+	data := createBenchmarkCallData()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		tokens := strings.Split(data, atSeparator)
+		arguments := make([][]byte, 0)
+		for _, token := range tokens[1:] {
+			argument, _ := hex.DecodeString(token)
+			arguments = append(arguments, argument)
+		}
+	}
+}
+
+func BenchmarkCallArgsParser_ParseData(b *testing.B) {
+	data := createBenchmarkCallData()
+	parser := NewCallArgsParser()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _, _ = parser.ParseData(data)
+	}
+}
+
+func BenchmarkCallArgsParser_ParseDataBytes(b *testing.B) {
+	data := []byte(createBenchmarkCallData())
+	parser := NewCallArgsParser()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _, _ = parser.ParseDataBytes(data)
+	}
+}
+
+func BenchmarkDataTokenizer_AppendDecoded(b *testing.B) {
+	data := []byte(createBenchmarkCallData())
+	tokenizer := NewDataTokenizer(data)
+	buffer := make([]byte, 0, len(data))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		tokenizer.Reset(data)
+		buffer = buffer[:0]
+		for tokenizer.Next() {
+			buffer, _ = tokenizer.AppendDecoded(buffer)
+		}
+	}
+}
+
+func BenchmarkDeployArgsParser_ParseData(b *testing.B) {
+	data := hex.EncodeToString([]byte(strings.Repeat("code", 256))) + "@0500@0502@01@02"
+	parser := NewDeployArgsParser()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = parser.ParseData(data)
+	}
+}
+
+func BenchmarkStorageUpdatesParser_GetStorageUpdates(b *testing.B) {
+	data := strings.Repeat("6b6579@76616c7565@", 9) + "6b6579@76616c7565"
+	parser := NewStorageUpdatesParser()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = parser.GetStorageUpdates(data)
+	}
+}
@@ -0,0 +1,131 @@
+package parsers
+
+import (
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+const (
+	// UpgradeContractFunctionName is the function of the data field which upgrades a contract with the provided code
+	UpgradeContractFunctionName = "upgradeContract"
+	// UpgradeFromSourceFunctionName is the function of the data field which upgrades a contract with the code of a
+	// source contract
+	UpgradeFromSourceFunctionName = "upgradeFromSource"
+	// DeployFromSourceFunctionName is the function of the data field which deploys a contract with the code of a
+	// source contract
+	DeployFromSourceFunctionName = "deployFromSource"
+)
+
+// minNumUpgradeTokens holds, for every upgrade function, the number of tokens preceding the arguments
+var minNumUpgradeTokens = map[string]int{
+	UpgradeContractFunctionName:   3,
+	UpgradeFromSourceFunctionName: 3,
+	DeployFromSourceFunctionName:  4,
+}
+
+// UpgradeArgs represents the parsed arguments of a contract upgrade or of a deploy or upgrade from source.
+// Code is only set for an upgrade with code, SourceAddress for the operations from source and VMType for a deploy
+// from source
+type UpgradeArgs struct {
+	Function      string
+	Code          []byte
+	SourceAddress []byte
+	VMType        []byte
+	CodeMetadata  vmcommon.CodeMetadata
+	Arguments     [][]byte
+}
+
+// ArgsUpgradeArgsParser holds the components of the upgrade arguments parser
+type ArgsUpgradeArgsParser struct {
+	AddressLength   int
+	VMTypesRegistry VMTypesRegistryHandler
+}
+
+type upgradeArgsParser struct {
+	addressLength   int
+	vmTypesRegistry VMTypesRegistryHandler
+}
+
+// NewUpgradeArgsParser creates a new parser
+func NewUpgradeArgsParser(args ArgsUpgradeArgsParser) (*upgradeArgsParser, error) {
+	if args.AddressLength <= 0 {
+		return nil, ErrInvalidAddressLength
+	}
+	if check.IfNil(args.VMTypesRegistry) {
+		return nil, ErrNilVMTypesRegistry
+	}
+
+	return &upgradeArgsParser{
+		addressLength:   args.AddressLength,
+		vmTypesRegistry: args.VMTypesRegistry,
+	}, nil
+}
+
+// ParseData parses strings of the following formats:
+// upgradeContract@codeHex@codeMetadataHex@argFooHex@argBarHex...
+// upgradeFromSource@sourceAddressHex@codeMetadataHex@argFooHex@argBarHex...
+// deployFromSource@sourceAddressHex@vmTypeHex@codeMetadataHex@argFooHex@argBarHex...
+func (parser *upgradeArgsParser) ParseData(data string) (*UpgradeArgs, error) {
+	buffer := []byte(data)
+	tokenizer := NewDataTokenizer(buffer)
+	tokenizer.Next()
+	functionLength := len(tokenizer.Token())
+	if functionLength == 0 {
+		return nil, ErrTokenizeFailed
+	}
+
+	result := &UpgradeArgs{
+		Function: data[:functionLength],
+	}
+	minNumTokens, ok := minNumUpgradeTokens[result.Function]
+	if !ok {
+		return nil, ErrUnknownUpgradeFunction
+	}
+
+	numTokens := tokenizer.NumTokens()
+	if numTokens < minNumTokens {
+		return nil, ErrInvalidUpgradeArguments
+	}
+
+	var err error
+	written := functionLength + 1
+	if result.Function == UpgradeContractFunctionName {
+		result.Code, written, err = decodeNextTokenInPlace(tokenizer, written)
+		if err != nil || len(result.Code) == 0 {
+			return nil, ErrInvalidCode
+		}
+	} else {
+		result.SourceAddress, written, err = decodeNextTokenInPlace(tokenizer, written)
+		if err != nil || len(result.SourceAddress) != parser.addressLength {
+			return nil, ErrInvalidSourceAddress
+		}
+	}
+
+	if result.Function == DeployFromSourceFunctionName {
+		result.VMType, written, err = decodeNextTokenInPlace(tokenizer, written)
+		if err != nil || len(result.VMType) == 0 {
+			return nil, ErrInvalidVMType
+		}
+		if !parser.vmTypesRegistry.IsKnown(result.VMType) {
+			return nil, ErrUnknownVMType
+		}
+	}
+
+	codeMetadataBytes, _, err := decodeNextTokenInPlace(tokenizer, written)
+	if err != nil {
+		return nil, ErrInvalidCodeMetadata
+	}
+	result.CodeMetadata = vmcommon.CodeMetadataFromBytes(codeMetadataBytes)
+
+	result.Arguments, err = decodeRemainingTokensInPlace(tokenizer, make([][]byte, 0, numTokens-minNumTokens))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (parser *upgradeArgsParser) IsInterfaceNil() bool {
+	return parser == nil
+}
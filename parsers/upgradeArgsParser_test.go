@@ -0,0 +1,126 @@
+package parsers
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testAddressLength = 32
+
+var testSourceAddressHex = strings.Repeat("0a", testAddressLength)
+
+func createUpgradeArgsParser(t *testing.T) *upgradeArgsParser {
+	registry, _ := NewDefaultVMTypesRegistry()
+	parser, err := NewUpgradeArgsParser(ArgsUpgradeArgsParser{
+		AddressLength:   testAddressLength,
+		VMTypesRegistry: registry,
+	})
+	require.Nil(t, err)
+
+	return parser
+}
+
+func TestNewUpgradeArgsParser(t *testing.T) {
+	t.Parallel()
+
+	registry, _ := NewDefaultVMTypesRegistry()
+
+	parser, err := NewUpgradeArgsParser(ArgsUpgradeArgsParser{VMTypesRegistry: registry})
+	require.Nil(t, parser)
+	require.Equal(t, ErrInvalidAddressLength, err)
+
+	parser, err = NewUpgradeArgsParser(ArgsUpgradeArgsParser{AddressLength: testAddressLength})
+	require.Nil(t, parser)
+	require.Equal(t, ErrNilVMTypesRegistry, err)
+
+	parser, err = NewUpgradeArgsParser(ArgsUpgradeArgsParser{AddressLength: testAddressLength, VMTypesRegistry: registry})
+	require.Nil(t, err)
+	require.False(t, parser.IsInterfaceNil())
+}
+
+func TestUpgradeArgsParser_ParseData(t *testing.T) {
+	t.Parallel()
+
+	parser := createUpgradeArgsParser(t)
+	sourceAddress, _ := hex.DecodeString(testSourceAddressHex)
+
+	parsed, err := parser.ParseData("upgradeContract@ABBA@0100")
+	require.Nil(t, err)
+	require.Equal(t, UpgradeContractFunctionName, parsed.Function)
+	require.Equal(t, []byte{0xAB, 0xBA}, parsed.Code)
+	require.Nil(t, parsed.SourceAddress)
+	require.Nil(t, parsed.VMType)
+	require.True(t, parsed.CodeMetadata.Upgradeable)
+	require.Equal(t, [][]byte{}, parsed.Arguments)
+
+	parsed, err = parser.ParseData("upgradeFromSource@" + testSourceAddressHex + "@0002@64@0A")
+	require.Nil(t, err)
+	require.Equal(t, UpgradeFromSourceFunctionName, parsed.Function)
+	require.Nil(t, parsed.Code)
+	require.Equal(t, sourceAddress, parsed.SourceAddress)
+	require.True(t, parsed.CodeMetadata.Payable)
+	require.Equal(t, [][]byte{{100}, {0xA}}, parsed.Arguments)
+
+	parsed, err = parser.ParseData("deployFromSource@" + testSourceAddressHex + "@0500@0000@@01")
+	require.Nil(t, err)
+	require.Equal(t, DeployFromSourceFunctionName, parsed.Function)
+	require.Equal(t, sourceAddress, parsed.SourceAddress)
+	require.Equal(t, []byte{5, 0}, parsed.VMType)
+	require.False(t, parsed.CodeMetadata.Upgradeable)
+	require.Equal(t, [][]byte{{}, {1}}, parsed.Arguments)
+}
+
+func TestUpgradeArgsParser_ParseDataWhenErroneousInput(t *testing.T) {
+	t.Parallel()
+
+	parser := createUpgradeArgsParser(t)
+
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr error
+	}{
+		{name: "empty data", data: "", expectedErr: ErrTokenizeFailed},
+		{name: "empty function", data: "@ABBA@0100", expectedErr: ErrTokenizeFailed},
+		{name: "unknown function", data: "ABBA@0500@0100", expectedErr: ErrUnknownUpgradeFunction},
+		{name: "missing code metadata", data: "upgradeContract@ABBA", expectedErr: ErrInvalidUpgradeArguments},
+		{name: "missing vm type", data: "deployFromSource@" + testSourceAddressHex + "@0100", expectedErr: ErrInvalidUpgradeArguments},
+		{name: "invalid code", data: "upgradeContract@XYZY@0100", expectedErr: ErrInvalidCode},
+		{name: "empty code", data: "upgradeContract@@0100", expectedErr: ErrInvalidCode},
+		{name: "invalid source address", data: "upgradeFromSource@0a0a@0100", expectedErr: ErrInvalidSourceAddress},
+		{name: "empty vm type", data: "deployFromSource@" + testSourceAddressHex + "@@0100", expectedErr: ErrInvalidVMType},
+		{name: "unknown vm type", data: "deployFromSource@" + testSourceAddressHex + "@0123@0100", expectedErr: ErrUnknownVMType},
+		{name: "invalid code metadata", data: "upgradeContract@ABBA@A", expectedErr: ErrInvalidCodeMetadata},
+		{name: "invalid argument", data: "upgradeContract@ABBA@0100@A", expectedErr: ErrTokenizeFailed},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parsed, err := parser.ParseData(tt.data)
+			require.Equal(t, tt.expectedErr, err)
+			require.Nil(t, parsed)
+		})
+	}
+}
+
+func TestUpgradeArgsParser_ParseDataWithRegisteredVMType(t *testing.T) {
+	t.Parallel()
+
+	registry := NewVMTypesRegistry()
+	_ = registry.Register([]byte{1, 0x23})
+	parser, _ := NewUpgradeArgsParser(ArgsUpgradeArgsParser{AddressLength: testAddressLength, VMTypesRegistry: registry})
+
+	parsed, err := parser.ParseData("deployFromSource@" + testSourceAddressHex + "@0123@0100")
+	require.Nil(t, err)
+	require.Equal(t, []byte{1, 0x23}, parsed.VMType)
+
+	parsed, err = parser.ParseData("deployFromSource@" + testSourceAddressHex + "@0500@0100")
+	require.Equal(t, ErrUnknownVMType, err)
+	require.Nil(t, parsed)
+}
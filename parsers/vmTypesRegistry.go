@@ -0,0 +1,95 @@
+package parsers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ VMTypesRegistryHandler = (*vmTypesRegistry)(nil)
+
+// WasmVMType is the type of the WebAssembly VM, known by the default VM types registry
+var WasmVMType = []byte{5, 0}
+
+// VMTypesRegistryHandler defines the registry of the VM types a contract can be deployed on
+type VMTypesRegistryHandler interface {
+	Register(vmType []byte) error
+	IsKnown(vmType []byte) bool
+	VMTypes() [][]byte
+	IsInterfaceNil() bool
+}
+
+type vmTypesRegistry struct {
+	mutVMTypes sync.RWMutex
+	vmTypes    [][]byte
+	known      map[string]struct{}
+}
+
+// NewVMTypesRegistry creates an empty VM types registry
+func NewVMTypesRegistry() *vmTypesRegistry {
+	return &vmTypesRegistry{
+		vmTypes: make([][]byte, 0),
+		known:   make(map[string]struct{}),
+	}
+}
+
+// NewDefaultVMTypesRegistry creates a registry holding the VM types of the protocol.
+// Additional VM types can be registered on the returned registry
+func NewDefaultVMTypesRegistry() (*vmTypesRegistry, error) {
+	registry := NewVMTypesRegistry()
+	err := registry.Register(WasmVMType)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry, nil
+}
+
+// Register adds a new VM type. The VM type must be unique and have the length of the VM type found in addresses
+func (registry *vmTypesRegistry) Register(vmType []byte) error {
+	if len(vmType) != vmcommon.VMTypeLen {
+		return fmt.Errorf("%w, vm type length should be %d", ErrInvalidVMType, vmcommon.VMTypeLen)
+	}
+
+	registry.mutVMTypes.Lock()
+	defer registry.mutVMTypes.Unlock()
+
+	_, exists := registry.known[string(vmType)]
+	if exists {
+		return fmt.Errorf("%w: %s", ErrVMTypeAlreadyRegistered, hex.EncodeToString(vmType))
+	}
+
+	registry.known[string(vmType)] = struct{}{}
+	registry.vmTypes = append(registry.vmTypes, append([]byte{}, vmType...))
+
+	return nil
+}
+
+// IsKnown returns true if the VM type was registered
+func (registry *vmTypesRegistry) IsKnown(vmType []byte) bool {
+	registry.mutVMTypes.RLock()
+	defer registry.mutVMTypes.RUnlock()
+
+	_, exists := registry.known[string(vmType)]
+	return exists
+}
+
+// VMTypes returns all the registered VM types, in registration order
+func (registry *vmTypesRegistry) VMTypes() [][]byte {
+	registry.mutVMTypes.RLock()
+	defer registry.mutVMTypes.RUnlock()
+
+	vmTypes := make([][]byte, 0, len(registry.vmTypes))
+	for _, vmType := range registry.vmTypes {
+		vmTypes = append(vmTypes, append([]byte{}, vmType...))
+	}
+
+	return vmTypes
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (registry *vmTypesRegistry) IsInterfaceNil() bool {
+	return registry == nil
+}
@@ -0,0 +1,46 @@
+package parsers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDefaultVMTypesRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry, err := NewDefaultVMTypesRegistry()
+	require.Nil(t, err)
+	require.False(t, registry.IsInterfaceNil())
+	require.True(t, registry.IsKnown([]byte{5, 0}))
+	require.False(t, registry.IsKnown([]byte{1, 0}))
+	require.Equal(t, [][]byte{{5, 0}}, registry.VMTypes())
+}
+
+func TestVMTypesRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	registry := NewVMTypesRegistry()
+	require.False(t, registry.IsKnown(WasmVMType))
+
+	err := registry.Register([]byte{1})
+	require.True(t, errors.Is(err, ErrInvalidVMType))
+
+	vmType := []byte{1, 2}
+	err = registry.Register(vmType)
+	require.Nil(t, err)
+	vmType[0] = 3
+	require.True(t, registry.IsKnown([]byte{1, 2}))
+
+	err = registry.Register([]byte{1, 2})
+	require.True(t, errors.Is(err, ErrVMTypeAlreadyRegistered))
+
+	err = registry.Register(WasmVMType)
+	require.Nil(t, err)
+
+	vmTypes := registry.VMTypes()
+	require.Equal(t, [][]byte{{1, 2}, {5, 0}}, vmTypes)
+	vmTypes[0][0] = 3
+	require.False(t, registry.IsKnown([]byte{3, 2}))
+}
@@ -0,0 +1,66 @@
+package vmcommon
+
+import "bytes"
+
+// AccessedKey identifies the account data accessed by a built-in function call. An empty Key stands for the account
+// fields kept outside the data trie (balance, nonce, owner, user name, code metadata, developer reward).
+// If IsPrefix is set, all the data trie keys starting with Key are accessed
+type AccessedKey struct {
+	Address  []byte
+	Key      []byte
+	IsPrefix bool
+}
+
+// Overlaps returns true if the two accessed keys can refer to the same account data
+func (ak AccessedKey) Overlaps(other AccessedKey) bool {
+	if !bytes.Equal(ak.Address, other.Address) {
+		return false
+	}
+
+	isAccountFields := len(ak.Key) == 0 && !ak.IsPrefix
+	isOtherAccountFields := len(other.Key) == 0 && !other.IsPrefix
+	if isAccountFields || isOtherAccountFields {
+		return isAccountFields == isOtherAccountFields
+	}
+
+	switch {
+	case ak.IsPrefix && other.IsPrefix:
+		return bytes.HasPrefix(ak.Key, other.Key) || bytes.HasPrefix(other.Key, ak.Key)
+	case ak.IsPrefix:
+		return bytes.HasPrefix(other.Key, ak.Key)
+	case other.IsPrefix:
+		return bytes.HasPrefix(ak.Key, other.Key)
+	default:
+		return bytes.Equal(ak.Key, other.Key)
+	}
+}
+
+// ReadWriteSet holds the account data a built-in function call reads and writes
+type ReadWriteSet struct {
+	Reads  []AccessedKey
+	Writes []AccessedKey
+}
+
+// ConflictsWith returns true if one of the calls writes account data the other one reads or writes, meaning that
+// the two calls can not be executed independently
+func (rws *ReadWriteSet) ConflictsWith(other *ReadWriteSet) bool {
+	if rws == nil || other == nil {
+		return false
+	}
+
+	return anyOverlaps(rws.Writes, other.Writes) ||
+		anyOverlaps(rws.Writes, other.Reads) ||
+		anyOverlaps(rws.Reads, other.Writes)
+}
+
+func anyOverlaps(first []AccessedKey, second []AccessedKey) bool {
+	for _, firstKey := range first {
+		for _, secondKey := range second {
+			if firstKey.Overlaps(secondKey) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package vmcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessedKey_Overlaps(t *testing.T) {
+	t.Parallel()
+
+	addr := []byte("addr")
+	accountFields := AccessedKey{Address: addr}
+	key := AccessedKey{Address: addr, Key: []byte("key")}
+	prefix := AccessedKey{Address: addr, Key: []byte("ke"), IsPrefix: true}
+	otherPrefix := AccessedKey{Address: addr, Key: []byte("other"), IsPrefix: true}
+	allKeys := AccessedKey{Address: addr, IsPrefix: true}
+
+	assert.True(t, accountFields.Overlaps(accountFields))
+	assert.False(t, accountFields.Overlaps(key))
+	assert.False(t, allKeys.Overlaps(accountFields))
+	assert.True(t, allKeys.Overlaps(key))
+	assert.True(t, key.Overlaps(key))
+	assert.False(t, key.Overlaps(AccessedKey{Address: addr, Key: []byte("key2")}))
+	assert.False(t, key.Overlaps(AccessedKey{Address: []byte("other"), Key: []byte("key")}))
+	assert.True(t, key.Overlaps(prefix))
+	assert.True(t, prefix.Overlaps(key))
+	assert.True(t, prefix.Overlaps(allKeys))
+	assert.False(t, prefix.Overlaps(otherPrefix))
+	assert.False(t, key.Overlaps(otherPrefix))
+}
+
+func TestReadWriteSet_ConflictsWith(t *testing.T) {
+	t.Parallel()
+
+	first := AccessedKey{Address: []byte("addr"), Key: []byte("first")}
+	second := AccessedKey{Address: []byte("addr"), Key: []byte("second")}
+
+	reader := &ReadWriteSet{Reads: []AccessedKey{first}}
+	writer := &ReadWriteSet{Reads: []AccessedKey{first}, Writes: []AccessedKey{first}}
+	otherWriter := &ReadWriteSet{Reads: []AccessedKey{second}, Writes: []AccessedKey{second}}
+
+	var nilSet *ReadWriteSet
+	assert.False(t, nilSet.ConflictsWith(writer))
+	assert.False(t, writer.ConflictsWith(nil))
+	assert.False(t, reader.ConflictsWith(reader))
+	assert.True(t, reader.ConflictsWith(writer))
+	assert.True(t, writer.ConflictsWith(reader))
+	assert.True(t, writer.ConflictsWith(writer))
+	assert.False(t, writer.ConflictsWith(otherWriter))
+	assert.False(t, reader.ConflictsWith(otherWriter))
+}
@@ -0,0 +1,21 @@
+package simulation
+
+import "errors"
+
+// ErrNilAccountsAdapter signals that a nil accounts adapter has been provided
+var ErrNilAccountsAdapter = errors.New("nil accounts adapter")
+
+// ErrNilAccount signals that a nil account has been provided
+var ErrNilAccount = errors.New("nil account")
+
+// ErrNilVmInput signals that a nil vm input has been provided
+var ErrNilVmInput = errors.New("nil vm input")
+
+// ErrWrongTypeAssertion signals that a type assertion failed
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrOperationNotSupported signals that the overlay does not support the requested operation
+var ErrOperationNotSupported = errors.New("operation not supported on a simulation overlay")
+
+// ErrInvalidSnapshot signals that the provided snapshot is out of the journal bounds
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
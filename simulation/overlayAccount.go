@@ -0,0 +1,237 @@
+package simulation
+
+import (
+	"math/big"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.UserAccountHandler = (*overlayAccount)(nil)
+var _ vmcommon.AccountDataHandler = (*overlayDataHandler)(nil)
+
+// accountLoader loads, from the underlying accounts adapter, a copy of an account which is not shared with the adapter
+// until saved, as the node's accounts adapter does
+type accountLoader func() (vmcommon.UserAccountHandler, error)
+
+// accountChange is a change applied on an account, through the account's own logic
+type accountChange func(account vmcommon.UserAccountHandler) error
+
+// overlayAccount is a copy-on-write view over an account of the underlying accounts adapter. The changes are applied,
+// through the account's own rules, on a copy loaded from the underlying adapter and are recorded, so that they can be
+// replayed on a new copy whenever the overlay account is copied. The underlying account is only read
+type overlayAccount struct {
+	base          vmcommon.UserAccountHandler
+	loadCopy      accountLoader
+	state         vmcommon.UserAccountHandler
+	changes       []accountChange
+	writtenValues map[string][]byte
+}
+
+func newOverlayAccount(loadCopy accountLoader) (*overlayAccount, error) {
+	base, err := loadCopy()
+	if err != nil {
+		return nil, err
+	}
+	state, err := loadCopy()
+	if err != nil {
+		return nil, err
+	}
+
+	return &overlayAccount{
+		base:          base,
+		loadCopy:      loadCopy,
+		state:         state,
+		changes:       make([]accountChange, 0),
+		writtenValues: make(map[string][]byte),
+	}, nil
+}
+
+// applyChange applies the change on the account copy and records it, if it succeeded
+func (oa *overlayAccount) applyChange(change accountChange) error {
+	err := change(oa.state)
+	if err != nil {
+		return err
+	}
+
+	oa.changes = append(oa.changes, change)
+	return nil
+}
+
+// AddressBytes returns the address of the account
+func (oa *overlayAccount) AddressBytes() []byte {
+	return oa.state.AddressBytes()
+}
+
+// IncreaseNonce adds the provided value to the current nonce
+func (oa *overlayAccount) IncreaseNonce(value uint64) {
+	_ = oa.applyChange(func(account vmcommon.UserAccountHandler) error {
+		account.IncreaseNonce(value)
+		return nil
+	})
+}
+
+// GetNonce returns the account nonce
+func (oa *overlayAccount) GetNonce() uint64 {
+	return oa.state.GetNonce()
+}
+
+// AddToBalance adds the provided value to the balance, as the underlying account does
+func (oa *overlayAccount) AddToBalance(value *big.Int) error {
+	if value != nil {
+		value = big.NewInt(0).Set(value)
+	}
+
+	return oa.applyChange(func(account vmcommon.UserAccountHandler) error {
+		return account.AddToBalance(value)
+	})
+}
+
+// GetBalance returns the account balance
+func (oa *overlayAccount) GetBalance() *big.Int {
+	return oa.state.GetBalance()
+}
+
+// ClaimDeveloperRewards claims the developer reward, as the underlying account does
+func (oa *overlayAccount) ClaimDeveloperRewards(sender []byte) (*big.Int, error) {
+	sender = cloneBytes(sender)
+	reward, err := oa.state.ClaimDeveloperRewards(sender)
+	if err != nil {
+		return nil, err
+	}
+
+	oa.changes = append(oa.changes, func(account vmcommon.UserAccountHandler) error {
+		_, errClaim := account.ClaimDeveloperRewards(sender)
+		return errClaim
+	})
+
+	return reward, nil
+}
+
+// GetDeveloperReward returns the developer reward
+func (oa *overlayAccount) GetDeveloperReward() *big.Int {
+	return oa.state.GetDeveloperReward()
+}
+
+// ChangeOwnerAddress changes the owner address, as the underlying account does
+func (oa *overlayAccount) ChangeOwnerAddress(sender []byte, newAddress []byte) error {
+	sender = cloneBytes(sender)
+	newAddress = cloneBytes(newAddress)
+
+	return oa.applyChange(func(account vmcommon.UserAccountHandler) error {
+		return account.ChangeOwnerAddress(sender, newAddress)
+	})
+}
+
+// SetOwnerAddress sets the owner address
+func (oa *overlayAccount) SetOwnerAddress(address []byte) {
+	address = cloneBytes(address)
+	_ = oa.applyChange(func(account vmcommon.UserAccountHandler) error {
+		account.SetOwnerAddress(address)
+		return nil
+	})
+}
+
+// GetOwnerAddress returns the owner address
+func (oa *overlayAccount) GetOwnerAddress() []byte {
+	return oa.state.GetOwnerAddress()
+}
+
+// SetUserName sets the user name
+func (oa *overlayAccount) SetUserName(userName []byte) {
+	userName = cloneBytes(userName)
+	_ = oa.applyChange(func(account vmcommon.UserAccountHandler) error {
+		account.SetUserName(userName)
+		return nil
+	})
+}
+
+// GetUserName returns the user name
+func (oa *overlayAccount) GetUserName() []byte {
+	return oa.state.GetUserName()
+}
+
+// GetCodeMetadata returns the code metadata of the underlying account
+func (oa *overlayAccount) GetCodeMetadata() []byte {
+	return oa.base.GetCodeMetadata()
+}
+
+// GetCodeHash returns the code hash of the underlying account
+func (oa *overlayAccount) GetCodeHash() []byte {
+	return oa.base.GetCodeHash()
+}
+
+// GetRootHash returns the root hash of the underlying account, as the overlay changes are never hashed
+func (oa *overlayAccount) GetRootHash() []byte {
+	return oa.base.GetRootHash()
+}
+
+// AccountDataHandler returns the copy-on-write handler of the account data
+func (oa *overlayAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return &overlayDataHandler{account: oa}
+}
+
+// clone returns an independent copy of the overlay account, by replaying the recorded changes on a new copy of the
+// underlying account
+func (oa *overlayAccount) clone() (*overlayAccount, error) {
+	state, err := oa.loadCopy()
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range oa.changes {
+		err = change(state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writtenValues := make(map[string][]byte, len(oa.writtenValues))
+	for key, value := range oa.writtenValues {
+		writtenValues[key] = cloneBytes(value)
+	}
+
+	return &overlayAccount{
+		base:          oa.base,
+		loadCopy:      oa.loadCopy,
+		state:         state,
+		changes:       append(make([]accountChange, 0, len(oa.changes)), oa.changes...),
+		writtenValues: writtenValues,
+	}, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (oa *overlayAccount) IsInterfaceNil() bool {
+	return oa == nil
+}
+
+type overlayDataHandler struct {
+	account *overlayAccount
+}
+
+// RetrieveValue returns the value written in the overlay, if any, otherwise the value of the underlying account
+func (odh *overlayDataHandler) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	value, written := odh.account.writtenValues[string(key)]
+	if written {
+		return cloneBytes(value), 0, nil
+	}
+
+	return odh.account.base.AccountDataHandler().RetrieveValue(key)
+}
+
+// SaveKeyValue writes the value in the overlay
+func (odh *overlayDataHandler) SaveKeyValue(key []byte, value []byte) error {
+	odh.account.writtenValues[string(key)] = cloneBytes(value)
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (odh *overlayDataHandler) IsInterfaceNil() bool {
+	return odh == nil
+}
+
+func cloneBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(data)), data...)
+}
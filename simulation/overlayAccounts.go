@@ -0,0 +1,258 @@
+package simulation
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.AccountsAdapter = (*overlayAccounts)(nil)
+
+type journalEntry struct {
+	address  string
+	previous *overlayAccount
+}
+
+// StorageChange holds a key written in an account data trie during a simulation
+type StorageChange struct {
+	Key      []byte
+	OldValue []byte
+	NewValue []byte
+}
+
+// AccountStorageDiff holds all the keys written in the data trie of an account during a simulation, sorted by key
+type AccountStorageDiff struct {
+	Address []byte
+	Changes []StorageChange
+}
+
+// overlayAccounts is a copy-on-write accounts adapter: the accounts are read from the underlying adapter and
+// all the saved accounts are kept in memory, so the underlying adapter is never written
+type overlayAccounts struct {
+	underlying  vmcommon.AccountsAdapter
+	mutAccounts sync.RWMutex
+	accounts    map[string]*overlayAccount
+	journal     []journalEntry
+}
+
+// NewOverlayAccounts creates a new copy-on-write accounts adapter over the provided one
+func NewOverlayAccounts(underlying vmcommon.AccountsAdapter) (*overlayAccounts, error) {
+	if check.IfNil(underlying) {
+		return nil, ErrNilAccountsAdapter
+	}
+
+	return &overlayAccounts{
+		underlying: underlying,
+		accounts:   make(map[string]*overlayAccount),
+		journal:    make([]journalEntry, 0),
+	}, nil
+}
+
+// GetExistingAccount returns the overlay account if it was saved, otherwise the existing account of the underlying adapter
+func (oa *overlayAccounts) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	return oa.getAccount(address, oa.underlying.GetExistingAccount)
+}
+
+// LoadAccount returns the overlay account if it was saved, otherwise loads it from the underlying adapter
+func (oa *overlayAccounts) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	return oa.getAccount(address, oa.underlying.LoadAccount)
+}
+
+func (oa *overlayAccounts) getAccount(
+	address []byte,
+	underlyingGetter func(address []byte) (vmcommon.AccountHandler, error),
+) (vmcommon.AccountHandler, error) {
+	oa.mutAccounts.RLock()
+	account, found := oa.accounts[string(address)]
+	oa.mutAccounts.RUnlock()
+	if found {
+		return account.clone()
+	}
+
+	loadCopy := func() (vmcommon.UserAccountHandler, error) {
+		baseAccount, err := underlyingGetter(address)
+		if err != nil {
+			return nil, err
+		}
+
+		userAccount, ok := baseAccount.(vmcommon.UserAccountHandler)
+		if !ok {
+			return nil, ErrWrongTypeAssertion
+		}
+
+		return userAccount, nil
+	}
+
+	return newOverlayAccount(loadCopy)
+}
+
+// SaveAccount keeps a copy of the provided account in the overlay. As the node's accounts adapter does, the written
+// keys are applied as deltas, so saving an account loaded before other saves does not drop their written keys
+func (oa *overlayAccounts) SaveAccount(account vmcommon.AccountHandler) error {
+	if check.IfNil(account) {
+		return ErrNilAccount
+	}
+
+	overlayAcc, ok := account.(*overlayAccount)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	savedAccount, err := overlayAcc.clone()
+	if err != nil {
+		return err
+	}
+
+	oa.mutAccounts.Lock()
+	defer oa.mutAccounts.Unlock()
+
+	address := string(overlayAcc.AddressBytes())
+	previous := oa.accounts[address]
+	oa.journal = append(oa.journal, journalEntry{
+		address:  address,
+		previous: previous,
+	})
+
+	if previous != nil {
+		for key, value := range previous.writtenValues {
+			_, overwritten := savedAccount.writtenValues[key]
+			if !overwritten {
+				savedAccount.writtenValues[key] = value
+			}
+		}
+	}
+	oa.accounts[address] = savedAccount
+
+	return nil
+}
+
+// isSavedSince returns true if the account was saved on the overlay after the provided snapshot
+func (oa *overlayAccounts) isSavedSince(address []byte, snapshot int) bool {
+	oa.mutAccounts.RLock()
+	defer oa.mutAccounts.RUnlock()
+
+	for i := snapshot; i < len(oa.journal); i++ {
+		if oa.journal[i].address == string(address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveAccount is not supported on the overlay
+func (oa *overlayAccounts) RemoveAccount(_ []byte) error {
+	return ErrOperationNotSupported
+}
+
+// Commit is not supported on the overlay, as the underlying adapter must stay untouched
+func (oa *overlayAccounts) Commit() ([]byte, error) {
+	return nil, ErrOperationNotSupported
+}
+
+// JournalLen returns the number of accounts saves done on the overlay
+func (oa *overlayAccounts) JournalLen() int {
+	oa.mutAccounts.RLock()
+	defer oa.mutAccounts.RUnlock()
+
+	return len(oa.journal)
+}
+
+// RevertToSnapshot reverts the overlay saves done after the provided snapshot
+func (oa *overlayAccounts) RevertToSnapshot(snapshot int) error {
+	oa.mutAccounts.Lock()
+	defer oa.mutAccounts.Unlock()
+
+	if snapshot < 0 || snapshot > len(oa.journal) {
+		return ErrInvalidSnapshot
+	}
+
+	for i := len(oa.journal) - 1; i >= snapshot; i-- {
+		entry := oa.journal[i]
+		if entry.previous == nil {
+			delete(oa.accounts, entry.address)
+			continue
+		}
+		oa.accounts[entry.address] = entry.previous
+	}
+	oa.journal = oa.journal[:snapshot]
+
+	return nil
+}
+
+// GetCode returns the code from the underlying adapter
+func (oa *overlayAccounts) GetCode(codeHash []byte) []byte {
+	return oa.underlying.GetCode(codeHash)
+}
+
+// RootHash returns the root hash of the underlying adapter, as the overlay changes are never hashed
+func (oa *overlayAccounts) RootHash() ([]byte, error) {
+	return oa.underlying.RootHash()
+}
+
+// StorageDiff returns, for every saved account with written keys, the old and the new values of these keys.
+// The accounts are sorted by address
+func (oa *overlayAccounts) StorageDiff() ([]AccountStorageDiff, error) {
+	oa.mutAccounts.RLock()
+	defer oa.mutAccounts.RUnlock()
+
+	addresses := make([]string, 0, len(oa.accounts))
+	for address, account := range oa.accounts {
+		if len(account.writtenValues) > 0 {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	diff := make([]AccountStorageDiff, 0, len(addresses))
+	for _, address := range addresses {
+		accountDiff, err := computeAccountStorageDiff(oa.accounts[address])
+		if err != nil {
+			return nil, err
+		}
+
+		diff = append(diff, accountDiff)
+	}
+
+	return diff, nil
+}
+
+func computeAccountStorageDiff(account *overlayAccount) (AccountStorageDiff, error) {
+	keys := make([]string, 0, len(account.writtenValues))
+	for key := range account.writtenValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	accountDiff := AccountStorageDiff{
+		Address: cloneBytes(account.AddressBytes()),
+		Changes: make([]StorageChange, 0, len(keys)),
+	}
+	for _, key := range keys {
+		oldValue, _, err := account.base.AccountDataHandler().RetrieveValue([]byte(key))
+		if err != nil {
+			return AccountStorageDiff{}, err
+		}
+
+		accountDiff.Changes = append(accountDiff.Changes, StorageChange{
+			Key:      []byte(key),
+			OldValue: cloneBytes(oldValue),
+			NewValue: cloneBytes(account.writtenValues[key]),
+		})
+	}
+
+	return accountDiff, nil
+}
+
+// IsModified returns true if the written value differs from the value of the underlying account
+func (sc StorageChange) IsModified() bool {
+	return !bytes.Equal(sc.OldValue, sc.NewValue)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (oa *overlayAccounts) IsInterfaceNil() bool {
+	return oa == nil
+}
@@ -0,0 +1,180 @@
+package simulation
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core/check"
+	"github.com/kalyan3104/k-core/hashing/sha256"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	senderAddress   = bytes.Repeat([]byte{1}, 32)
+	receiverAddress = bytes.Repeat([]byte{2}, 32)
+)
+
+func createAccounts(t *testing.T) vmcommon.AccountsAdapter {
+	accounts, err := state.NewAccountsDB(state.ArgsNewAccountsDB{Hasher: sha256.NewSha256()})
+	require.Nil(t, err)
+
+	return accounts
+}
+
+func saveAccount(t *testing.T, accounts vmcommon.AccountsAdapter, address []byte, handler func(acc vmcommon.UserAccountHandler)) {
+	account, err := accounts.LoadAccount(address)
+	require.Nil(t, err)
+
+	userAcc := account.(vmcommon.UserAccountHandler)
+	handler(userAcc)
+	require.Nil(t, accounts.SaveAccount(userAcc))
+}
+
+func TestNewOverlayAccounts(t *testing.T) {
+	t.Parallel()
+
+	overlay, err := NewOverlayAccounts(nil)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+	assert.True(t, check.IfNil(overlay))
+
+	overlay, err = NewOverlayAccounts(createAccounts(t))
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(overlay))
+}
+
+func TestOverlayAccounts_ShouldNotChangeUnderlyingAccounts(t *testing.T) {
+	t.Parallel()
+
+	accounts := createAccounts(t)
+	saveAccount(t, accounts, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		_ = acc.AddToBalance(big.NewInt(100))
+		_ = acc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("old"))
+	})
+	rootHash, _ := accounts.Commit()
+
+	overlay, _ := NewOverlayAccounts(accounts)
+	account, err := overlay.LoadAccount(senderAddress)
+	require.Nil(t, err)
+	userAcc := account.(vmcommon.UserAccountHandler)
+	assert.Equal(t, big.NewInt(100), userAcc.GetBalance())
+
+	assert.Nil(t, userAcc.AddToBalance(big.NewInt(-40)))
+	assert.Equal(t, state.ErrInsufficientFunds, userAcc.AddToBalance(big.NewInt(-100)))
+	userAcc.IncreaseNonce(1)
+	_ = userAcc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("new"))
+	_ = userAcc.AccountDataHandler().SaveKeyValue([]byte("other"), []byte("value"))
+
+	reloaded, _ := overlay.LoadAccount(senderAddress)
+	assert.Equal(t, big.NewInt(100), reloaded.(vmcommon.UserAccountHandler).GetBalance(), "unsaved changes should not be visible")
+
+	require.Nil(t, overlay.SaveAccount(userAcc))
+	reloaded, _ = overlay.LoadAccount(senderAddress)
+	reloadedUserAcc := reloaded.(vmcommon.UserAccountHandler)
+	assert.Equal(t, big.NewInt(60), reloadedUserAcc.GetBalance())
+	assert.Equal(t, uint64(1), reloadedUserAcc.GetNonce())
+	value, _, _ := reloadedUserAcc.AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("new"), value)
+
+	diff, err := overlay.StorageDiff()
+	require.Nil(t, err)
+	assert.Equal(t, []AccountStorageDiff{
+		{
+			Address: senderAddress,
+			Changes: []StorageChange{
+				{Key: []byte("key"), OldValue: []byte("old"), NewValue: []byte("new")},
+				{Key: []byte("other"), OldValue: nil, NewValue: []byte("value")},
+			},
+		},
+	}, diff)
+	assert.True(t, diff[0].Changes[0].IsModified())
+
+	_, err = overlay.Commit()
+	assert.Equal(t, ErrOperationNotSupported, err)
+	assert.Equal(t, ErrOperationNotSupported, overlay.RemoveAccount(senderAddress))
+
+	underlyingAccount, _ := accounts.LoadAccount(senderAddress)
+	underlyingUserAcc := underlyingAccount.(vmcommon.UserAccountHandler)
+	assert.Equal(t, big.NewInt(100), underlyingUserAcc.GetBalance())
+	value, _, _ = underlyingUserAcc.AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("old"), value)
+	currentRootHash, _ := accounts.RootHash()
+	assert.Equal(t, rootHash, currentRootHash)
+	assert.Equal(t, 0, accounts.JournalLen())
+}
+
+func TestOverlayAccounts_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	overlay, _ := NewOverlayAccounts(createAccounts(t))
+	saveAccount(t, overlay, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		_ = acc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("first"))
+	})
+	snapshot := overlay.JournalLen()
+	saveAccount(t, overlay, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		_ = acc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("second"))
+	})
+	saveAccount(t, overlay, receiverAddress, func(acc vmcommon.UserAccountHandler) {
+		_ = acc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+	})
+	assert.Equal(t, 3, overlay.JournalLen())
+
+	assert.Equal(t, ErrInvalidSnapshot, overlay.RevertToSnapshot(4))
+	assert.Nil(t, overlay.RevertToSnapshot(snapshot))
+
+	diff, _ := overlay.StorageDiff()
+	require.Len(t, diff, 1)
+	assert.Equal(t, []byte("first"), diff[0].Changes[0].NewValue)
+
+	_, err := overlay.GetExistingAccount(receiverAddress)
+	assert.NotNil(t, err)
+}
+
+func TestOverlayAccount_OwnerAndDeveloperRewards(t *testing.T) {
+	t.Parallel()
+
+	overlay, _ := NewOverlayAccounts(createAccounts(t))
+	account, _ := overlay.LoadAccount(receiverAddress)
+	userAcc := account.(vmcommon.UserAccountHandler)
+
+	userAcc.SetOwnerAddress(senderAddress)
+	assert.Equal(t, state.ErrOperationNotPermitted, userAcc.ChangeOwnerAddress(receiverAddress, receiverAddress))
+	assert.Equal(t, state.ErrInvalidAddressLength, userAcc.ChangeOwnerAddress(senderAddress, []byte("short")))
+	assert.Nil(t, userAcc.ChangeOwnerAddress(senderAddress, receiverAddress))
+	assert.Equal(t, receiverAddress, userAcc.GetOwnerAddress())
+
+	_, err := userAcc.ClaimDeveloperRewards(senderAddress)
+	assert.Equal(t, state.ErrOperationNotPermitted, err)
+	reward, err := userAcc.ClaimDeveloperRewards(receiverAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), reward)
+
+	userAcc.SetUserName([]byte("name"))
+	assert.Equal(t, []byte("name"), userAcc.GetUserName())
+}
+
+func TestOverlayAccounts_SaveAccountShouldApplyWrittenKeysAsDeltas(t *testing.T) {
+	t.Parallel()
+
+	overlay, _ := NewOverlayAccounts(createAccounts(t))
+	staleAccount, _ := overlay.LoadAccount(senderAddress)
+
+	saveAccount(t, overlay, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		_ = acc.AccountDataHandler().SaveKeyValue([]byte("key1"), []byte("value1"))
+		_ = acc.AccountDataHandler().SaveKeyValue([]byte("key2"), []byte("value2"))
+	})
+
+	staleUserAcc := staleAccount.(vmcommon.UserAccountHandler)
+	_ = staleUserAcc.AccountDataHandler().SaveKeyValue([]byte("key2"), []byte("stale"))
+	require.Nil(t, overlay.SaveAccount(staleUserAcc))
+
+	diff, _ := overlay.StorageDiff()
+	require.Len(t, diff, 1)
+	assert.Equal(t, []StorageChange{
+		{Key: []byte("key1"), NewValue: []byte("value1")},
+		{Key: []byte("key2"), NewValue: []byte("stale")},
+	}, diff[0].Changes)
+}
@@ -0,0 +1,218 @@
+package simulation
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/builtInFunctions"
+)
+
+// ArgsBuiltInFunctionsSimulator holds the components needed to create a built-in functions simulator.
+// The Accounts field of BuiltInFunctionsArgs is ignored, as the built-in functions are created once over an overlay
+// of Accounts, which every simulation starts from empty. PayableHandler is optional
+type ArgsBuiltInFunctionsSimulator struct {
+	Accounts             vmcommon.AccountsAdapter
+	BuiltInFunctionsArgs builtInFunctions.ArgsCreateBuiltInFunctionContainer
+	PayableHandler       vmcommon.PayableHandler
+}
+
+// SimulationResult holds the output of a simulated built-in function call and the storage changes it would do.
+// If the built-in function failed, Error holds the cause as a VMError, carrying the return code the execution would
+// have ended with, while VMOutput has the SimulateFailed return code
+type SimulationResult struct {
+	VMOutput    *vmcommon.VMOutput
+	StorageDiff []AccountStorageDiff
+	Error       error
+}
+
+type builtInFunctionsSimulator struct {
+	mutSimulation    sync.Mutex
+	overlay          *overlayAccounts
+	container        vmcommon.BuiltInFunctionContainer
+	shardCoordinator vmcommon.Coordinator
+}
+
+// NewBuiltInFunctionsSimulator creates a component able to execute built-in functions without changing the state
+func NewBuiltInFunctionsSimulator(args ArgsBuiltInFunctionsSimulator) (*builtInFunctionsSimulator, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+
+	overlay, err := NewOverlayAccounts(args.Accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := createBuiltInFunctions(args, overlay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &builtInFunctionsSimulator{
+		overlay:          overlay,
+		container:        container,
+		shardCoordinator: args.BuiltInFunctionsArgs.ShardCoordinator,
+	}, nil
+}
+
+// Simulate executes the built-in function from the provided input over a copy-on-write overlay of the accounts.
+// The sender and the destination accounts are loaded and saved as the blockchain hook does, but only in the overlay,
+// so the underlying accounts adapter is never changed. An account the built-in function saved itself is not saved
+// again. The gas charges made by the built-in function are attached to the resulted VMOutput, even if the execution
+// failed. The simulations share the built-in functions and the overlay, so they are executed one at a time and the
+// overlay is reverted after each of them
+func (s *builtInFunctionsSimulator) Simulate(vmInput *vmcommon.ContractCallInput) (*SimulationResult, error) {
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if vmInput.GasTrace == nil {
+		inputWithGasTrace := *vmInput
+		inputWithGasTrace.GasTrace = vmcommon.NewGasTrace()
+		vmInput = &inputWithGasTrace
+	}
+
+	s.mutSimulation.Lock()
+	defer s.mutSimulation.Unlock()
+	defer s.revertOverlay()
+
+	overlay := s.overlay
+	function, err := s.container.Get(vmInput.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	sndAccount, dstAccount, err := s.getUserAccounts(overlay, vmInput)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := overlay.JournalLen()
+	vmOutput, err := function.ProcessBuiltinFunction(sndAccount, dstAccount, vmInput)
+	if err != nil {
+		vmErr := builtInFunctions.NewVMError(vmInput.Function, err)
+		return &SimulationResult{
+			VMOutput: &vmcommon.VMOutput{
+				ReturnCode:    vmcommon.SimulateFailed,
+				ReturnMessage: vmErr.Error(),
+				GasTrace:      vmInput.GasTrace,
+			},
+			StorageDiff: make([]AccountStorageDiff, 0),
+			Error:       vmErr,
+		}, nil
+	}
+
+	err = saveAccountIfNotSaved(overlay, sndAccount, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		err = saveAccountIfNotSaved(overlay, dstAccount, snapshot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	storageDiff, err := overlay.StorageDiff()
+	if err != nil {
+		return nil, err
+	}
+	vmOutput.GasTrace = vmInput.GasTrace
+
+	return &SimulationResult{
+		VMOutput:    vmOutput,
+		StorageDiff: storageDiff,
+	}, nil
+}
+
+// saveAccountIfNotSaved saves the account the built-in function was called with, unless the built-in function already
+// saved its own state of the account, which the provided one would overwrite
+func saveAccountIfNotSaved(overlay *overlayAccounts, account vmcommon.UserAccountHandler, snapshot int) error {
+	if check.IfNil(account) || overlay.isSavedSince(account.AddressBytes(), snapshot) {
+		return nil
+	}
+
+	return overlay.SaveAccount(account)
+}
+
+// revertOverlay discards all the accounts saved in the overlay, so the next simulation starts from the underlying state
+func (s *builtInFunctionsSimulator) revertOverlay() {
+	_ = s.overlay.RevertToSnapshot(0)
+}
+
+func createBuiltInFunctions(args ArgsBuiltInFunctionsSimulator, overlay vmcommon.AccountsAdapter) (vmcommon.BuiltInFunctionContainer, error) {
+	argsCreator := args.BuiltInFunctionsArgs
+	argsCreator.Accounts = overlay
+
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(argsCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	err = creator.CreateBuiltInFunctionContainer()
+	if err != nil {
+		return nil, err
+	}
+
+	if !check.IfNil(args.PayableHandler) {
+		err = creator.SetPayableHandler(args.PayableHandler)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return creator.BuiltInFunctionContainer(), nil
+}
+
+func (s *builtInFunctionsSimulator) getUserAccounts(
+	overlay vmcommon.AccountsAdapter,
+	vmInput *vmcommon.ContractCallInput,
+) (vmcommon.UserAccountHandler, vmcommon.UserAccountHandler, error) {
+	var sndAccount vmcommon.UserAccountHandler
+	var err error
+	if s.isInSelfShard(vmInput.CallerAddr) {
+		sndAccount, err = loadUserAccount(overlay, vmInput.CallerAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return sndAccount, sndAccount, nil
+	}
+
+	var dstAccount vmcommon.UserAccountHandler
+	if s.isInSelfShard(vmInput.RecipientAddr) {
+		dstAccount, err = loadUserAccount(overlay, vmInput.RecipientAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return sndAccount, dstAccount, nil
+}
+
+func (s *builtInFunctionsSimulator) isInSelfShard(address []byte) bool {
+	return s.shardCoordinator.ComputeId(address) == s.shardCoordinator.SelfId()
+}
+
+func loadUserAccount(accounts vmcommon.AccountsAdapter, address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAccount, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *builtInFunctionsSimulator) IsInterfaceNil() bool {
+	return s == nil
+}
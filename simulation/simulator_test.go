@@ -0,0 +1,229 @@
+package simulation
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core"
+	"github.com/kalyan3104/k-core/core/check"
+	"github.com/kalyan3104/k-core/data/dct"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/builtInFunctions"
+	"github.com/kalyan3104/k-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	tokenID        = []byte("TKN-abcdef")
+	tokenKey       = []byte(core.ProtectedKeyPrefix + core.DCTKeyIdentifier + string(tokenID))
+	testMarshaller = &mock.MarshalizerMock{}
+)
+
+func createMockArgs(accounts vmcommon.AccountsAdapter) ArgsBuiltInFunctionsSimulator {
+	return ArgsBuiltInFunctionsSimulator{
+		Accounts: accounts,
+		BuiltInFunctionsArgs: builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+			GasMap:                           mock.CreateGasMap(1),
+			MapDNSAddresses:                  make(map[string]struct{}),
+			Marshalizer:                      testMarshaller,
+			ShardCoordinator:                 mock.NewMultiShardsCoordinatorMock(1),
+			EnableEpochsHandler:              &mock.EnableEpochsHandlerStub{},
+			MaxNumOfAddressesForTransferRole: 100,
+		},
+		PayableHandler: &mock.PayableHandlerStub{},
+	}
+}
+
+func createTransferInput(value int64) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  senderAddress,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{tokenID, big.NewInt(value).Bytes()},
+			GasProvided: 100,
+		},
+		RecipientAddr: receiverAddress,
+		Function:      core.BuiltInFunctionDCTTransfer,
+	}
+}
+
+func marshalToken(t *testing.T, value int64) []byte {
+	marshaledData, err := testMarshaller.Marshal(&dct.DCToken{Value: big.NewInt(value)})
+	require.Nil(t, err)
+
+	return marshaledData
+}
+
+func TestNewBuiltInFunctionsSimulator(t *testing.T) {
+	t.Parallel()
+
+	simulator, err := NewBuiltInFunctionsSimulator(createMockArgs(nil))
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+	assert.True(t, check.IfNil(simulator))
+
+	args := createMockArgs(createAccounts(t))
+	args.BuiltInFunctionsArgs.EnableEpochsHandler = nil
+	simulator, err = NewBuiltInFunctionsSimulator(args)
+	assert.Equal(t, builtInFunctions.ErrNilEnableEpochsHandler, err)
+	assert.True(t, check.IfNil(simulator))
+
+	simulator, err = NewBuiltInFunctionsSimulator(createMockArgs(createAccounts(t)))
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(simulator))
+}
+
+func TestBuiltInFunctionsSimulator_SimulateTransferShouldNotChangeState(t *testing.T) {
+	t.Parallel()
+
+	accounts := createAccounts(t)
+	saveAccount(t, accounts, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		_ = acc.AccountDataHandler().SaveKeyValue(tokenKey, marshalToken(t, 100))
+	})
+	rootHash, _ := accounts.Commit()
+
+	simulator, _ := NewBuiltInFunctionsSimulator(createMockArgs(accounts))
+
+	_, err := simulator.Simulate(nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createTransferInput(40)
+	input.Function = "missing"
+	_, err = simulator.Simulate(input)
+	assert.True(t, errors.Is(err, builtInFunctions.ErrInvalidContainerKey))
+
+	result, err := simulator.Simulate(createTransferInput(40))
+	require.Nil(t, err)
+	assert.Nil(t, result.Error)
+	assert.Equal(t, vmcommon.Ok, result.VMOutput.ReturnCode)
+	assert.Equal(t, []vmcommon.GasTraceEntry{{Label: "FunctionCost", Units: 1, Total: 1}}, result.VMOutput.GasTrace.Entries())
+	assert.Equal(t, uint64(100)-result.VMOutput.GasRemaining, result.VMOutput.GasTrace.Total())
+	assert.Equal(t, []AccountStorageDiff{
+		{
+			Address: senderAddress,
+			Changes: []StorageChange{{Key: tokenKey, OldValue: marshalToken(t, 100), NewValue: marshalToken(t, 60)}},
+		},
+		{
+			Address: receiverAddress,
+			Changes: []StorageChange{{Key: tokenKey, OldValue: nil, NewValue: marshalToken(t, 40)}},
+		},
+	}, result.StorageDiff)
+
+	currentRootHash, _ := accounts.RootHash()
+	assert.Equal(t, rootHash, currentRootHash)
+	assert.Equal(t, 0, accounts.JournalLen())
+
+	result, err = simulator.Simulate(createTransferInput(40))
+	require.Nil(t, err)
+	assert.Equal(t, marshalToken(t, 60), result.StorageDiff[0].Changes[0].NewValue, "simulations should not see each other")
+}
+
+func TestBuiltInFunctionsSimulator_ConcurrentSimulationsShouldNotSeeEachOther(t *testing.T) {
+	t.Parallel()
+
+	accounts := createAccounts(t)
+	saveAccount(t, accounts, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		_ = acc.AccountDataHandler().SaveKeyValue(tokenKey, marshalToken(t, 100))
+	})
+	_, _ = accounts.Commit()
+
+	simulator, _ := NewBuiltInFunctionsSimulator(createMockArgs(accounts))
+
+	numSimulations := 10
+	results := make([]*SimulationResult, numSimulations)
+	wg := sync.WaitGroup{}
+	wg.Add(numSimulations)
+	for i := 0; i < numSimulations; i++ {
+		go func(idx int) {
+			defer wg.Done()
+			results[idx], _ = simulator.Simulate(createTransferInput(40))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		require.NotNil(t, result)
+		assert.Equal(t, marshalToken(t, 60), result.StorageDiff[0].Changes[0].NewValue)
+	}
+}
+
+func TestBuiltInFunctionsSimulator_SimulateFailedExecution(t *testing.T) {
+	t.Parallel()
+
+	accounts := createAccounts(t)
+	simulator, _ := NewBuiltInFunctionsSimulator(createMockArgs(accounts))
+
+	result, err := simulator.Simulate(createTransferInput(40))
+	require.Nil(t, err)
+	assert.True(t, errors.Is(result.Error, builtInFunctions.ErrInsufficientFunds))
+	assert.Equal(t, vmcommon.OutOfFunds, vmcommon.ReturnCodeFromError(result.Error))
+	assert.Equal(t, vmcommon.SimulateFailed, result.VMOutput.ReturnCode)
+	assert.Equal(t, result.Error.Error(), result.VMOutput.ReturnMessage)
+	assert.Empty(t, result.StorageDiff)
+	assert.Equal(t, []vmcommon.GasTraceEntry{{Label: "FunctionCost", Units: 1, Total: 1}}, result.VMOutput.GasTrace.Entries())
+
+	input := createTransferInput(40)
+	gasTrace := vmcommon.NewGasTrace()
+	input.GasTrace = gasTrace
+	result, _ = simulator.Simulate(input)
+	assert.True(t, result.VMOutput.GasTrace == gasTrace)
+	assert.Equal(t, uint64(1), gasTrace.Total())
+}
+
+func TestBuiltInFunctionsSimulator_SimulateShouldReportSystemAccountChanges(t *testing.T) {
+	t.Parallel()
+
+	accounts := createAccounts(t)
+	rootHash, _ := accounts.RootHash()
+	simulator, _ := NewBuiltInFunctionsSimulator(createMockArgs(accounts))
+
+	result, err := simulator.Simulate(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.DCTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{tokenID},
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+		Function:      core.BuiltInFunctionDCTPause,
+	})
+	require.Nil(t, err)
+	require.Nil(t, result.Error)
+
+	require.Len(t, result.StorageDiff, 1)
+	assert.Equal(t, vmcommon.SystemAccountAddress, result.StorageDiff[0].Address)
+	require.Len(t, result.StorageDiff[0].Changes, 1)
+	change := result.StorageDiff[0].Changes[0]
+	assert.Equal(t, tokenKey, change.Key)
+	assert.True(t, builtInFunctions.DCTGlobalMetadataFromBytes(change.NewValue).Paused)
+
+	currentRootHash, _ := accounts.RootHash()
+	assert.Equal(t, rootHash, currentRootHash)
+	systemAccount, _ := accounts.LoadAccount(vmcommon.SystemAccountAddress)
+	value, _, _ := systemAccount.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue(tokenKey)
+	assert.Empty(t, value)
+}
+
+func TestSaveAccountIfNotSaved_ShouldNotOverwriteAccountSavedByBuiltInFunction(t *testing.T) {
+	t.Parallel()
+
+	overlay, _ := NewOverlayAccounts(createAccounts(t))
+	staleAccount, _ := loadUserAccount(overlay, senderAddress)
+	snapshot := overlay.JournalLen()
+
+	saveAccount(t, overlay, senderAddress, func(acc vmcommon.UserAccountHandler) {
+		_ = acc.AddToBalance(big.NewInt(10))
+	})
+	require.Nil(t, saveAccountIfNotSaved(overlay, staleAccount, snapshot))
+	assert.Equal(t, 1, overlay.JournalLen())
+
+	account, _ := loadUserAccount(overlay, senderAddress)
+	assert.Equal(t, big.NewInt(10), account.GetBalance())
+
+	otherAccount, _ := loadUserAccount(overlay, receiverAddress)
+	_ = otherAccount.AddToBalance(big.NewInt(5))
+	require.Nil(t, saveAccountIfNotSaved(overlay, otherAccount, snapshot))
+	assert.Equal(t, 2, overlay.JournalLen())
+	require.Nil(t, saveAccountIfNotSaved(overlay, nil, snapshot))
+}
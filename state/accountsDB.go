@@ -0,0 +1,254 @@
+package state
+
+import (
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	"github.com/kalyan3104/k-core/core/check"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.AccountsAdapter = (*accountsDB)(nil)
+
+// journalEntry holds the state of an account before it was saved or removed.
+// A nil previous account means the account did not exist.
+type journalEntry struct {
+	address  string
+	previous *userAccount
+}
+
+// ArgsNewAccountsDB defines the arguments needed to create a new in-memory accounts adapter
+type ArgsNewAccountsDB struct {
+	Hasher vmcommon.Hasher
+}
+
+// accountsDB is an in-memory accounts adapter with a journal of all the changes since the last commit
+type accountsDB struct {
+	hasher   vmcommon.Hasher
+	accounts map[string]*userAccount
+	codes    map[string][]byte
+	journal  []journalEntry
+	mut      sync.RWMutex
+}
+
+// NewAccountsDB creates a new in-memory accounts adapter
+func NewAccountsDB(args ArgsNewAccountsDB) (*accountsDB, error) {
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+
+	return &accountsDB{
+		hasher:   args.Hasher,
+		accounts: make(map[string]*userAccount),
+		codes:    make(map[string][]byte),
+		journal:  make([]journalEntry, 0),
+	}, nil
+}
+
+// GetExistingAccount returns a copy of the account saved under the provided address.
+// Returns ErrAccountNotFound if the account does not exist
+func (adb *accountsDB) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+
+	adb.mut.RLock()
+	defer adb.mut.RUnlock()
+
+	account, exists := adb.accounts[string(address)]
+	if !exists {
+		return nil, ErrAccountNotFound
+	}
+
+	return account.clone(), nil
+}
+
+// LoadAccount returns a copy of the account saved under the provided address or a new empty account if it does
+// not exist. Changes on the returned account are visible only after SaveAccount is called
+func (adb *accountsDB) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+
+	adb.mut.RLock()
+	defer adb.mut.RUnlock()
+
+	account, exists := adb.accounts[string(address)]
+	if !exists {
+		return NewUserAccount(address)
+	}
+
+	return account.clone(), nil
+}
+
+// SaveAccount saves a copy of the provided account and journalizes its previous state. The code hash and the root
+// hash are computed on the saved copy, the provided account being left untouched
+func (adb *accountsDB) SaveAccount(account vmcommon.AccountHandler) error {
+	if check.IfNil(account) {
+		return ErrNilAccount
+	}
+	userAcc, ok := account.(*userAccount)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	adb.mut.Lock()
+	defer adb.mut.Unlock()
+
+	stored := userAcc.clone()
+	stored.codeHash = nil
+	if len(stored.code) > 0 {
+		stored.codeHash = adb.hasher.Compute(string(stored.code))
+		adb.codes[string(stored.codeHash)] = cloneBytes(stored.code)
+	}
+	stored.rootHash = adb.computeDataRootHash(stored.dataTrie)
+
+	address := string(stored.address)
+	adb.journalize(address)
+	adb.accounts[address] = stored
+
+	return nil
+}
+
+// RemoveAccount removes the account saved under the provided address and journalizes its previous state
+func (adb *accountsDB) RemoveAccount(address []byte) error {
+	if len(address) == 0 {
+		return ErrNilAddress
+	}
+
+	adb.mut.Lock()
+	defer adb.mut.Unlock()
+
+	_, exists := adb.accounts[string(address)]
+	if !exists {
+		return ErrAccountNotFound
+	}
+
+	adb.journalize(string(address))
+	delete(adb.accounts, string(address))
+
+	return nil
+}
+
+func (adb *accountsDB) journalize(address string) {
+	adb.journal = append(adb.journal, journalEntry{
+		address:  address,
+		previous: adb.accounts[address],
+	})
+}
+
+// JournalLen returns the number of changes since the last commit
+func (adb *accountsDB) JournalLen() int {
+	adb.mut.RLock()
+	defer adb.mut.RUnlock()
+
+	return len(adb.journal)
+}
+
+// RevertToSnapshot reverts all the changes done after the provided journal length
+func (adb *accountsDB) RevertToSnapshot(snapshot int) error {
+	adb.mut.Lock()
+	defer adb.mut.Unlock()
+
+	if snapshot < 0 || snapshot > len(adb.journal) {
+		return ErrInvalidSnapshot
+	}
+
+	for i := len(adb.journal) - 1; i >= snapshot; i-- {
+		entry := adb.journal[i]
+		if entry.previous == nil {
+			delete(adb.accounts, entry.address)
+			continue
+		}
+
+		adb.accounts[entry.address] = entry.previous
+	}
+	adb.journal = adb.journal[:snapshot]
+
+	return nil
+}
+
+// Commit clears the journal and returns the root hash of the current state
+func (adb *accountsDB) Commit() ([]byte, error) {
+	adb.mut.Lock()
+	defer adb.mut.Unlock()
+
+	adb.journal = make([]journalEntry, 0)
+
+	return adb.computeRootHash(), nil
+}
+
+// RootHash returns the root hash of the current state
+func (adb *accountsDB) RootHash() ([]byte, error) {
+	adb.mut.RLock()
+	defer adb.mut.RUnlock()
+
+	return adb.computeRootHash(), nil
+}
+
+// GetCode returns the code saved under the provided code hash
+func (adb *accountsDB) GetCode(codeHash []byte) []byte {
+	adb.mut.RLock()
+	defer adb.mut.RUnlock()
+
+	return cloneBytes(adb.codes[string(codeHash)])
+}
+
+// computeRootHash hashes all the accounts, sorted by address, so the result only depends on the state
+func (adb *accountsDB) computeRootHash() []byte {
+	addresses := make([]string, 0, len(adb.accounts))
+	for address := range adb.accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	buff := make([]byte, 0)
+	for _, address := range addresses {
+		buff = appendWithLength(buff, []byte(address))
+		buff = appendWithLength(buff, adb.computeAccountHash(adb.accounts[address]))
+	}
+
+	return adb.hasher.Compute(string(buff))
+}
+
+func (adb *accountsDB) computeAccountHash(account *userAccount) []byte {
+	nonce := make([]byte, 8)
+	binary.BigEndian.PutUint64(nonce, account.nonce)
+
+	buff := make([]byte, 0)
+	buff = appendWithLength(buff, account.address)
+	buff = appendWithLength(buff, nonce)
+	buff = appendWithLength(buff, account.balance.Bytes())
+	buff = appendWithLength(buff, account.developerReward.Bytes())
+	buff = appendWithLength(buff, account.ownerAddress)
+	buff = appendWithLength(buff, account.userName)
+	buff = appendWithLength(buff, account.codeHash)
+	buff = appendWithLength(buff, account.codeMetadata)
+	buff = appendWithLength(buff, adb.computeDataRootHash(account.dataTrie))
+
+	return adb.hasher.Compute(string(buff))
+}
+
+func (adb *accountsDB) computeDataRootHash(dt *dataTrie) []byte {
+	buff := make([]byte, 0)
+	for _, key := range dt.sortedKeys() {
+		buff = appendWithLength(buff, []byte(key))
+		buff = appendWithLength(buff, dt.values[key])
+	}
+
+	return adb.hasher.Compute(string(buff))
+}
+
+func appendWithLength(buff []byte, data []byte) []byte {
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(data)))
+
+	buff = append(buff, length...)
+	return append(buff, data...)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (adb *accountsDB) IsInterfaceNil() bool {
+	return adb == nil
+}
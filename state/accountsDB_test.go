@@ -0,0 +1,264 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core"
+	"github.com/kalyan3104/k-core/core/check"
+	"github.com/kalyan3104/k-core/data/dct"
+	"github.com/kalyan3104/k-core/hashing/sha256"
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+	"github.com/kalyan3104/k-vm-common-go/builtInFunctions"
+	"github.com/kalyan3104/k-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAccountsDB() *accountsDB {
+	adb, _ := NewAccountsDB(ArgsNewAccountsDB{Hasher: sha256.NewSha256()})
+	return adb
+}
+
+func loadUserAccount(t *testing.T, adb *accountsDB, address []byte) *userAccount {
+	account, err := adb.LoadAccount(address)
+	require.Nil(t, err)
+
+	return account.(*userAccount)
+}
+
+func TestNewAccountsDB(t *testing.T) {
+	t.Parallel()
+
+	adb, err := NewAccountsDB(ArgsNewAccountsDB{})
+	assert.Equal(t, ErrNilHasher, err)
+	assert.True(t, check.IfNil(adb))
+
+	adb, err = NewAccountsDB(ArgsNewAccountsDB{Hasher: sha256.NewSha256()})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(adb))
+}
+
+func TestAccountsDB_LoadSaveAndGet(t *testing.T) {
+	t.Parallel()
+
+	adb := createAccountsDB()
+	address := []byte("address")
+
+	_, err := adb.GetExistingAccount(address)
+	assert.Equal(t, ErrAccountNotFound, err)
+
+	acc := loadUserAccount(t, adb, address)
+	_ = acc.AddToBalance(big.NewInt(100))
+	acc.SetCode([]byte("code"))
+
+	_, err = adb.GetExistingAccount(address)
+	assert.Equal(t, ErrAccountNotFound, err, "changes must not be visible before save")
+
+	err = adb.SaveAccount(acc)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, adb.JournalLen())
+
+	existing, err := adb.GetExistingAccount(address)
+	assert.Nil(t, err)
+	existingAcc := existing.(*userAccount)
+	assert.Equal(t, big.NewInt(100), existingAcc.GetBalance())
+	assert.Equal(t, []byte("code"), adb.GetCode(existingAcc.GetCodeHash()))
+
+	err = adb.SaveAccount(&mock.UserAccountStub{})
+	assert.Equal(t, ErrWrongTypeAssertion, err)
+
+	err = adb.SaveAccount(nil)
+	assert.Equal(t, ErrNilAccount, err)
+}
+
+func TestAccountsDB_SaveAccountShouldNotChangeProvidedAccount(t *testing.T) {
+	t.Parallel()
+
+	adb := createAccountsDB()
+	address := []byte("address")
+
+	acc := loadUserAccount(t, adb, address)
+	acc.SetCode([]byte("code"))
+	_ = acc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+
+	err := adb.SaveAccount(acc)
+	assert.Nil(t, err)
+	assert.Nil(t, acc.GetCodeHash())
+	assert.Nil(t, acc.GetRootHash())
+
+	saved := loadUserAccount(t, adb, address)
+	assert.NotEmpty(t, saved.GetCodeHash())
+	assert.NotEmpty(t, saved.GetRootHash())
+
+	saved.SetCode(nil)
+	err = adb.SaveAccount(saved)
+	assert.Nil(t, err)
+
+	existing, _ := adb.GetExistingAccount(address)
+	assert.Nil(t, existing.(*userAccount).GetCodeHash())
+}
+
+func TestAccountsDB_RemoveAccount(t *testing.T) {
+	t.Parallel()
+
+	adb := createAccountsDB()
+	address := []byte("address")
+
+	err := adb.RemoveAccount(address)
+	assert.Equal(t, ErrAccountNotFound, err)
+
+	_ = adb.SaveAccount(loadUserAccount(t, adb, address))
+	err = adb.RemoveAccount(address)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, adb.JournalLen())
+
+	_, err = adb.GetExistingAccount(address)
+	assert.Equal(t, ErrAccountNotFound, err)
+}
+
+func TestAccountsDB_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	adb := createAccountsDB()
+	address := []byte("address")
+
+	acc := loadUserAccount(t, adb, address)
+	_ = acc.AddToBalance(big.NewInt(10))
+	_ = adb.SaveAccount(acc)
+	snapshot := adb.JournalLen()
+	rootHashBefore, _ := adb.RootHash()
+
+	acc = loadUserAccount(t, adb, address)
+	_ = acc.AddToBalance(big.NewInt(10))
+	_ = acc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+	_ = adb.SaveAccount(acc)
+	_ = adb.SaveAccount(loadUserAccount(t, adb, []byte("other")))
+	_ = adb.RemoveAccount(address)
+
+	err := adb.RevertToSnapshot(adb.JournalLen() + 1)
+	assert.Equal(t, ErrInvalidSnapshot, err)
+	err = adb.RevertToSnapshot(-1)
+	assert.Equal(t, ErrInvalidSnapshot, err)
+
+	err = adb.RevertToSnapshot(snapshot)
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot, adb.JournalLen())
+
+	reverted := loadUserAccount(t, adb, address)
+	assert.Equal(t, big.NewInt(10), reverted.GetBalance())
+	value, _, _ := reverted.AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Len(t, value, 0)
+
+	_, err = adb.GetExistingAccount([]byte("other"))
+	assert.Equal(t, ErrAccountNotFound, err)
+
+	rootHashAfter, _ := adb.RootHash()
+	assert.Equal(t, rootHashBefore, rootHashAfter)
+
+	err = adb.RevertToSnapshot(0)
+	assert.Nil(t, err)
+	_, err = adb.GetExistingAccount(address)
+	assert.Equal(t, ErrAccountNotFound, err)
+}
+
+func TestAccountsDB_CommitAndRootHashShouldBeDeterministic(t *testing.T) {
+	t.Parallel()
+
+	populate := func(adb *accountsDB, addresses [][]byte) {
+		for i, address := range addresses {
+			acc := loadUserAccount(t, adb, address)
+			_ = acc.AddToBalance(big.NewInt(int64(len(address))))
+			_ = acc.AccountDataHandler().SaveKeyValue([]byte("key"), address)
+			_ = acc.AccountDataHandler().SaveKeyValue(address, []byte{byte(i)})
+			_ = adb.SaveAccount(acc)
+		}
+	}
+
+	first := createAccountsDB()
+	populate(first, [][]byte{[]byte("alice"), []byte("bob"), []byte("carol")})
+	second := createAccountsDB()
+	populate(second, [][]byte{[]byte("alice"), []byte("bob"), []byte("carol")})
+
+	firstRootHash, err := first.Commit()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, first.JournalLen())
+	secondRootHash, _ := second.RootHash()
+	assert.Equal(t, firstRootHash, secondRootHash)
+
+	acc := loadUserAccount(t, second, []byte("bob"))
+	acc.IncreaseNonce(1)
+	_ = second.SaveAccount(acc)
+	secondRootHash, _ = second.RootHash()
+	assert.NotEqual(t, firstRootHash, secondRootHash)
+
+	emptyRootHash, _ := createAccountsDB().RootHash()
+	assert.NotEqual(t, firstRootHash, emptyRootHash)
+}
+
+func TestAccountsDB_ShouldRunBuiltInFunctionsEndToEnd(t *testing.T) {
+	t.Parallel()
+
+	adb := createAccountsDB()
+	marshaller := &mock.MarshalizerMock{}
+	gasMap := mock.CreateGasMap(1)
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                           gasMap,
+		MapDNSAddresses:                  make(map[string]struct{}),
+		Marshalizer:                      marshaller,
+		Accounts:                         adb,
+		ShardCoordinator:                 mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler:              &mock.EnableEpochsHandlerStub{},
+		MaxNumOfAddressesForTransferRole: 100,
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	tokenID := []byte("TKN-abcdef")
+	tokenKey := append([]byte(core.ProtectedKeyPrefix+core.DCTKeyIdentifier), tokenID...)
+	senderAddress := []byte("sender-address-0000000000000000")
+	receiverAddress := []byte("receiver-address-00000000000000")
+
+	sender := loadUserAccount(t, adb, senderAddress)
+	marshaledData, _ := marshaller.Marshal(&dct.DCToken{Value: big.NewInt(100)})
+	_ = sender.AccountDataHandler().SaveKeyValue(tokenKey, marshaledData)
+	require.Nil(t, adb.SaveAccount(sender))
+	snapshot := adb.JournalLen()
+
+	transferFunc, err := creator.BuiltInFunctionContainer().Get(core.BuiltInFunctionDCTTransfer)
+	require.Nil(t, err)
+
+	sender = loadUserAccount(t, adb, senderAddress)
+	receiver := loadUserAccount(t, adb, receiverAddress)
+	vmOutput, err := transferFunc.ProcessBuiltinFunction(sender, receiver, &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  senderAddress,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{tokenID, big.NewInt(40).Bytes()},
+			GasProvided: 100,
+		},
+		RecipientAddr: receiverAddress,
+		Function:      core.BuiltInFunctionDCTTransfer,
+	})
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Nil(t, adb.SaveAccount(sender))
+	require.Nil(t, adb.SaveAccount(receiver))
+
+	checkTokenBalance := func(address []byte, expected int64) {
+		account := loadUserAccount(t, adb, address)
+		dctData := &dct.DCToken{Value: big.NewInt(0)}
+		marshaledValue, _, _ := account.AccountDataHandler().RetrieveValue(tokenKey)
+		if len(marshaledValue) > 0 {
+			_ = marshaller.Unmarshal(dctData, marshaledValue)
+		}
+		assert.Equal(t, big.NewInt(expected), dctData.Value)
+	}
+	checkTokenBalance(senderAddress, 60)
+	checkTokenBalance(receiverAddress, 40)
+
+	require.Nil(t, adb.RevertToSnapshot(snapshot))
+	checkTokenBalance(senderAddress, 100)
+	checkTokenBalance(receiverAddress, 0)
+}
@@ -0,0 +1,72 @@
+package state
+
+import (
+	"sort"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.AccountDataHandler = (*dataTrie)(nil)
+
+// dataTrie is an in-memory key-value store holding the data of one account
+type dataTrie struct {
+	values map[string][]byte
+}
+
+func newDataTrie() *dataTrie {
+	return &dataTrie{
+		values: make(map[string][]byte),
+	}
+}
+
+// RetrieveValue returns the value saved under the provided key. A missing key yields an empty value
+func (dt *dataTrie) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	return dt.values[string(key)], 0, nil
+}
+
+// SaveKeyValue saves the value under the provided key. An empty value removes the key
+func (dt *dataTrie) SaveKeyValue(key []byte, value []byte) error {
+	if len(value) == 0 {
+		delete(dt.values, string(key))
+		return nil
+	}
+
+	dt.values[string(key)] = cloneBytes(value)
+	return nil
+}
+
+func (dt *dataTrie) sortedKeys() []string {
+	keys := make([]string, 0, len(dt.values))
+	for key := range dt.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (dt *dataTrie) clone() *dataTrie {
+	values := make(map[string][]byte, len(dt.values))
+	for key, value := range dt.values {
+		values[key] = cloneBytes(value)
+	}
+
+	return &dataTrie{
+		values: values,
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dt *dataTrie) IsInterfaceNil() bool {
+	return dt == nil
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	clone := make([]byte, len(b))
+	copy(clone, b)
+	return clone
+}
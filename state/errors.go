@@ -0,0 +1,33 @@
+package state
+
+import "errors"
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrNilAddress signals that a nil or empty address has been provided
+var ErrNilAddress = errors.New("nil address")
+
+// ErrNilAccount signals that a nil account has been provided
+var ErrNilAccount = errors.New("nil account")
+
+// ErrAccountNotFound signals that the requested account does not exist
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrWrongTypeAssertion signals that a type assertion failed
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrInvalidSnapshot signals that the provided snapshot is out of the journal bounds
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// ErrInsufficientFunds signals that the account balance would become negative
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrNilValue signals that a nil value has been provided
+var ErrNilValue = errors.New("nil value")
+
+// ErrOperationNotPermitted signals that the operation is not permitted on the account
+var ErrOperationNotPermitted = errors.New("operation in account not permitted")
+
+// ErrInvalidAddressLength signals that the provided address has an invalid length
+var ErrInvalidAddressLength = errors.New("invalid address length")
@@ -0,0 +1,194 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+
+	vmcommon "github.com/kalyan3104/k-vm-common-go"
+)
+
+var _ vmcommon.UserAccountHandler = (*userAccount)(nil)
+
+// userAccount is the in-memory account handled by the accounts adapter
+type userAccount struct {
+	address         []byte
+	nonce           uint64
+	balance         *big.Int
+	developerReward *big.Int
+	ownerAddress    []byte
+	userName        []byte
+	code            []byte
+	codeHash        []byte
+	codeMetadata    []byte
+	rootHash        []byte
+	dataTrie        *dataTrie
+}
+
+// NewUserAccount creates a new empty account for the provided address
+func NewUserAccount(address []byte) (*userAccount, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+
+	return &userAccount{
+		address:         cloneBytes(address),
+		balance:         big.NewInt(0),
+		developerReward: big.NewInt(0),
+		dataTrie:        newDataTrie(),
+	}, nil
+}
+
+// AddressBytes returns the address of the account
+func (a *userAccount) AddressBytes() []byte {
+	return a.address
+}
+
+// IncreaseNonce adds the provided value to the current nonce
+func (a *userAccount) IncreaseNonce(value uint64) {
+	a.nonce += value
+}
+
+// GetNonce returns the account nonce
+func (a *userAccount) GetNonce() uint64 {
+	return a.nonce
+}
+
+// AddToBalance adds the provided value to the balance. The balance can not become negative
+func (a *userAccount) AddToBalance(value *big.Int) error {
+	if value == nil {
+		return ErrNilValue
+	}
+
+	newBalance := big.NewInt(0).Add(a.balance, value)
+	if newBalance.Sign() < 0 {
+		return ErrInsufficientFunds
+	}
+
+	a.balance = newBalance
+	return nil
+}
+
+// GetBalance returns the account balance
+func (a *userAccount) GetBalance() *big.Int {
+	return big.NewInt(0).Set(a.balance)
+}
+
+// AddToDeveloperReward adds the provided value to the developer reward
+func (a *userAccount) AddToDeveloperReward(value *big.Int) {
+	if value == nil {
+		return
+	}
+
+	a.developerReward = big.NewInt(0).Add(a.developerReward, value)
+}
+
+// ClaimDeveloperRewards resets the developer reward and returns its old value, if the sender is the owner
+func (a *userAccount) ClaimDeveloperRewards(sender []byte) (*big.Int, error) {
+	if !bytes.Equal(sender, a.ownerAddress) {
+		return nil, ErrOperationNotPermitted
+	}
+
+	oldValue := a.developerReward
+	a.developerReward = big.NewInt(0)
+
+	return oldValue, nil
+}
+
+// GetDeveloperReward returns the developer reward
+func (a *userAccount) GetDeveloperReward() *big.Int {
+	return big.NewInt(0).Set(a.developerReward)
+}
+
+// ChangeOwnerAddress changes the owner address, if the sender is the current owner
+func (a *userAccount) ChangeOwnerAddress(sender []byte, newAddress []byte) error {
+	if !bytes.Equal(sender, a.ownerAddress) {
+		return ErrOperationNotPermitted
+	}
+	if len(newAddress) != len(a.address) {
+		return ErrInvalidAddressLength
+	}
+
+	a.ownerAddress = cloneBytes(newAddress)
+	return nil
+}
+
+// SetOwnerAddress sets the owner address
+func (a *userAccount) SetOwnerAddress(address []byte) {
+	a.ownerAddress = cloneBytes(address)
+}
+
+// GetOwnerAddress returns the owner address
+func (a *userAccount) GetOwnerAddress() []byte {
+	return a.ownerAddress
+}
+
+// SetUserName sets the user name
+func (a *userAccount) SetUserName(userName []byte) {
+	a.userName = cloneBytes(userName)
+}
+
+// GetUserName returns the user name
+func (a *userAccount) GetUserName() []byte {
+	return a.userName
+}
+
+// SetCode sets the code of the account. The code hash is computed when the account is saved
+func (a *userAccount) SetCode(code []byte) {
+	a.code = cloneBytes(code)
+}
+
+// GetCode returns the code of the account
+func (a *userAccount) GetCode() []byte {
+	return a.code
+}
+
+// GetCodeHash returns the code hash
+func (a *userAccount) GetCodeHash() []byte {
+	return a.codeHash
+}
+
+// SetCodeMetadata sets the code metadata
+func (a *userAccount) SetCodeMetadata(codeMetadata []byte) {
+	a.codeMetadata = cloneBytes(codeMetadata)
+}
+
+// GetCodeMetadata returns the code metadata
+func (a *userAccount) GetCodeMetadata() []byte {
+	return a.codeMetadata
+}
+
+// GetRootHash returns the root hash of the data trie, as computed on the last save
+func (a *userAccount) GetRootHash() []byte {
+	return a.rootHash
+}
+
+// AccountDataHandler returns the handler of the account data
+func (a *userAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return a.dataTrie
+}
+
+// GetAllData returns a copy of all the key-value pairs saved in the account data
+func (a *userAccount) GetAllData() map[string][]byte {
+	return a.dataTrie.clone().values
+}
+
+func (a *userAccount) clone() *userAccount {
+	return &userAccount{
+		address:         cloneBytes(a.address),
+		nonce:           a.nonce,
+		balance:         big.NewInt(0).Set(a.balance),
+		developerReward: big.NewInt(0).Set(a.developerReward),
+		ownerAddress:    cloneBytes(a.ownerAddress),
+		userName:        cloneBytes(a.userName),
+		code:            cloneBytes(a.code),
+		codeHash:        cloneBytes(a.codeHash),
+		codeMetadata:    cloneBytes(a.codeMetadata),
+		rootHash:        cloneBytes(a.rootHash),
+		dataTrie:        a.dataTrie.clone(),
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (a *userAccount) IsInterfaceNil() bool {
+	return a == nil
+}
@@ -0,0 +1,115 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-core/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserAccount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty address should error", func(t *testing.T) {
+		t.Parallel()
+
+		acc, err := NewUserAccount(nil)
+		assert.Equal(t, ErrNilAddress, err)
+		assert.True(t, check.IfNil(acc))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		acc, err := NewUserAccount([]byte("address"))
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(acc))
+		assert.Equal(t, []byte("address"), acc.AddressBytes())
+		assert.Equal(t, big.NewInt(0), acc.GetBalance())
+		assert.Equal(t, big.NewInt(0), acc.GetDeveloperReward())
+	})
+}
+
+func TestUserAccount_AddToBalance(t *testing.T) {
+	t.Parallel()
+
+	acc, _ := NewUserAccount([]byte("address"))
+
+	err := acc.AddToBalance(nil)
+	assert.Equal(t, ErrNilValue, err)
+
+	err = acc.AddToBalance(big.NewInt(10))
+	assert.Nil(t, err)
+
+	err = acc.AddToBalance(big.NewInt(-11))
+	assert.Equal(t, ErrInsufficientFunds, err)
+	assert.Equal(t, big.NewInt(10), acc.GetBalance())
+
+	err = acc.AddToBalance(big.NewInt(-10))
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), acc.GetBalance())
+}
+
+func TestUserAccount_OwnerOperations(t *testing.T) {
+	t.Parallel()
+
+	owner := []byte("owner-address-1")
+	acc, _ := NewUserAccount([]byte("contract-addr-1"))
+	acc.SetOwnerAddress(owner)
+	acc.AddToDeveloperReward(big.NewInt(7))
+
+	_, err := acc.ClaimDeveloperRewards([]byte("not the owner"))
+	assert.Equal(t, ErrOperationNotPermitted, err)
+
+	reward, err := acc.ClaimDeveloperRewards(owner)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(7), reward)
+	assert.Equal(t, big.NewInt(0), acc.GetDeveloperReward())
+
+	err = acc.ChangeOwnerAddress(owner, []byte("short"))
+	assert.Equal(t, ErrInvalidAddressLength, err)
+
+	err = acc.ChangeOwnerAddress([]byte("not the owner"), []byte("new-owner-addr1"))
+	assert.Equal(t, ErrOperationNotPermitted, err)
+
+	err = acc.ChangeOwnerAddress(owner, []byte("new-owner-addr1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("new-owner-addr1"), acc.GetOwnerAddress())
+}
+
+func TestUserAccount_AccountDataHandler(t *testing.T) {
+	t.Parallel()
+
+	acc, _ := NewUserAccount([]byte("address"))
+	dataHandler := acc.AccountDataHandler()
+
+	value, _, err := dataHandler.RetrieveValue([]byte("key"))
+	assert.Nil(t, err)
+	assert.Len(t, value, 0)
+
+	_ = dataHandler.SaveKeyValue([]byte("key"), []byte("value"))
+	value, _, _ = dataHandler.RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), value)
+
+	_ = dataHandler.SaveKeyValue([]byte("key"), nil)
+	value, _, _ = dataHandler.RetrieveValue([]byte("key"))
+	assert.Len(t, value, 0)
+	assert.Len(t, acc.dataTrie.values, 0)
+}
+
+func TestUserAccount_CloneShouldNotAlias(t *testing.T) {
+	t.Parallel()
+
+	acc, _ := NewUserAccount([]byte("address"))
+	_ = acc.AddToBalance(big.NewInt(5))
+	_ = acc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value"))
+
+	clone := acc.clone()
+	_ = clone.AddToBalance(big.NewInt(5))
+	_ = clone.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("changed"))
+
+	value, _, _ := acc.AccountDataHandler().RetrieveValue([]byte("key"))
+	require.Equal(t, []byte("value"), value)
+	require.Equal(t, big.NewInt(5), acc.GetBalance())
+}
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/kalyan3104/k-core/core"
+	"github.com/kalyan3104/k-vm-common-go/abi"
 )
 
 // txDataBuilder constructs a string to be used for transaction arguments
@@ -137,6 +138,17 @@ func (builder *txDataBuilder) BigInt(value *big.Int) *txDataBuilder {
 	return builder.Bytes(value.Bytes())
 }
 
+// Typed appends a value encoded as a whole argument of the provided ABI type. The data string is not changed if the
+// value can not be encoded.
+func (builder *txDataBuilder) Typed(valueType abi.Type, value interface{}) (*txDataBuilder, error) {
+	encoded, err := abi.EncodeTopLevel(valueType, value)
+	if err != nil {
+		return builder, err
+	}
+
+	return builder.Bytes(encoded), nil
+}
+
 // IssueDCT appends to the data string all the elements required to request an DCT issuing.
 func (builder *txDataBuilder) IssueDCT(token string, ticker string, supply int64, numDecimals byte) *txDataBuilder {
 	return builder.Func("issue").Str(token).Str(ticker).Int64(supply).Byte(numDecimals)
@@ -0,0 +1,65 @@
+package vmcommon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// VMError is an execution failure carrying the code the VM ends the execution with. ErrorCode is a stable numeric
+// identifier of the failure, Function is the name of the built-in function which failed, if any, while Err is the
+// wrapped cause, so that the failure can still be checked with errors.Is
+type VMError struct {
+	ReturnCode ReturnCode
+	ErrorCode  uint32
+	Function   string
+	Err        error
+}
+
+// NewVMError creates a new VMError wrapping the cause
+func NewVMError(returnCode ReturnCode, errorCode uint32, function string, cause error) *VMError {
+	return &VMError{
+		ReturnCode: returnCode,
+		ErrorCode:  errorCode,
+		Function:   function,
+		Err:        cause,
+	}
+}
+
+// Error returns the description of the failure, prefixed by the name of the function, if any
+func (e *VMError) Error() string {
+	if len(e.Function) == 0 {
+		return fmt.Sprintf("%v", e.Err)
+	}
+
+	return fmt.Sprintf("%s: %v", e.Function, e.Err)
+}
+
+// Unwrap returns the cause of the failure
+func (e *VMError) Unwrap() error {
+	return e.Err
+}
+
+type returnCodeHandler interface {
+	ReturnCode() ReturnCode
+}
+
+// ReturnCodeFromError returns the code a VM should end the execution with for the provided error. A nil error means
+// Ok, a VMError or an input validation error provides its own code, while any other error means ExecutionFailed. The
+// errors of the built-in functions are mapped to their catalog codes by builtInFunctions.ReturnCodeFromError
+func ReturnCodeFromError(err error) ReturnCode {
+	if err == nil {
+		return Ok
+	}
+
+	var vmErr *VMError
+	if errors.As(err, &vmErr) {
+		return vmErr.ReturnCode
+	}
+
+	var handler returnCodeHandler
+	if errors.As(err, &handler) {
+		return handler.ReturnCode()
+	}
+
+	return ExecutionFailed
+}
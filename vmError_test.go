@@ -0,0 +1,47 @@
+package vmcommon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMError(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("cause")
+	vmErr := NewVMError(OutOfGas, 10, "function", cause)
+	assert.Equal(t, "function: cause", vmErr.Error())
+	assert.True(t, errors.Is(vmErr, cause))
+
+	vmErr = NewVMError(OutOfGas, 10, "", cause)
+	assert.Equal(t, "cause", vmErr.Error())
+
+	var unwrapped *VMError
+	require.True(t, errors.As(fmt.Errorf("wrapped: %w", vmErr), &unwrapped))
+	assert.Equal(t, uint32(10), unwrapped.ErrorCode)
+}
+
+func TestReturnCodeFromError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Ok, ReturnCodeFromError(nil))
+	assert.Equal(t, ExecutionFailed, ReturnCodeFromError(errors.New("plain error")))
+	assert.Equal(t, OutOfFunds, ReturnCodeFromError(NewVMError(OutOfFunds, 2, "function", errors.New("cause"))))
+	assert.Equal(t, OutOfFunds, ReturnCodeFromError(fmt.Errorf("wrapped: %w", NewVMError(OutOfFunds, 2, "", nil))))
+	assert.Equal(t, OutOfGas, ReturnCodeFromError(&InputValidationError{Field: "GasLocked", Err: ErrGasLockedExceedsGasProvided}))
+}
+
+func TestReturnCode_String(t *testing.T) {
+	t.Parallel()
+
+	for rc := Ok; rc <= SimulateFailed; rc++ {
+		assert.NotContains(t, rc.String(), "unknown error", rc)
+	}
+	assert.Equal(t, "upgrade failed", UpgradeFailed.String())
+	assert.Equal(t, "simulate failed", SimulateFailed.String())
+	assert.Equal(t, "unknown error, code: 13", ReturnCode(13).String())
+}